@@ -1,13 +1,35 @@
 package main
 
 import (
+	"crypto"
+	"fmt"
+	"log"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/alerts"
+	authn "github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/events"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/db"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/db/postgres"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/memory"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/scheduler"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/cli"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/graphql"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/grpc"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/http"
 )
 
@@ -31,34 +53,654 @@ func ProductStockRepositoryFactory(repoType RepositoryType) repository.IProductS
 type HandlerType string
 
 const (
-	HTTP HandlerType = "HTTP"
+	HTTP    HandlerType = "HTTP"
+	GRPC    HandlerType = "GRPC"
+	GRAPHQL HandlerType = "GRAPHQL"
+	CLI     HandlerType = "CLI"
 )
 
-func AppHandlerFactory(handlerType HandlerType, paginationConfig domain.PaginationConfig, repo repository.IProductStockRepository) domain.App {
+// NewHandlerTypes reads a comma separated list of handler types, so the
+// HTTP, gRPC and GraphQL servers can run side by side. CLI runs a single
+// command and exits, so it cannot be combined with the servers.
+func NewHandlerTypes(handlerTypesStr string) []HandlerType {
+	var handlerTypes []HandlerType
+	for raw := range strings.SplitSeq(handlerTypesStr, ",") {
+		handlerType := HandlerType(strings.ToUpper(strings.TrimSpace(raw)))
+		if handlerType != HTTP && handlerType != GRPC && handlerType != GRAPHQL && handlerType != CLI {
+			panic("invalid handler type")
+		}
+
+		if !slices.Contains(handlerTypes, handlerType) {
+			handlerTypes = append(handlerTypes, handlerType)
+		}
+	}
+
+	if slices.Contains(handlerTypes, CLI) && len(handlerTypes) > 1 {
+		panic("the CLI handler type cannot be combined with others")
+	}
+
+	return handlerTypes
+}
+
+// apps runs several apps at once. Each of them stops the process when it
+// fails, so Run only waits.
+type apps []domain.App
+
+func (a apps) Run() {
+	var wg sync.WaitGroup
+	for _, app := range a {
+		wg.Go(app.Run)
+	}
+	wg.Wait()
+}
+
+const (
+	defaultIdempotencyKeyTTL   = 24 * time.Hour
+	defaultSoftDeleteRetention = 30 * 24 * time.Hour
+	stockStreamPollInterval    = time.Second
+)
+
+func AppHandlerFactory(handlerTypes []HandlerType, idempotencyKeyTTL, softDeleteRetention time.Duration, authUC *usecases.AuthenticateUseCase, tenants repository.ITenantRepository, repo repository.IProductStockRepository, alertChannels []alert.IChannel, jobs *Jobs) domain.App {
+	refreshSnapshotUC := usecases.NewRefreshRestockPrioritySnapshotUseCase(repo)
 	createUC := usecases.NewCreateProductStockUseCase(repo)
-	getAllUC := usecases.NewGetAllProductStockUseCase(repo, paginationConfig)
+	getAllUC := usecases.NewGetAllProductStockUseCase(repo)
 	getOneUC := usecases.NewGetOneProductStockUseCase(repo)
 	updateUC := usecases.NewUpdateProductStockUseCase(repo)
 	deleteUC := usecases.NewDeleteProductStockUseCase(repo)
-	getByCategoryUC := usecases.NewGetByCategoryProductStockUseCase(repo, paginationConfig)
-	getPriorityUC := usecases.NewGetProductPriorityUseCase(repo, paginationConfig)
-
-	switch handlerType {
-	case HTTP:
-		productStockHandler := http.NewProductStockHandler(
-			createUC,
-			getAllUC,
-			getOneUC,
-			updateUC,
-			deleteUC,
-			getByCategoryUC,
-			getPriorityUC,
-		)
-
-		return http.NewGinApp(productStockHandler)
-	default:
-		panic("invalid handler type")
+	getByCategoryUC := usecases.NewGetByCategoryProductStockUseCase(repo)
+	getPriorityUC := usecases.NewGetProductPriorityUseCase(repo)
+	restockPlanUC := usecases.NewCreateRestockPlanUseCase(repo)
+	simulateUC := usecases.NewSimulateInventoryUseCase(repo)
+	stockoutRiskUC := usecases.NewEstimateStockoutRiskUseCase(repo)
+	searchUC := usecases.NewSearchProductStockUseCase(repo)
+	getBySKUUC := usecases.NewGetBySKUProductStockUseCase(repo)
+	getByBarcodeUC := usecases.NewGetByBarcodeProductStockUseCase(repo)
+	importUC := usecases.NewImportProductStockUseCase(repo)
+	exportUC := usecases.NewExportProductStockUseCase(getAllUC)
+	exportRestockUC := usecases.NewExportRestockPrioritiesUseCase(getPriorityUC)
+	batchUC := usecases.NewBatchProductStockUseCase(repo, createUC, updateUC, deleteUC)
+	restoreUC := usecases.NewRestoreProductStockUseCase(repo)
+	auditLogUC := usecases.NewGetAuditLogUseCase(repo)
+	countByCategoryUC := usecases.NewCountByCategoryProductStockUseCase(repo)
+	historyUC := usecases.NewGetProductStockHistoryUseCase(repo)
+	createWebhookUC := usecases.NewCreateWebhookSubscriptionUseCase(repo)
+	getWebhooksUC := usecases.NewGetWebhookSubscriptionsUseCase(repo)
+	deleteWebhookUC := usecases.NewDeleteWebhookSubscriptionUseCase(repo)
+	webhookDeliveriesUC := usecases.NewGetWebhookDeliveriesUseCase(repo)
+	redeliverWebhookUC := usecases.NewRedeliverWebhookUseCase(repo)
+	createAlertRuleUC := usecases.NewCreateAlertRuleUseCase(repo, alertChannels)
+	getAlertRulesUC := usecases.NewGetAlertRulesUseCase(repo)
+	deleteAlertRuleUC := usecases.NewDeleteAlertRuleUseCase(repo)
+	getAlertsUC := usecases.NewGetAlertsUseCase(repo)
+	testAlertChannelUC := usecases.NewTestAlertChannelUseCase(alertChannels)
+
+	idempotencyRepo, ok := repo.(repository.IIdempotencyKeyRepository)
+	if !ok {
+		idempotencyRepo = memory.NewIdempotencyKeyRepository()
+	}
+	idempotencyUC := usecases.NewIdempotentRequestUseCase(idempotencyRepo, idempotencyKeyTTL)
+	resolveTenantUC := usecases.NewResolveTenantUseCase(tenants)
+	getJobsUC := usecases.NewGetJobsUseCase(jobs.repo, jobs.schedule)
+	getJobRunsUC := usecases.NewGetJobRunsUseCase(jobs.repo, jobs.schedule)
+
+	purgeDeletedUC := usecases.NewPurgeDeletedProductStockUseCase(repo, tenants, softDeleteRetention)
+	jobs.Add(PurgeDeletedProductsJob, "Deletes for good the products deleted longer than the retention ago", "@hourly", func() (string, *domain.Error) {
+		purged, err := purgeDeletedUC.Execute()
+		return fmt.Sprintf("purged %d deleted products", purged), err
+	})
+
+	jobs.Add(PurgeIdempotencyKeysJob, "Deletes the expired idempotency keys", "@hourly", func() (string, *domain.Error) {
+		purged, err := idempotencyUC.PurgeExpired()
+		return fmt.Sprintf("purged %d expired idempotency keys", purged), err
+	})
+
+	if _, ok := repo.(repository.IAuditLogRepository); ok {
+		recomputeSalesUC := usecases.NewRecomputeAverageDailySalesUseCase(repo, tenants, updateUC)
+		jobs.Add(RecomputeAverageDailySalesJob, "Sets the average daily sales of the products to the stock taken out of them per day over the last 30 days", "0 2 * * *", func() (string, *domain.Error) {
+			updated, err := recomputeSalesUC.Execute()
+			return fmt.Sprintf("updated the average daily sales of %d products", updated), err
+		})
+	}
+
+	if len(alertChannels) > 0 {
+		digestUC := usecases.NewSendRestockDigestUseCase(tenants, getPriorityUC, alertChannels)
+		jobs.Add(SendRestockDigestJob, "Sends the most urgent restock priorities of every tenant through the alert channels", "0 8 * * *", func() (string, *domain.Error) {
+			sent, err := digestUC.Execute()
+			return fmt.Sprintf("sent %d restock digests", sent), err
+		})
+	}
+
+	var handlers apps
+	for _, handlerType := range handlerTypes {
+		switch handlerType {
+		case HTTP:
+			streamUC := usecases.NewStockStreamUseCase(repo)
+			go runEvery(stockStreamPollInterval, streamUC.Poll)
+
+			httpHandlers := http.Handlers{
+				ProductStock: http.NewProductStockHandler(
+					createUC,
+					getAllUC,
+					getOneUC,
+					updateUC,
+					deleteUC,
+					restoreUC,
+					getByCategoryUC,
+				),
+				Search:  http.NewSearchHandler(searchUC, getBySKUUC, getByBarcodeUC),
+				Import:  http.NewImportHandler(importUC),
+				Export:  http.NewExportHandler(exportUC, exportRestockUC),
+				Batch:   http.NewBatchHandler(batchUC),
+				Restock: http.NewRestockHandler(getPriorityUC, refreshSnapshotUC, restockPlanUC, simulateUC, stockoutRiskUC),
+				Audit:   http.NewAuditHandler(auditLogUC),
+				Stream:  http.NewStreamHandler(streamUC),
+				Webhooks: http.NewWebhookHandler(
+					createWebhookUC,
+					getWebhooksUC,
+					deleteWebhookUC,
+					webhookDeliveriesUC,
+					redeliverWebhookUC,
+				),
+				Alerts: http.NewAlertHandler(
+					createAlertRuleUC,
+					getAlertRulesUC,
+					deleteAlertRuleUC,
+					getAlertsUC,
+					testAlertChannelUC,
+				),
+				Jobs: http.NewJobHandler(getJobsUC, getJobRunsUC),
+			}
+
+			handlers = append(handlers, http.NewGinApp(httpHandlers, authUC, resolveTenantUC, idempotencyUC))
+		case GRPC:
+			productStockServer := grpc.NewProductStockServer(
+				createUC,
+				getAllUC,
+				getOneUC,
+				updateUC,
+				deleteUC,
+				getByCategoryUC,
+				getPriorityUC,
+				exportUC,
+				exportRestockUC,
+			)
+
+			handlers = append(handlers, grpc.NewGrpcApp(productStockServer, authUC, resolveTenantUC))
+		case GRAPHQL:
+			resolver := graphql.NewResolver(
+				createUC,
+				getAllUC,
+				getOneUC,
+				updateUC,
+				deleteUC,
+				restoreUC,
+				getByCategoryUC,
+				countByCategoryUC,
+				getPriorityUC,
+				historyUC,
+			)
+
+			handlers = append(handlers, graphql.NewGraphQLApp(resolver, authUC, resolveTenantUC))
+		case CLI:
+			commands := cli.NewProductStockCommands(
+				createUC,
+				getAllUC,
+				getOneUC,
+				updateUC,
+				deleteUC,
+				getPriorityUC,
+				importUC,
+				exportUC,
+				exportRestockUC,
+			)
+
+			handlers = append(handlers, cli.NewCLIApp(os.Args[1:], commands, resolveTenantUC))
+		default:
+			panic("invalid handler type")
+		}
+	}
+
+	if len(handlers) == 1 {
+		return handlers[0]
+	}
+
+	return handlers
+}
+
+type AuthConfig struct {
+	JWTSecret   string
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+	APIKeys     string
+	Disabled    bool
+}
+
+func NewAuthConfig(jwtSecret, jwksFile, jwtIssuer, jwtAudience, apiKeys, disabledStr string) AuthConfig {
+	disabled := false
+	if disabledStr != "" {
+		var err error
+		if disabled, err = strconv.ParseBool(disabledStr); err != nil {
+			panic("bad auth disabled configuration")
+		}
+	}
+
+	return AuthConfig{
+		JWTSecret:   jwtSecret,
+		JWKSFile:    jwksFile,
+		JWTIssuer:   jwtIssuer,
+		JWTAudience: jwtAudience,
+		APIKeys:     apiKeys,
+		Disabled:    disabled,
+	}
+}
+
+// AuthenticatorFactory accepts bearer tokens when a JWT secret or JWKS file
+// is configured and API keys when any is listed. At least one of them is
+// required unless authentication is explicitly disabled.
+func AuthenticatorFactory(config AuthConfig) *usecases.AuthenticateUseCase {
+	if config.Disabled {
+		log.Println("authentication is disabled, every request is made as admin")
+		return usecases.NewAuthenticateUseCase(nil, nil, true)
 	}
+
+	var tokens auth.ITokenVerifier
+	if config.JWTSecret != "" || config.JWKSFile != "" {
+		var keys map[string]crypto.PublicKey
+		if config.JWKSFile != "" {
+			var err error
+			if keys, err = authn.LoadJWKS(config.JWKSFile); err != nil {
+				panic("bad jwks configuration: " + err.Error())
+			}
+		}
+
+		verifier, err := authn.NewJWTVerifier([]byte(config.JWTSecret), keys, config.JWTIssuer, config.JWTAudience)
+		if err != nil {
+			panic("bad jwt configuration: " + err.Error())
+		}
+		tokens = verifier
+	}
+
+	var apiKeys auth.IAPIKeyVerifier
+	if config.APIKeys != "" {
+		keys, err := authn.ParseAPIKeys(config.APIKeys)
+		if err != nil {
+			panic("bad api keys configuration: " + err.Error())
+		}
+
+		verifier, err := authn.NewStaticAPIKeyVerifier(keys)
+		if err != nil {
+			panic("bad api keys configuration: " + err.Error())
+		}
+		apiKeys = verifier
+	}
+
+	if tokens == nil && apiKeys == nil {
+		panic("no authentication configured: set AUTH_JWT_SECRET, AUTH_JWKS_FILE or AUTH_API_KEYS, or AUTH_DISABLED=true")
+	}
+
+	return usecases.NewAuthenticateUseCase(tokens, apiKeys, false)
+}
+
+// TenantRepositoryFactory loads the tenants of the tenants file. Without one
+// there is a single default tenant, with the global pagination config and
+// the built-in categories.
+func TenantRepositoryFactory(tenantsFile string, paginationConfig domain.PaginationConfig) repository.ITenantRepository {
+	if tenantsFile == "" {
+		return memory.NewTenantRepository([]tenant.Tenant{{
+			ID:         tenant.DefaultID,
+			Pagination: paginationConfig,
+			Categories: entities.DefaultProductCategories,
+		}})
+	}
+
+	tenants, err := memory.LoadTenants(tenantsFile, paginationConfig)
+	if err != nil {
+		panic("bad tenants configuration: " + err.Error())
+	}
+
+	return memory.NewTenantRepository(tenants)
+}
+
+type EventSinkType string
+
+const (
+	LogSink     EventSinkType = "LOG"
+	WebhookSink EventSinkType = "WEBHOOK"
+	NatsSink    EventSinkType = "NATS"
+)
+
+const (
+	defaultEventDispatchInterval = time.Second
+	defaultEventMaxAttempts      = 10
+	defaultWebhookMaxAttempts    = 10
+	defaultEventSubjectPrefix    = "stock"
+)
+
+type EventsConfig struct {
+	Sinks            []EventSinkType
+	WebhookURL       string
+	NatsURL          string
+	SubjectPrefix    string
+	DispatchInterval time.Duration
+	MaxAttempts      int
+	// WebhookMaxAttempts is the number of attempts after which a delivery to
+	// a webhook subscription is dead.
+	WebhookMaxAttempts int
+}
+
+func NewEventsConfig(sinksStr, webhookURL, natsURL, subjectPrefix, dispatchIntervalStr, maxAttemptsStr, webhookMaxAttemptsStr string) EventsConfig {
+	config := EventsConfig{
+		WebhookURL:         webhookURL,
+		NatsURL:            natsURL,
+		SubjectPrefix:      subjectPrefix,
+		DispatchInterval:   parseDurationConfig(dispatchIntervalStr, defaultEventDispatchInterval, "event dispatch interval"),
+		MaxAttempts:        defaultEventMaxAttempts,
+		WebhookMaxAttempts: defaultWebhookMaxAttempts,
+	}
+
+	for raw := range strings.SplitSeq(sinksStr, ",") {
+		sink := EventSinkType(strings.ToUpper(strings.TrimSpace(raw)))
+		if sink == "" {
+			continue
+		}
+
+		if sink != LogSink && sink != WebhookSink && sink != NatsSink {
+			panic("invalid event sink")
+		}
+
+		if !slices.Contains(config.Sinks, sink) {
+			config.Sinks = append(config.Sinks, sink)
+		}
+	}
+
+	if config.SubjectPrefix == "" {
+		config.SubjectPrefix = defaultEventSubjectPrefix
+	}
+
+	if maxAttemptsStr != "" {
+		maxAttempts, err := strconv.Atoi(maxAttemptsStr)
+		if err != nil || maxAttempts < 1 {
+			panic("bad event max attempts configuration")
+		}
+		config.MaxAttempts = maxAttempts
+	}
+
+	if webhookMaxAttemptsStr != "" {
+		webhookMaxAttempts, err := strconv.Atoi(webhookMaxAttemptsStr)
+		if err != nil || webhookMaxAttempts < 1 {
+			panic("bad webhook max attempts configuration")
+		}
+		config.WebhookMaxAttempts = webhookMaxAttempts
+	}
+
+	return config
+}
+
+func EventSinksFactory(config EventsConfig) []event.ISink {
+	var sinks []event.ISink
+	for _, sinkType := range config.Sinks {
+		switch sinkType {
+		case LogSink:
+			sinks = append(sinks, events.NewLogSink())
+		case WebhookSink:
+			sink, err := events.NewWebhookSink(config.WebhookURL)
+			if err != nil {
+				panic("bad event webhook configuration: " + err.Error())
+			}
+			sinks = append(sinks, sink)
+		case NatsSink:
+			publisher, err := events.NewNatsPublisher(config.NatsURL)
+			if err != nil {
+				panic("bad event nats configuration: " + err.Error())
+			}
+			sinks = append(sinks, events.NewBrokerSink(publisher, config.SubjectPrefix))
+		default:
+			panic("invalid event sink")
+		}
+	}
+
+	return sinks
+}
+
+// StartOutboxDispatcher relays the events of the outbox to the sinks in the
+// background, and delivers them to the webhook subscriptions and evaluates
+// the alert rules when the repository can keep them. Without sinks the
+// events wait in the outbox until some are configured; repositories without
+// an outbox raise no events.
+func StartOutboxDispatcher(repo repository.IProductStockRepository, sinks []event.ISink, alertChannels []alert.IChannel, config EventsConfig, jobs *Jobs) {
+	outbox, ok := repo.(repository.IOutboxRepository)
+	if !ok {
+		return
+	}
+
+	if _, ok := repo.(repository.IAlertRepository); ok {
+		sinks = append([]event.ISink{usecases.NewEvaluateAlertRulesUseCase(repo, alertChannels)}, sinks...)
+	}
+
+	// The subscriptions come first, so they get the events even while
+	// another sink fails.
+	if webhookRepo, ok := repo.(repository.IWebhookRepository); ok {
+		sinks = append([]event.ISink{usecases.NewEnqueueWebhookDeliveriesUseCase(repo)}, sinks...)
+
+		deliverUC := usecases.NewDeliverWebhooksUseCase(webhookRepo, events.NewHTTPSender(), config.WebhookMaxAttempts)
+		go runEvery(config.DispatchInterval, func() {
+			if _, err := deliverUC.Execute(); err != nil {
+				log.Printf("failed to deliver webhooks: %s", err.Message)
+			}
+		})
+	}
+
+	if len(sinks) == 0 {
+		return
+	}
+
+	dispatchUC := usecases.NewDispatchOutboxEventsUseCase(outbox, sinks, config.MaxAttempts)
+	go runEvery(config.DispatchInterval, func() {
+		if _, err := dispatchUC.Execute(); err != nil {
+			log.Printf("failed to dispatch events: %s", err.Message)
+		}
+	})
+	jobs.Add(PurgeDispatchedEventsJob, "Deletes the events dispatched longer than a week ago", "@hourly", func() (string, *domain.Error) {
+		purged, err := dispatchUC.PurgeDispatched()
+		return fmt.Sprintf("purged %d dispatched events", purged), err
+	})
+}
+
+type AlertChannelType string
+
+const (
+	LogAlertChannel   AlertChannelType = "LOG"
+	EmailAlertChannel AlertChannelType = "EMAIL"
+	SlackAlertChannel AlertChannelType = "SLACK"
+)
+
+type AlertsConfig struct {
+	Channels        []AlertChannelType
+	SMTPAddr        string
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPFrom        string
+	SMTPTo          string
+	SlackWebhookURL string
+}
+
+func NewAlertsConfig(channelsStr, smtpAddr, smtpUsername, smtpPassword, smtpFrom, smtpTo, slackWebhookURL string) AlertsConfig {
+	config := AlertsConfig{
+		SMTPAddr:        smtpAddr,
+		SMTPUsername:    smtpUsername,
+		SMTPPassword:    smtpPassword,
+		SMTPFrom:        smtpFrom,
+		SMTPTo:          smtpTo,
+		SlackWebhookURL: slackWebhookURL,
+	}
+
+	for raw := range strings.SplitSeq(channelsStr, ",") {
+		channel := AlertChannelType(strings.ToUpper(strings.TrimSpace(raw)))
+		if channel == "" {
+			continue
+		}
+
+		if channel != LogAlertChannel && channel != EmailAlertChannel && channel != SlackAlertChannel {
+			panic("invalid alert channel")
+		}
+
+		if !slices.Contains(config.Channels, channel) {
+			config.Channels = append(config.Channels, channel)
+		}
+	}
+
+	return config
+}
+
+// AlertChannelsFactory builds the channels alert rules may notify. Rules
+// cannot be created without any.
+func AlertChannelsFactory(config AlertsConfig) []alert.IChannel {
+	var channels []alert.IChannel
+	for _, channelType := range config.Channels {
+		switch channelType {
+		case LogAlertChannel:
+			channels = append(channels, alerts.NewLogChannel())
+		case EmailAlertChannel:
+			channel, err := alerts.NewSMTPChannel(config.SMTPAddr, config.SMTPUsername, config.SMTPPassword, config.SMTPFrom, config.SMTPTo)
+			if err != nil {
+				panic("bad alert email configuration: " + err.Error())
+			}
+			channels = append(channels, channel)
+		case SlackAlertChannel:
+			channel, err := alerts.NewSlackChannel(config.SlackWebhookURL)
+			if err != nil {
+				panic("bad alert slack configuration: " + err.Error())
+			}
+			channels = append(channels, channel)
+		default:
+			panic("invalid alert channel")
+		}
+	}
+
+	return channels
+}
+
+const (
+	PurgeDeletedProductsJob       = "purge_deleted_products"
+	PurgeIdempotencyKeysJob       = "purge_idempotency_keys"
+	PurgeDispatchedEventsJob      = "purge_dispatched_events"
+	PurgeJobRunsJob               = "purge_job_runs"
+	RecomputeAverageDailySalesJob = "recompute_average_daily_sales"
+	SendRestockDigestJob          = "send_restock_digest"
+
+	// jobOff as the schedule of a job turns it off.
+	jobOff          = "off"
+	jobRunRetention = 30 * 24 * time.Hour
+)
+
+var jobNames = []string{
+	PurgeDeletedProductsJob,
+	PurgeIdempotencyKeysJob,
+	PurgeDispatchedEventsJob,
+	PurgeJobRunsJob,
+	RecomputeAverageDailySalesJob,
+	SendRestockDigestJob,
+}
+
+// JobsConfig overrides the default schedules of the jobs, by name.
+type JobsConfig struct {
+	Schedules map[string]string
+}
+
+// NewJobsConfig reads name=schedule pairs separated by semicolons, as cron
+// expressions may hold spaces and commas.
+func NewJobsConfig(schedulesStr string) JobsConfig {
+	config := JobsConfig{Schedules: map[string]string{}}
+
+	for raw := range strings.SplitSeq(schedulesStr, ";") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		name, spec, ok := strings.Cut(raw, "=")
+		name, spec = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(spec)
+		if !ok || spec == "" {
+			panic("bad job schedules configuration")
+		}
+
+		if !slices.Contains(jobNames, name) {
+			panic("bad job schedules configuration: unknown job " + name)
+		}
+
+		config.Schedules[name] = spec
+	}
+
+	return config
+}
+
+// Jobs runs the background jobs on their schedules once started, each on a
+// single instance at a time.
+type Jobs struct {
+	config   JobsConfig
+	cron     *scheduler.CronScheduler
+	repo     repository.IJobRepository
+	schedule *usecases.ScheduleJobUseCase
+}
+
+// JobsFactory coordinates the instances through the repository when it can
+// keep the runs. Otherwise the runs are kept in memory and every instance
+// runs the jobs.
+func JobsFactory(repo repository.IProductStockRepository, config JobsConfig) *Jobs {
+	jobRepo, ok := repo.(repository.IJobRepository)
+	if !ok {
+		jobRepo = memory.NewJobRepository()
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	cron := scheduler.NewCronScheduler()
+	jobs := &Jobs{
+		config:   config,
+		cron:     cron,
+		repo:     jobRepo,
+		schedule: usecases.NewScheduleJobUseCase(jobRepo, cron, host+":"+strconv.Itoa(os.Getpid())),
+	}
+
+	purgeRunsUC := usecases.NewPurgeJobRunsUseCase(jobRepo, jobRunRetention)
+	jobs.Add(PurgeJobRunsJob, "Deletes the runs of the jobs finished longer than 30 days ago", "@daily", func() (string, *domain.Error) {
+		purged, err := purgeRunsUC.Execute()
+		return fmt.Sprintf("purged %d job runs", purged), err
+	})
+
+	return jobs
+}
+
+// Add schedules the job as configured, or by default on the given schedule.
+func (j *Jobs) Add(name, description, defaultSpec string, task job.Task) {
+	spec := defaultSpec
+	if configured, ok := j.config.Schedules[name]; ok {
+		spec = configured
+	}
+
+	if strings.EqualFold(spec, jobOff) {
+		return
+	}
+
+	schedule, err := scheduler.ParseSchedule(spec)
+	if err != nil {
+		panic("bad schedule of job " + name + ": " + err.Error())
+	}
+
+	j.schedule.Execute(job.Job{
+		Name:        name,
+		Description: description,
+		Spec:        spec,
+		Schedule:    schedule,
+		Task:        task,
+	})
+}
+
+func (j *Jobs) Start() {
+	j.cron.Start()
 }
 
 func NewPaginationConfig(paginationDefaultLimitStr, paginationMaxLimitStr string) domain.PaginationConfig {
@@ -78,3 +720,33 @@ func NewPaginationConfig(paginationDefaultLimitStr, paginationMaxLimitStr string
 		MaxLimit:     paginationMaxLimit,
 	}
 }
+
+func NewIdempotencyKeyTTL(idempotencyKeyTTLStr string) time.Duration {
+	return parseDurationConfig(idempotencyKeyTTLStr, defaultIdempotencyKeyTTL, "idempotency key ttl")
+}
+
+func NewSoftDeleteRetention(softDeleteRetentionStr string) time.Duration {
+	return parseDurationConfig(softDeleteRetentionStr, defaultSoftDeleteRetention, "soft delete retention")
+}
+
+func parseDurationConfig(value string, defaultValue time.Duration, name string) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		panic("bad " + name + " configuration")
+	}
+
+	return duration
+}
+
+func runEvery(interval time.Duration, fn func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		fn()
+	}
+}
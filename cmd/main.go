@@ -3,25 +3,89 @@ package main
 import (
 	"log"
 	"os"
+	"slices"
 
 	_ "github.com/danielalmeidafarias/go_stock_engine/docs"
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
 	"github.com/joho/godotenv"
 )
 
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 JWT sent as "Bearer <token>"
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Static API key of a machine client
+
 func main() {
 	if err := godotenv.Load("../.env"); err != nil {
 		log.Println(".env not found, using system environment variables")
 	}
 
 	repositoryType := RepositoryType(os.Getenv("REPOSITORY_TYPE"))
-	handlerType := HandlerType(os.Getenv("HANDLER_TYPE"))
+	handlerType := os.Getenv("HANDLER_TYPE")
 	paginationDefaultLimit := os.Getenv("PAGINATION_DEFAULT_LIMIT")
 	paginationMaxLimit := os.Getenv("PAGINATION_MAX_LIMIT")
+	idempotencyKeyTTL := os.Getenv("IDEMPOTENCY_KEY_TTL")
+	softDeleteRetention := os.Getenv("SOFT_DELETE_RETENTION")
+	authJWTSecret := os.Getenv("AUTH_JWT_SECRET")
+	authJWKSFile := os.Getenv("AUTH_JWKS_FILE")
+	authJWTIssuer := os.Getenv("AUTH_JWT_ISSUER")
+	authJWTAudience := os.Getenv("AUTH_JWT_AUDIENCE")
+	authAPIKeys := os.Getenv("AUTH_API_KEYS")
+	authDisabled := os.Getenv("AUTH_DISABLED")
+	tenantsFile := os.Getenv("TENANTS_FILE")
+	eventSinks := os.Getenv("EVENT_SINKS")
+	eventWebhookURL := os.Getenv("EVENT_WEBHOOK_URL")
+	eventNatsURL := os.Getenv("EVENT_NATS_URL")
+	eventSubjectPrefix := os.Getenv("EVENT_SUBJECT_PREFIX")
+	eventDispatchInterval := os.Getenv("EVENT_DISPATCH_INTERVAL")
+	eventMaxAttempts := os.Getenv("EVENT_MAX_ATTEMPTS")
+	webhookMaxAttempts := os.Getenv("WEBHOOK_MAX_ATTEMPTS")
+	alertChannels := os.Getenv("ALERT_CHANNELS")
+	alertSMTPAddr := os.Getenv("ALERT_SMTP_ADDR")
+	alertSMTPUsername := os.Getenv("ALERT_SMTP_USERNAME")
+	alertSMTPPassword := os.Getenv("ALERT_SMTP_PASSWORD")
+	alertSMTPFrom := os.Getenv("ALERT_SMTP_FROM")
+	alertSMTPTo := os.Getenv("ALERT_SMTP_TO")
+	alertSlackWebhookURL := os.Getenv("ALERT_SLACK_WEBHOOK_URL")
+	jobSchedules := os.Getenv("JOB_SCHEDULES")
 
+	handlerTypes := NewHandlerTypes(handlerType)
 	paginationConfig := NewPaginationConfig(paginationDefaultLimit, paginationMaxLimit)
+	idempotencyKeyTTLConfig := NewIdempotencyKeyTTL(idempotencyKeyTTL)
+	softDeleteRetentionConfig := NewSoftDeleteRetention(softDeleteRetention)
+	authConfig := NewAuthConfig(authJWTSecret, authJWKSFile, authJWTIssuer, authJWTAudience, authAPIKeys, authDisabled)
+	eventsConfig := NewEventsConfig(eventSinks, eventWebhookURL, eventNatsURL, eventSubjectPrefix, eventDispatchInterval, eventMaxAttempts, webhookMaxAttempts)
+	alertsConfig := NewAlertsConfig(alertChannels, alertSMTPAddr, alertSMTPUsername, alertSMTPPassword, alertSMTPFrom, alertSMTPTo, alertSlackWebhookURL)
+	jobsConfig := NewJobsConfig(jobSchedules)
+
+	// Commands run from the CLI are not authenticated.
+	var authUC *usecases.AuthenticateUseCase
+	if !slices.Contains(handlerTypes, CLI) {
+		authUC = AuthenticatorFactory(authConfig)
+	}
+	tenantRepository := TenantRepositoryFactory(tenantsFile, paginationConfig)
+
+	alertChannelList := AlertChannelsFactory(alertsConfig)
 
 	productStockRepository := ProductStockRepositoryFactory(repositoryType)
-	appHadler := AppHandlerFactory(handlerType, paginationConfig, productStockRepository)
+	jobs := JobsFactory(productStockRepository, jobsConfig)
+
+	// Events recorded by CLI commands are dispatched by the servers.
+	if !slices.Contains(handlerTypes, CLI) {
+		StartOutboxDispatcher(productStockRepository, EventSinksFactory(eventsConfig), alertChannelList, eventsConfig, jobs)
+	}
+
+	appHadler := AppHandlerFactory(handlerTypes, idempotencyKeyTTLConfig, softDeleteRetentionConfig, authUC, tenantRepository, productStockRepository, alertChannelList, jobs)
+
+	// Jobs are run by the servers too.
+	if !slices.Contains(handlerTypes, CLI) {
+		jobs.Start()
+	}
 
 	appHadler.Run()
 }
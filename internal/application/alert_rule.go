@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const alertCursorScope = "alerts"
+
+// alertRepository binds the repository to the tenant and fails when it
+// cannot keep alerts.
+func alertRepository(repo repository.IProductStockRepository, t tenant.Tenant) (repository.IAlertRepository, *domain.Error) {
+	alertRepo, ok := repo.ForTenant(t.ID).(repository.IAlertRepository)
+	if !ok {
+		return nil, domain.NewError("alerts are not supported by the configured repository", domain.ErrInternal)
+	}
+
+	return alertRepo, nil
+}
+
+func channelNames(channels []alert.IChannel) []string {
+	names := make([]string, len(channels))
+	for i, c := range channels {
+		names[i] = c.Name()
+	}
+
+	return names
+}
+
+type CreateAlertRuleUseCase struct {
+	repo     repository.IProductStockRepository
+	channels []string
+}
+
+// NewCreateAlertRuleUseCase accepts rules notifying the given channels only.
+func NewCreateAlertRuleUseCase(repo repository.IProductStockRepository, channels []alert.IChannel) *CreateAlertRuleUseCase {
+	return &CreateAlertRuleUseCase{
+		repo:     repo,
+		channels: channelNames(channels),
+	}
+}
+
+type CreateAlertRuleDTO struct {
+	Tenant         tenant.Tenant
+	Name           string
+	Condition      string
+	MinCriticality int
+	Categories     []string
+	Channels       []string
+	// Cooldown is a duration such as 30m, 1h by default.
+	Cooldown string
+}
+
+func (uc *CreateAlertRuleUseCase) Execute(dto CreateAlertRuleDTO) (*alert.Rule, *domain.Error) {
+	alertRepo, err := alertRepository(uc.repo, dto.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	cooldown := alert.DefaultCooldown
+	if dto.Cooldown != "" {
+		parsed, parseErr := time.ParseDuration(strings.TrimSpace(dto.Cooldown))
+		if parseErr != nil {
+			return nil, domain.NewError("cooldown must be a duration such as 30m or 2h", domain.ErrBadRequest)
+		}
+		cooldown = parsed
+	}
+
+	var categories []entities.ProductCategory
+	for _, c := range dto.Categories {
+		category := entities.ProductCategory(strings.TrimSpace(c))
+		if !dto.Tenant.AllowsCategory(category) {
+			return nil, domain.NewError("invalid product category: "+c, domain.ErrBadRequest)
+		}
+		categories = append(categories, category)
+	}
+
+	var channels []string
+	for _, c := range dto.Channels {
+		channel := strings.ToLower(strings.TrimSpace(c))
+		if !slices.Contains(uc.channels, channel) {
+			if len(uc.channels) == 0 {
+				return nil, domain.NewError("no alert channels are configured", domain.ErrBadRequest)
+			}
+			return nil, domain.NewError("unknown channel "+c+", the configured ones are "+strings.Join(uc.channels, ", "), domain.ErrBadRequest)
+		}
+		channels = append(channels, channel)
+	}
+
+	rule, err := alert.NewRule(
+		strings.TrimSpace(dto.Name),
+		alert.Condition(strings.TrimSpace(dto.Condition)),
+		entities.CriticalityLevel(dto.MinCriticality),
+		categories,
+		channels,
+		cooldown,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := alertRepo.CreateAlertRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.ID = &id
+	return rule, nil
+}
+
+type GetAlertRulesUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetAlertRulesUseCase(repo repository.IProductStockRepository) *GetAlertRulesUseCase {
+	return &GetAlertRulesUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetAlertRulesUseCase) Execute(t tenant.Tenant) ([]alert.Rule, *domain.Error) {
+	alertRepo, err := alertRepository(uc.repo, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertRepo.GetAlertRules()
+}
+
+type DeleteAlertRuleUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewDeleteAlertRuleUseCase(repo repository.IProductStockRepository) *DeleteAlertRuleUseCase {
+	return &DeleteAlertRuleUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *DeleteAlertRuleUseCase) Execute(t tenant.Tenant, id string) *domain.Error {
+	alertRepo, err := alertRepository(uc.repo, t)
+	if err != nil {
+		return err
+	}
+
+	return alertRepo.DeleteAlertRule(id)
+}
+
+type GetAlertsUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetAlertsUseCase(repo repository.IProductStockRepository) *GetAlertsUseCase {
+	return &GetAlertsUseCase{
+		repo: repo,
+	}
+}
+
+type GetAlertsDTO struct {
+	Tenant     tenant.Tenant
+	RuleID     string
+	Pagination domain.Pagination
+}
+
+// Execute lists the alerts raised, newest first.
+func (uc *GetAlertsUseCase) Execute(dto GetAlertsDTO) (*domain.Page[alert.Alert], *domain.Error) {
+	alertRepo, err := alertRepository(uc.repo, dto.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+	if err := domain.ApplyCursor(&dto.Pagination, alertCursorScope, 1); err != nil {
+		return nil, err
+	}
+
+	alerts, err := alertRepo.GetAlerts(dto.RuleID, dto.Pagination.Lookahead())
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(alerts, dto.Pagination, alertCursorScope, func(a alert.Alert) []any {
+		return []any{a.ID}
+	})
+
+	if dto.Pagination.IncludeTotal {
+		total, err := alertRepo.CountAlerts(dto.RuleID)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return &page, nil
+}
+
+type TestAlertChannelUseCase struct {
+	channels []alert.IChannel
+}
+
+func NewTestAlertChannelUseCase(channels []alert.IChannel) *TestAlertChannelUseCase {
+	return &TestAlertChannelUseCase{
+		channels: channels,
+	}
+}
+
+// Execute sends a sample alert through the channel, to check its
+// configuration. Nothing is recorded.
+func (uc *TestAlertChannelUseCase) Execute(name string) *domain.Error {
+	i := slices.IndexFunc(uc.channels, func(c alert.IChannel) bool {
+		return c.Name() == name
+	})
+	if i < 0 {
+		return domain.NewError("alert channel not found", domain.ErrNotFound)
+	}
+
+	sku := "SAMPLE-1"
+	sample := alert.Alert{
+		RuleName:  "Test of the " + name + " channel",
+		Condition: alert.OutOfStock,
+		ProductID: "00000000-0000-0000-0000-000000000000",
+		Product: event.Product{
+			Name:              "Sample product",
+			Category:          string(entities.Oil),
+			MinimumStock:      10,
+			AverageDailySales: 2,
+			LeadTimeDays:      5,
+			CriticalityLevel:  int(entities.Critical),
+			SKU:               &sku,
+		},
+		ProjectedStock: -10,
+		Status:         alert.Pending,
+		TriggeredAt:    time.Now(),
+	}
+
+	return uc.channels[i].Send(sample)
+}
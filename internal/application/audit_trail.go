@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+)
+
+// withinTransaction runs fn in a transaction when the repository supports
+// them, so a change, its audit entries and its events are written together.
+func withinTransaction(repo repository.IProductStockRepository, fn func(repo repository.IProductStockRepository) *domain.Error) *domain.Error {
+	if txRepo, ok := repo.(repository.ITransactionalRepository); ok {
+		return txRepo.WithinTransaction(fn)
+	}
+
+	return fn(repo)
+}
+
+// recordAudit appends the entries through the given repository, which is
+// bound to the transaction of the change when there is one. Repositories
+// without an audit log record nothing.
+func recordAudit(repo repository.IProductStockRepository, entries ...audit.Entry) *domain.Error {
+	auditRepo, ok := repo.(repository.IAuditLogRepository)
+	if !ok {
+		return nil
+	}
+
+	return auditRepo.AppendAuditEntries(entries)
+}
+
+// recordEvents appends the events to the outbox of the given repository,
+// like recordAudit. Repositories without an outbox record nothing.
+func recordEvents(repo repository.IProductStockRepository, events ...event.Event) *domain.Error {
+	outboxRepo, ok := repo.(repository.IOutboxRepository)
+	if !ok {
+		return nil
+	}
+
+	return outboxRepo.AppendOutboxEvents(events)
+}
+
+// refreshRestockPrioritySnapshot updates the entry of the product in the
+// restock priority snapshot of the given repository, like recordAudit.
+// Repositories without a snapshot refresh nothing.
+func refreshRestockPrioritySnapshot(repo repository.IProductStockRepository, p *entities.ProductStock, occurredAt time.Time) *domain.Error {
+	snapshotRepo, ok := repo.(repository.IRestockPrioritySnapshotRepository)
+	if !ok {
+		return nil
+	}
+
+	return snapshotRepo.RefreshRestockPrioritySnapshot(p, occurredAt)
+}
+
+// recordProductStockChange records a change to a product in the audit log,
+// the events it raises in the outbox and its restock priority in the
+// snapshot. Changes to no field record nothing.
+func recordProductStockChange(repo repository.IProductStockRepository, actor string, operation audit.Operation, before, after *entities.ProductStock, occurredAt time.Time) *domain.Error {
+	entry := audit.NewProductStockEntry(actor, operation, before, after, occurredAt)
+	if len(entry.Changes) == 0 {
+		return nil
+	}
+
+	if err := recordAudit(repo, entry); err != nil {
+		return err
+	}
+
+	if err := recordEvents(repo, event.NewProductStockEvents(actor, before, after, occurredAt)...); err != nil {
+		return err
+	}
+
+	return refreshRestockPrioritySnapshot(repo, after, occurredAt)
+}
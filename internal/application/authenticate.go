@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+)
+
+// AuthenticateUseCase identifies the caller of a request by its bearer token
+// or API key, as given in the headers or metadata of every transport: the
+// raw Authorization value and the X-API-Key one. A nil verifier means that kind of credential is not accepted.
+// When authentication is disabled every caller is an admin named by the
+// actor it claims to be.
+type AuthenticateUseCase struct {
+	tokens   auth.ITokenVerifier
+	apiKeys  auth.IAPIKeyVerifier
+	disabled bool
+}
+
+func NewAuthenticateUseCase(tokens auth.ITokenVerifier, apiKeys auth.IAPIKeyVerifier, disabled bool) *AuthenticateUseCase {
+	return &AuthenticateUseCase{
+		tokens:   tokens,
+		apiKeys:  apiKeys,
+		disabled: disabled,
+	}
+}
+
+type AuthenticateDTO struct {
+	Authorization string
+	APIKey        string
+	Actor         string
+}
+
+func (uc *AuthenticateUseCase) Execute(dto AuthenticateDTO) (*auth.Principal, *domain.Error) {
+	var bearerToken string
+	if dto.Authorization != "" {
+		scheme, token, _ := strings.Cut(dto.Authorization, " ")
+		bearerToken = strings.TrimSpace(token)
+		if !strings.EqualFold(scheme, auth.BearerScheme) || bearerToken == "" {
+			return nil, domain.NewError("authorization must be a bearer token", domain.ErrUnauthorized)
+		}
+	}
+
+	if uc.disabled {
+		subject := strings.TrimSpace(dto.Actor)
+		if subject == "" {
+			subject = audit.AnonymousActor
+		}
+
+		return &auth.Principal{Subject: subject, Role: auth.Admin}, nil
+	}
+
+	switch {
+	case bearerToken != "":
+		if uc.tokens == nil {
+			return nil, domain.NewError("bearer tokens are not accepted", domain.ErrUnauthorized)
+		}
+
+		return uc.tokens.VerifyToken(bearerToken)
+	case dto.APIKey != "":
+		if uc.apiKeys == nil {
+			return nil, domain.NewError("api keys are not accepted", domain.ErrUnauthorized)
+		}
+
+		return uc.apiKeys.VerifyAPIKey(dto.APIKey)
+	default:
+		return nil, domain.NewError("authentication required", domain.ErrUnauthorized)
+	}
+}
@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type BatchOperationType string
+
+const (
+	BatchCreate BatchOperationType = "create"
+	BatchUpdate BatchOperationType = "update"
+	BatchDelete BatchOperationType = "delete"
+)
+
+const maxBatchOperations = 1000
+
+type BatchProductStockUseCase struct {
+	repo     repository.IProductStockRepository
+	createUC *CreateProductStockUseCase
+	updateUC *UpdateProductStockUseCase
+	deleteUC *DeleteProductStockUseCase
+}
+
+func NewBatchProductStockUseCase(
+	repo repository.IProductStockRepository,
+	createUC *CreateProductStockUseCase,
+	updateUC *UpdateProductStockUseCase,
+	deleteUC *DeleteProductStockUseCase,
+) *BatchProductStockUseCase {
+	return &BatchProductStockUseCase{
+		repo:     repo,
+		createUC: createUC,
+		updateUC: updateUC,
+		deleteUC: deleteUC,
+	}
+}
+
+// BatchOperationDTO carries the DTO matching its type. ID names the product
+// of updates and deletes.
+type BatchOperationDTO struct {
+	Type   BatchOperationType
+	ID     string
+	Create CreateProductStockDTO
+	Update UpdateProductStockDTO
+}
+
+// BatchProductStockDTO runs the operations in order, on behalf of the
+// actor. An atomic batch runs in a single transaction and stops at the
+// first failure; otherwise every operation is applied on its own.
+type BatchProductStockDTO struct {
+	Tenant     tenant.Tenant
+	Atomic     bool
+	Operations []BatchOperationDTO
+	Actor      string
+}
+
+// BatchOperationResult is either applied, failed (Err set) or, in an atomic
+// batch that failed, rolled back or skipped.
+type BatchOperationResult struct {
+	Type       BatchOperationType
+	ID         string
+	Err        *domain.Error
+	Skipped    bool
+	RolledBack bool
+}
+
+type BatchReport struct {
+	Atomic    bool
+	Succeeded int
+	Failed    int
+	Results   []BatchOperationResult
+}
+
+func (uc *BatchProductStockUseCase) Execute(dto BatchProductStockDTO) (*BatchReport, *domain.Error) {
+	if len(dto.Operations) == 0 {
+		return nil, domain.NewError("operations are required", domain.ErrBadRequest)
+	}
+
+	if len(dto.Operations) > maxBatchOperations {
+		return nil, domain.NewError(fmt.Sprintf("a batch has at most %d operations", maxBatchOperations), domain.ErrBadRequest)
+	}
+
+	for i, op := range dto.Operations {
+		if op.Type != BatchCreate && op.Type != BatchUpdate && op.Type != BatchDelete {
+			return nil, domain.NewError(fmt.Sprintf("operation %d: type must be 'create', 'update' or 'delete'", i), domain.ErrBadRequest)
+		}
+	}
+
+	if dto.Atomic {
+		return uc.executeAtomic(dto)
+	}
+
+	repo := uc.repo.ForTenant(dto.Tenant.ID)
+	report := &BatchReport{Results: make([]BatchOperationResult, len(dto.Operations))}
+	for i, op := range dto.Operations {
+		result := uc.apply(repo, op, dto)
+		report.Results[i] = result
+
+		if result.Err != nil {
+			report.Failed++
+			continue
+		}
+
+		report.Succeeded++
+	}
+
+	return report, nil
+}
+
+func (uc *BatchProductStockUseCase) executeAtomic(dto BatchProductStockDTO) (*BatchReport, *domain.Error) {
+	txRepo, ok := uc.repo.ForTenant(dto.Tenant.ID).(repository.ITransactionalRepository)
+	if !ok {
+		return nil, domain.NewError("atomic batches are not supported by the configured repository", domain.ErrBadRequest)
+	}
+
+	operations := dto.Operations
+	report := &BatchReport{Atomic: true, Results: make([]BatchOperationResult, len(operations))}
+	failed := -1
+
+	err := txRepo.WithinTransaction(func(repo repository.IProductStockRepository) *domain.Error {
+		for i, op := range operations {
+			report.Results[i] = uc.apply(repo, op, dto)
+			if report.Results[i].Err != nil {
+				failed = i
+				return report.Results[i].Err
+			}
+		}
+
+		return nil
+	})
+
+	if failed >= 0 {
+		for i := range report.Results {
+			switch {
+			case i < failed:
+				report.Results[i].RolledBack = true
+				if operations[i].Type == BatchCreate {
+					report.Results[i].ID = ""
+				}
+			case i > failed:
+				report.Results[i] = BatchOperationResult{Type: operations[i].Type, Skipped: true}
+			}
+		}
+
+		report.Failed = 1
+		return report, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	report.Succeeded = len(operations)
+	return report, nil
+}
+
+// apply runs the operation on behalf of the actor of the batch, in its
+// tenant.
+func (uc *BatchProductStockUseCase) apply(repo repository.IProductStockRepository, op BatchOperationDTO, batch BatchProductStockDTO) BatchOperationResult {
+	result := BatchOperationResult{Type: op.Type, ID: op.ID}
+
+	var p *entities.ProductStock
+	switch op.Type {
+	case BatchCreate:
+		op.Create.Tenant = batch.Tenant
+		op.Create.Actor = batch.Actor
+		p, result.Err = uc.createUC.execute(repo, op.Create)
+	case BatchUpdate:
+		op.Update.ID = op.ID
+		op.Update.Tenant = batch.Tenant
+		op.Update.Actor = batch.Actor
+		p, result.Err = uc.updateUC.execute(repo, op.Update)
+	case BatchDelete:
+		result.Err = uc.deleteUC.execute(repo, DeleteProductStockDTO{Tenant: batch.Tenant, ID: op.ID, Actor: batch.Actor})
+	}
+
+	if p != nil {
+		result.ID = *p.ID
+	}
+
+	return result
+}
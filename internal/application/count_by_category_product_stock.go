@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type CountByCategoryProductStockUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewCountByCategoryProductStockUseCase(repo repository.IProductStockRepository) *CountByCategoryProductStockUseCase {
+	return &CountByCategoryProductStockUseCase{
+		repo: repo,
+	}
+}
+
+// Execute counts the products of each category of the tenant, empty ones
+// included.
+func (uc *CountByCategoryProductStockUseCase) Execute(t tenant.Tenant) (map[entities.ProductCategory]int, *domain.Error) {
+	counts, err := uc.repo.ForTenant(t.ID).CountByCategory()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[entities.ProductCategory]int, len(t.Categories))
+	for _, category := range t.Categories {
+		result[category] = counts[category]
+	}
+
+	return result, nil
+}
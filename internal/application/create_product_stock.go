@@ -1,9 +1,13 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type CreateProductStockUseCase struct {
@@ -17,6 +21,7 @@ func NewCreateProductStockUseCase(repo repository.IProductStockRepository) *Crea
 }
 
 type CreateProductStockDTO struct {
+	Tenant            tenant.Tenant
 	Name              string
 	Category          string
 	CurrentStock      int
@@ -25,12 +30,36 @@ type CreateProductStockDTO struct {
 	LeadTimeDays      int
 	UnitCost          float64
 	CriticalityLevel  int
+	SKU               *string
+	Barcodes          []string
+	ExternalIDs       map[string]string
+	Actor             string
 }
 
 func (uc *CreateProductStockUseCase) Execute(dto CreateProductStockDTO) (string, *domain.Error) {
+	productStock, err := uc.execute(uc.repo.ForTenant(dto.Tenant.ID), dto)
+	if err != nil {
+		return "", err
+	}
+
+	return *productStock.ID, nil
+}
+
+// execute writes through the given repository, so batches can run it inside
+// their transaction.
+func (uc *CreateProductStockUseCase) execute(repo repository.IProductStockRepository, dto CreateProductStockDTO) (*entities.ProductStock, *domain.Error) {
+	if dto.Category != "" && !dto.Tenant.AllowsCategory(entities.ProductCategory(dto.Category)) {
+		return nil, domain.NewError("invalid product category", domain.ErrBadRequest)
+	}
+
 	productStock, err := entities.NewProductStock(
 		nil,
 		dto.Name,
+		entities.ProductIdentifiers{
+			SKU:         dto.SKU,
+			Barcodes:    dto.Barcodes,
+			ExternalIDs: dto.ExternalIDs,
+		},
 		entities.ProductCategory(dto.Category),
 		dto.CurrentStock,
 		dto.MinimumStock,
@@ -40,8 +69,26 @@ func (uc *CreateProductStockUseCase) Execute(dto CreateProductStockDTO) (string,
 		entities.CriticalityLevel(dto.CriticalityLevel),
 	)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	err = withinTransaction(repo, func(repo repository.IProductStockRepository) *domain.Error {
+		if err := checkDeletedIdentifiers(repo, productStock); err != nil {
+			return err
+		}
+
+		id, err := repo.Create(productStock)
+		if err != nil {
+			return err
+		}
+
+		productStock.ID = &id
+
+		return recordProductStockChange(repo, dto.Actor, audit.Create, nil, productStock, time.Now())
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return uc.repo.Create(productStock)
+	return productStock, nil
 }
@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type RestockPlanObjective string
+
+const (
+	MaximizeUrgency      RestockPlanObjective = "urgency"
+	MinimizeStockoutCost RestockPlanObjective = "stockout_cost"
+	defaultPlanObjective                      = MaximizeUrgency
+)
+
+type CreateRestockPlanUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewCreateRestockPlanUseCase(repo repository.IProductStockRepository) *CreateRestockPlanUseCase {
+	return &CreateRestockPlanUseCase{
+		repo: repo,
+	}
+}
+
+type CreateRestockPlanDTO struct {
+	Tenant          tenant.Tenant
+	Budget          float64
+	CategoryBudgets map[string]float64
+	Objective       string
+}
+
+type RestockPlanLine struct {
+	Quantity     int
+	Cost         float64
+	UrgencyScore int
+	StockoutCost float64
+	ProductStock *entities.ProductStock
+}
+
+type RestockPlan struct {
+	Objective             RestockPlanObjective
+	Budget                float64
+	TotalCost             float64
+	TotalUrgency          int
+	StockoutCostAvoided   float64
+	RemainingStockoutCost float64
+	Lines                 []RestockPlanLine
+}
+
+func (uc *CreateRestockPlanUseCase) Execute(dto CreateRestockPlanDTO) (*RestockPlan, *domain.Error) {
+	objective := RestockPlanObjective(dto.Objective)
+	if objective == "" {
+		objective = defaultPlanObjective
+	}
+
+	if objective != MaximizeUrgency && objective != MinimizeStockoutCost {
+		return nil, domain.NewError("objective must be 'urgency' or 'stockout_cost'", domain.ErrBadRequest)
+	}
+
+	if dto.Budget <= 0 {
+		return nil, domain.NewError("budget must be greater than zero", domain.ErrBadRequest)
+	}
+
+	for category, limit := range dto.CategoryBudgets {
+		if !dto.Tenant.AllowsCategory(entities.ProductCategory(category)) {
+			return nil, domain.NewError("invalid product category: "+category, domain.ErrBadRequest)
+		}
+
+		if limit < 0 {
+			return nil, domain.NewError("category budgets must be non-negative", domain.ErrBadRequest)
+		}
+	}
+
+	products, err := uc.repo.ForTenant(dto.Tenant.ID).GetAll(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]RestockPlanLine, 0)
+	for _, priority := range restock.Prioritize(products) {
+		p := priority.ProductStock
+		candidates = append(candidates, RestockPlanLine{
+			Quantity:     priority.SuggestedQuantity,
+			Cost:         float64(priority.SuggestedQuantity) * p.UnitCost,
+			UrgencyScore: priority.UrgencyScore,
+			StockoutCost: priority.StockoutCost(p),
+			ProductStock: p,
+		})
+	}
+
+	items := make([]restock.PlanItem, len(candidates))
+	for i, line := range candidates {
+		items[i] = restock.PlanItem{
+			Group: string(line.ProductStock.Category),
+			Cost:  line.Cost,
+			Value: float64(line.UrgencyScore),
+		}
+
+		if objective == MinimizeStockoutCost {
+			items[i].Value = line.StockoutCost
+		}
+	}
+
+	plan := &RestockPlan{
+		Objective: objective,
+		Budget:    dto.Budget,
+		Lines:     []RestockPlanLine{},
+	}
+
+	selected := make([]bool, len(candidates))
+	for _, i := range restock.OptimizePlan(items, restock.PlanConstraints{
+		Budget:    dto.Budget,
+		GroupCaps: dto.CategoryBudgets,
+	}) {
+		selected[i] = true
+	}
+
+	for i, line := range candidates {
+		if !selected[i] {
+			plan.RemainingStockoutCost += line.StockoutCost
+			continue
+		}
+
+		plan.Lines = append(plan.Lines, line)
+		plan.TotalCost += line.Cost
+		plan.TotalUrgency += line.UrgencyScore
+		plan.StockoutCostAvoided += line.StockoutCost
+	}
+
+	return plan, nil
+}
@@ -1,8 +1,12 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type DeleteProductStockUseCase struct {
@@ -15,15 +19,35 @@ func NewDeleteProductStockUseCase(repo repository.IProductStockRepository) *Dele
 	}
 }
 
-func (uc *DeleteProductStockUseCase) Execute(id string) *domain.Error {
-	if id == "" {
+type DeleteProductStockDTO struct {
+	Tenant tenant.Tenant
+	ID     string
+	Actor  string
+}
+
+func (uc *DeleteProductStockUseCase) Execute(dto DeleteProductStockDTO) *domain.Error {
+	return uc.execute(uc.repo.ForTenant(dto.Tenant.ID), dto)
+}
+
+func (uc *DeleteProductStockUseCase) execute(repo repository.IProductStockRepository, dto DeleteProductStockDTO) *domain.Error {
+	if dto.ID == "" {
 		return domain.NewError("id is required", domain.ErrBadRequest)
 	}
 
-	_, err := uc.repo.GetOneByID(id)
-	if err != nil {
-		return err
-	}
+	return withinTransaction(repo, func(repo repository.IProductStockRepository) *domain.Error {
+		before, err := repo.GetOneByID(dto.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := repo.DeleteProductStock(dto.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		after := *before
+		after.DeletedAt = &now
 
-	return uc.repo.DeleteProductStock(id)
+		return recordProductStockChange(repo, dto.Actor, audit.Delete, before, &after, now)
+	})
 }
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+)
+
+const (
+	webhookBatchSize = 20
+	// webhookLease must outlast the sending of a whole batch.
+	webhookLease         = 5 * time.Minute
+	webhookFirstRetry    = 30 * time.Second
+	webhookMaxRetryDelay = 6 * time.Hour
+)
+
+// EnqueueWebhookDeliveriesUseCase is the event sink of the webhook
+// subscriptions: it queues a delivery of the event to every subscription of
+// its tenant that wants it, which DeliverWebhooksUseCase then sends.
+type EnqueueWebhookDeliveriesUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewEnqueueWebhookDeliveriesUseCase(repo repository.IProductStockRepository) *EnqueueWebhookDeliveriesUseCase {
+	return &EnqueueWebhookDeliveriesUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *EnqueueWebhookDeliveriesUseCase) Name() string {
+	return "webhooks"
+}
+
+func (uc *EnqueueWebhookDeliveriesUseCase) Publish(e event.Event) *domain.Error {
+	webhookRepo, ok := uc.repo.ForTenant(e.TenantID).(repository.IWebhookRepository)
+	if !ok {
+		return nil
+	}
+
+	subscriptions, err := webhookRepo.GetWebhookSubscriptions()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	var deliveries []webhook.Delivery
+	for _, s := range subscriptions {
+		if s.Wants(e.Type) {
+			deliveries = append(deliveries, webhook.NewDelivery(*s.ID, e, now))
+		}
+	}
+
+	return webhookRepo.EnqueueWebhookDeliveries(deliveries)
+}
+
+// DeliverWebhooksUseCase posts the due deliveries to their subscriptions,
+// signed with their secrets. A delivery succeeds on any 2xx response. Failed
+// ones are retried with exponential backoff until maxAttempts, after which
+// they are dead until redelivered by hand.
+type DeliverWebhooksUseCase struct {
+	repo        repository.IWebhookRepository
+	sender      webhook.ISender
+	maxAttempts int
+}
+
+func NewDeliverWebhooksUseCase(repo repository.IWebhookRepository, sender webhook.ISender, maxAttempts int) *DeliverWebhooksUseCase {
+	return &DeliverWebhooksUseCase{
+		repo:        repo,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Execute sends the due deliveries, batch after batch, until none is left.
+func (uc *DeliverWebhooksUseCase) Execute() (int, *domain.Error) {
+	succeeded := 0
+
+	for {
+		now := time.Now()
+
+		deliveries, err := uc.repo.ClaimWebhookDeliveries(webhookBatchSize, now, now.Add(webhookLease))
+		if err != nil {
+			return succeeded, err
+		}
+
+		subscriptions := map[string]*webhook.Subscription{}
+		for i := range deliveries {
+			d := &deliveries[i]
+
+			// A subscription deleted meanwhile took its deliveries with it.
+			s, ok := subscriptions[d.SubscriptionID]
+			if !ok {
+				if s, err = uc.repo.GetWebhookSubscription(d.SubscriptionID); err != nil && err.ErrCode != domain.ErrNotFound {
+					return succeeded, err
+				}
+				subscriptions[d.SubscriptionID] = s
+			}
+
+			if s == nil {
+				continue
+			}
+
+			uc.deliver(s, d)
+			if err := uc.repo.UpdateWebhookDelivery(d); err != nil && err.ErrCode != domain.ErrNotFound {
+				return succeeded, err
+			}
+
+			if d.Status == webhook.Succeeded {
+				succeeded++
+			}
+		}
+
+		if len(deliveries) < webhookBatchSize {
+			return succeeded, nil
+		}
+	}
+}
+
+func (uc *DeliverWebhooksUseCase) deliver(s *webhook.Subscription, d *webhook.Delivery) {
+	body, jsonErr := json.Marshal(d.Event)
+	if jsonErr != nil {
+		uc.fail(d, nil, "failed to encode event: "+jsonErr.Error())
+		return
+	}
+
+	now := time.Now()
+	statusCode, err := uc.sender.Send(webhook.Request{
+		URL: s.URL,
+		Headers: map[string]string{
+			"Content-Type":          "application/json",
+			"X-Webhook-ID":          *s.ID,
+			"X-Webhook-Delivery":    strconv.FormatInt(d.ID, 10),
+			"X-Event-ID":            strconv.FormatInt(d.Event.ID, 10),
+			"X-Event-Type":          string(d.Event.Type),
+			webhook.SignatureHeader: webhook.SignatureHeaderValue(s.Secret, now, body),
+		},
+		Body: body,
+	})
+
+	switch {
+	case err != nil:
+		uc.fail(d, nil, err.Message)
+	case statusCode < 200 || statusCode > 299:
+		uc.fail(d, &statusCode, "unexpected status "+strconv.Itoa(statusCode))
+	default:
+		d.Succeed(statusCode, time.Now())
+	}
+}
+
+func (uc *DeliverWebhooksUseCase) fail(d *webhook.Delivery, statusCode *int, reason string) {
+	now := time.Now()
+
+	if d.Attempts+1 >= uc.maxAttempts {
+		log.Printf("webhook delivery %d of event %d is dead after %d attempts: %s", d.ID, d.Event.ID, d.Attempts+1, reason)
+		d.Fail(statusCode, reason, now, nil)
+		return
+	}
+
+	nextAttemptAt := now.Add(backoff(d.Attempts+1, webhookFirstRetry, webhookMaxRetryDelay))
+	d.Fail(statusCode, reason, now, &nextAttemptAt)
+}
@@ -0,0 +1,381 @@
+package usecases
+
+import (
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/events"
+)
+
+const webhookTestSecret = "0123456789abcdef-secret"
+
+// webhookTestRepository keeps subscriptions and deliveries in memory. The
+// product stock methods are left to the nil embedded interface, since the
+// webhook use cases never call them.
+type webhookTestRepository struct {
+	repository.IProductStockRepository
+
+	mu            sync.Mutex
+	subscriptions []webhook.Subscription
+	deliveries    []webhook.Delivery
+}
+
+func (r *webhookTestRepository) ForTenant(string) repository.IProductStockRepository {
+	return r
+}
+
+func (r *webhookTestRepository) CreateWebhookSubscription(s *webhook.Subscription) (string, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := "sub-" + strconv.Itoa(len(r.subscriptions)+1)
+	s.ID = &id
+	r.subscriptions = append(r.subscriptions, *s)
+
+	return id, nil
+}
+
+func (r *webhookTestRepository) GetWebhookSubscriptions() ([]webhook.Subscription, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.subscriptions), nil
+}
+
+func (r *webhookTestRepository) GetWebhookSubscription(id string) (*webhook.Subscription, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.subscriptions {
+		if *s.ID == id {
+			return &s, nil
+		}
+	}
+
+	return nil, domain.NewError("webhook subscription not found", domain.ErrNotFound)
+}
+
+func (r *webhookTestRepository) DeleteWebhookSubscription(id string) *domain.Error {
+	return domain.NewError("not supported", domain.ErrInternal)
+}
+
+func (r *webhookTestRepository) EnqueueWebhookDeliveries(deliveries []webhook.Delivery) *domain.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range deliveries {
+		d.ID = int64(len(r.deliveries) + 1)
+		r.deliveries = append(r.deliveries, d)
+	}
+
+	return nil
+}
+
+func (r *webhookTestRepository) ClaimWebhookDeliveries(limit int, now, leaseUntil time.Time) ([]webhook.Delivery, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var claimed []webhook.Delivery
+	for i := range r.deliveries {
+		d := &r.deliveries[i]
+		if len(claimed) == limit || d.Status != webhook.Pending || d.NextAttemptAt.After(now) {
+			continue
+		}
+
+		claimed = append(claimed, *d)
+		d.NextAttemptAt = &leaseUntil
+	}
+
+	return claimed, nil
+}
+
+func (r *webhookTestRepository) UpdateWebhookDelivery(d *webhook.Delivery) *domain.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == d.ID {
+			r.deliveries[i] = *d
+			return nil
+		}
+	}
+
+	return domain.NewError("webhook delivery not found", domain.ErrNotFound)
+}
+
+func (r *webhookTestRepository) GetWebhookDelivery(subscriptionID string, id int64) (*webhook.Delivery, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID && d.ID == id {
+			return &d, nil
+		}
+	}
+
+	return nil, domain.NewError("webhook delivery not found", domain.ErrNotFound)
+}
+
+func (r *webhookTestRepository) GetWebhookDeliveries(string, webhook.Status, *domain.Pagination) ([]webhook.Delivery, *domain.Error) {
+	return nil, domain.NewError("not supported", domain.ErrInternal)
+}
+
+func (r *webhookTestRepository) CountWebhookDeliveries(string, webhook.Status) (int, *domain.Error) {
+	return 0, domain.NewError("not supported", domain.ErrInternal)
+}
+
+// delivery returns the stored delivery with the given id.
+func (r *webhookTestRepository) delivery(t *testing.T, id int64) webhook.Delivery {
+	t.Helper()
+
+	d, err := r.GetWebhookDelivery("sub-1", id)
+	if err != nil {
+		t.Fatalf("delivery %d: %s", id, err.Message)
+	}
+
+	return *d
+}
+
+// makeDue moves the next attempt of every pending delivery to the past, as
+// if its backoff had elapsed.
+func (r *webhookTestRepository) makeDue() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	past := time.Now().Add(-time.Second)
+	for i := range r.deliveries {
+		if r.deliveries[i].Status == webhook.Pending {
+			r.deliveries[i].NextAttemptAt = &past
+		}
+	}
+}
+
+// webhookReceiver is a local stand-in for a subscriber. It answers with
+// status and records the requests it got.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	w.WriteHeader(rc.status)
+}
+
+func (rc *webhookReceiver) setStatus(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.status = status
+}
+
+func (rc *webhookReceiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return len(rc.requests)
+}
+
+// newWebhookTest subscribes a receiver answering with status to stock
+// changes and queues the delivery of one event to it.
+func newWebhookTest(t *testing.T, status int) (*webhookTestRepository, *webhookReceiver) {
+	t.Helper()
+
+	receiver := &webhookReceiver{status: status}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	repo := &webhookTestRepository{}
+	tenantDefault := tenant.Tenant{ID: tenant.DefaultID}
+
+	if _, err := NewCreateWebhookSubscriptionUseCase(repo).Execute(CreateWebhookSubscriptionDTO{
+		Tenant:     tenantDefault,
+		URL:        server.URL,
+		EventTypes: []string{string(event.StockChanged)},
+		Secret:     webhookTestSecret,
+	}); err != nil {
+		t.Fatalf("create subscription: %s", err.Message)
+	}
+
+	enqueue := NewEnqueueWebhookDeliveriesUseCase(repo)
+	for _, e := range []event.Event{
+		{ID: 7, Type: event.StockChanged, TenantID: tenant.DefaultID, ProductID: "p1", OccurredAt: time.Now()},
+		{ID: 8, Type: event.ProductCreated, TenantID: tenant.DefaultID, ProductID: "p2", OccurredAt: time.Now()},
+	} {
+		if err := enqueue.Publish(e); err != nil {
+			t.Fatalf("publish: %s", err.Message)
+		}
+	}
+
+	if len(repo.deliveries) != 1 {
+		t.Fatalf("queued %d deliveries, want only the one of the subscribed event type", len(repo.deliveries))
+	}
+
+	return repo, receiver
+}
+
+func TestDeliverWebhooksSignsTheBody(t *testing.T) {
+	repo, receiver := newWebhookTest(t, http.StatusNoContent)
+
+	succeeded, err := NewDeliverWebhooksUseCase(repo, events.NewHTTPSender(), 3).Execute()
+	if err != nil || succeeded != 1 {
+		t.Fatalf("execute = %d, %v, want 1 delivery", succeeded, err)
+	}
+
+	req, body := receiver.requests[0], receiver.bodies[0]
+
+	var timestamp, signature string
+	for part := range strings.SplitSeq(req.Header.Get(webhook.SignatureHeader), ",") {
+		if v, ok := strings.CutPrefix(part, "t="); ok {
+			timestamp = v
+		}
+		if v, ok := strings.CutPrefix(part, "v1="); ok {
+			signature = v
+		}
+	}
+
+	unix, parseErr := strconv.ParseInt(timestamp, 10, 64)
+	if parseErr != nil {
+		t.Fatalf("signature header %q has no timestamp", req.Header.Get(webhook.SignatureHeader))
+	}
+
+	want := webhook.Sign(webhookTestSecret, time.Unix(unix, 0), body)
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		t.Fatalf("signature = %q, want %q", signature, want)
+	}
+
+	if got := webhook.Sign("another-secret-value", time.Unix(unix, 0), body); got == signature {
+		t.Fatal("another secret gave the same signature")
+	}
+
+	if req.Header.Get("X-Event-ID") != "7" || req.Header.Get("X-Event-Type") != string(event.StockChanged) {
+		t.Fatalf("event headers = %q, %q", req.Header.Get("X-Event-ID"), req.Header.Get("X-Event-Type"))
+	}
+
+	d := repo.delivery(t, 1)
+	if d.Status != webhook.Succeeded || d.Attempts != 1 || *d.LastStatusCode != http.StatusNoContent {
+		t.Fatalf("delivery = %+v, want succeeded after one attempt", d)
+	}
+}
+
+func TestDeliverWebhooksBacksOffExponentially(t *testing.T) {
+	repo, receiver := newWebhookTest(t, http.StatusInternalServerError)
+	uc := NewDeliverWebhooksUseCase(repo, events.NewHTTPSender(), 5)
+
+	for attempt, delay := range []time.Duration{webhookFirstRetry, 2 * webhookFirstRetry, 4 * webhookFirstRetry} {
+		repo.makeDue()
+
+		before := time.Now()
+		if _, err := uc.Execute(); err != nil {
+			t.Fatalf("execute: %s", err.Message)
+		}
+
+		d := repo.delivery(t, 1)
+		if d.Status != webhook.Pending || d.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: delivery = %+v, want pending", attempt+1, d)
+		}
+		if *d.LastStatusCode != http.StatusInternalServerError || d.LastError == nil {
+			t.Fatalf("attempt %d: last outcome = %v, %v", attempt+1, d.LastStatusCode, d.LastError)
+		}
+		if wait := d.NextAttemptAt.Sub(before); wait < delay || wait > delay+5*time.Second {
+			t.Fatalf("attempt %d: retried after %s, want %s", attempt+1, wait, delay)
+		}
+
+		// Nothing is due until the backoff elapses.
+		if _, err := uc.Execute(); err != nil {
+			t.Fatalf("execute: %s", err.Message)
+		}
+		if receiver.count() != attempt+1 {
+			t.Fatalf("attempt %d: receiver got %d requests", attempt+1, receiver.count())
+		}
+	}
+}
+
+func TestDeliverWebhooksDeadLettersAfterMaxAttempts(t *testing.T) {
+	const maxAttempts = 3
+
+	repo, receiver := newWebhookTest(t, http.StatusBadGateway)
+	uc := NewDeliverWebhooksUseCase(repo, events.NewHTTPSender(), maxAttempts)
+
+	for range maxAttempts + 2 {
+		repo.makeDue()
+		if _, err := uc.Execute(); err != nil {
+			t.Fatalf("execute: %s", err.Message)
+		}
+	}
+
+	d := repo.delivery(t, 1)
+	if d.Status != webhook.Dead || d.Attempts != maxAttempts || d.NextAttemptAt != nil {
+		t.Fatalf("delivery = %+v, want dead after %d attempts", d, maxAttempts)
+	}
+
+	if receiver.count() != maxAttempts {
+		t.Fatalf("receiver got %d requests, want %d", receiver.count(), maxAttempts)
+	}
+}
+
+func TestRedeliverWebhookRetriesADeadDelivery(t *testing.T) {
+	repo, receiver := newWebhookTest(t, http.StatusServiceUnavailable)
+	uc := NewDeliverWebhooksUseCase(repo, events.NewHTTPSender(), 1)
+
+	if _, err := uc.Execute(); err != nil {
+		t.Fatalf("execute: %s", err.Message)
+	}
+	if d := repo.delivery(t, 1); d.Status != webhook.Dead {
+		t.Fatalf("delivery = %+v, want dead", d)
+	}
+
+	redelivered, err := NewRedeliverWebhookUseCase(repo).Execute(RedeliverWebhookDTO{
+		Tenant:         tenant.Tenant{ID: tenant.DefaultID},
+		SubscriptionID: "sub-1",
+		DeliveryID:     "1",
+	})
+	if err != nil {
+		t.Fatalf("redeliver: %s", err.Message)
+	}
+	if redelivered.Status != webhook.Pending || redelivered.Attempts != 0 {
+		t.Fatalf("redelivered = %+v, want pending with no attempts", redelivered)
+	}
+
+	receiver.setStatus(http.StatusOK)
+	if succeeded, err := uc.Execute(); err != nil || succeeded != 1 {
+		t.Fatalf("execute = %d, %v, want 1 delivery", succeeded, err)
+	}
+
+	d := repo.delivery(t, 1)
+	if d.Status != webhook.Succeeded || d.Attempts != 1 || d.DeliveredAt == nil {
+		t.Fatalf("delivery = %+v, want succeeded", d)
+	}
+	if receiver.count() != 2 || string(receiver.bodies[0]) != string(receiver.bodies[1]) {
+		t.Fatalf("receiver got %d requests, want the same event twice", receiver.count())
+	}
+
+	if _, err := NewRedeliverWebhookUseCase(repo).Execute(RedeliverWebhookDTO{
+		Tenant:         tenant.Tenant{ID: tenant.DefaultID},
+		SubscriptionID: "sub-1",
+		DeliveryID:     "404",
+	}); err == nil || err.ErrCode != domain.ErrNotFound {
+		t.Fatalf("redeliver unknown = %v, want not found", err)
+	}
+}
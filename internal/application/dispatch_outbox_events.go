@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"log"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+)
+
+const (
+	outboxBatchSize = 20
+	// outboxLease must outlast the publishing of a whole batch, or another
+	// dispatcher may claim its events again meanwhile.
+	outboxLease          = 5 * time.Minute
+	outboxFirstRetry     = time.Second
+	outboxMaxRetryDelay  = time.Hour
+	outboxRetentionAfter = 7 * 24 * time.Hour
+)
+
+// DispatchOutboxEventsUseCase relays the events of the outbox to the sinks.
+// An event is dispatched once every sink accepted it; when any fails it is
+// published to all of them again later, so delivery is at least once.
+// Retries back off exponentially until maxAttempts, after which the event
+// stays in the outbox, given up on.
+type DispatchOutboxEventsUseCase struct {
+	outbox      repository.IOutboxRepository
+	sinks       []event.ISink
+	maxAttempts int
+}
+
+func NewDispatchOutboxEventsUseCase(outbox repository.IOutboxRepository, sinks []event.ISink, maxAttempts int) *DispatchOutboxEventsUseCase {
+	return &DispatchOutboxEventsUseCase{
+		outbox:      outbox,
+		sinks:       sinks,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Execute dispatches the due events, batch after batch, until none is left.
+func (uc *DispatchOutboxEventsUseCase) Execute() (int, *domain.Error) {
+	dispatched := 0
+
+	for {
+		now := time.Now()
+
+		events, err := uc.outbox.ClaimOutboxEvents(outboxBatchSize, now, now.Add(outboxLease))
+		if err != nil {
+			return dispatched, err
+		}
+
+		for _, e := range events {
+			ok, err := uc.dispatch(e)
+			if err != nil {
+				return dispatched, err
+			}
+
+			if ok {
+				dispatched++
+			}
+		}
+
+		if len(events) < outboxBatchSize {
+			return dispatched, nil
+		}
+	}
+}
+
+func (uc *DispatchOutboxEventsUseCase) dispatch(e repository.OutboxEvent) (bool, *domain.Error) {
+	for _, sink := range uc.sinks {
+		if err := sink.Publish(e.Event); err != nil {
+			return false, uc.retry(e, sink.Name()+": "+err.Message)
+		}
+	}
+
+	return true, uc.outbox.MarkOutboxEventDispatched(e.Event.ID, time.Now())
+}
+
+func (uc *DispatchOutboxEventsUseCase) retry(e repository.OutboxEvent, lastError string) *domain.Error {
+	attempts := e.Attempts + 1
+
+	if attempts >= uc.maxAttempts {
+		log.Printf("giving up on event %d (%s) after %d attempts: %s", e.Event.ID, e.Event.Type, attempts, lastError)
+		return uc.outbox.RetryOutboxEvent(e.Event.ID, attempts, nil, lastError)
+	}
+
+	nextAttemptAt := time.Now().Add(backoff(attempts, outboxFirstRetry, outboxMaxRetryDelay))
+	return uc.outbox.RetryOutboxEvent(e.Event.ID, attempts, &nextAttemptAt, lastError)
+}
+
+// backoff is the delay before the next attempt after the given number of
+// failed ones. It doubles with every failure, from first up to maximum.
+func backoff(attempts int, first, maximum time.Duration) time.Duration {
+	delay := first
+	for i := 1; i < attempts && delay < maximum; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maximum)
+}
+
+// PurgeDispatched deletes the events dispatched longer than a week ago.
+func (uc *DispatchOutboxEventsUseCase) PurgeDispatched() (int, *domain.Error) {
+	return uc.outbox.PurgeDispatchedOutboxEvents(time.Now().Add(-outboxRetentionAfter))
+}
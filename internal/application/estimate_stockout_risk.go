@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const (
+	defaultRiskTrials     = 1000
+	maxRiskTrials         = 100_000
+	defaultRiskConfidence = 0.9
+)
+
+type EstimateStockoutRiskUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewEstimateStockoutRiskUseCase(repo repository.IProductStockRepository) *EstimateStockoutRiskUseCase {
+	return &EstimateStockoutRiskUseCase{
+		repo: repo,
+	}
+}
+
+type DistributionDTO struct {
+	Kind   string
+	Spread float64
+}
+
+// EstimateStockoutRiskDTO optionally narrows the estimation to one product
+// or one category. A nil Seed draws a random one, which is reported back so
+// the run can be reproduced.
+type EstimateStockoutRiskDTO struct {
+	Tenant     tenant.Tenant
+	ProductID  string
+	Category   string
+	Trials     int
+	Seed       *uint64
+	Confidence float64
+	Demand     DistributionDTO
+	LeadTime   DistributionDTO
+	Pagination domain.Pagination
+}
+
+type ProductStockoutRisk struct {
+	restock.StockoutRisk
+	ProductStock *entities.ProductStock
+}
+
+type StockoutRiskReport struct {
+	Trials     int
+	Seed       uint64
+	Confidence float64
+	Products   []ProductStockoutRisk
+}
+
+func (uc *EstimateStockoutRiskUseCase) Execute(dto EstimateStockoutRiskDTO) (*StockoutRiskReport, *domain.Error) {
+	params, err := newRiskParams(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := uc.loadProducts(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	risks := make([]ProductStockoutRisk, len(products))
+	var wg sync.WaitGroup
+
+	for i, p := range products {
+		wg.Go(func() {
+			risks[i] = ProductStockoutRisk{
+				StockoutRisk: restock.EstimateStockoutRisk(p, params),
+				ProductStock: p,
+			}
+		})
+	}
+	wg.Wait()
+
+	sort.Slice(risks, func(i, j int) bool {
+		x := risks[i]
+		y := risks[j]
+
+		if x.StockoutProbability != y.StockoutProbability {
+			return x.StockoutProbability > y.StockoutProbability
+		}
+
+		if x.BelowMinimumProbability != y.BelowMinimumProbability {
+			return x.BelowMinimumProbability > y.BelowMinimumProbability
+		}
+
+		if x.ProductStock.CriticalityLevel != y.ProductStock.CriticalityLevel {
+			return x.ProductStock.CriticalityLevel > y.ProductStock.CriticalityLevel
+		}
+
+		return strings.ToLower(x.ProductStock.Name) < strings.ToLower(y.ProductStock.Name)
+	})
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+
+	return &StockoutRiskReport{
+		Trials:     params.Trials,
+		Seed:       params.Seed,
+		Confidence: params.Confidence,
+		Products:   domain.PaginatedSlice(risks, &dto.Pagination),
+	}, nil
+}
+
+func (uc *EstimateStockoutRiskUseCase) loadProducts(dto EstimateStockoutRiskDTO) ([]*entities.ProductStock, *domain.Error) {
+	repo := uc.repo.ForTenant(dto.Tenant.ID)
+
+	if dto.ProductID != "" {
+		p, err := repo.GetOneByID(dto.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*entities.ProductStock{p}, nil
+	}
+
+	if dto.Category != "" {
+		category := entities.ProductCategory(dto.Category)
+		if !dto.Tenant.AllowsCategory(category) {
+			return nil, domain.NewError("invalid product category", domain.ErrBadRequest)
+		}
+
+		return repo.GetByCategory(category, nil)
+	}
+
+	return repo.GetAll(nil, nil)
+}
+
+func newRiskParams(dto EstimateStockoutRiskDTO) (restock.RiskParams, *domain.Error) {
+	params := restock.RiskParams{
+		Trials:     dto.Trials,
+		Confidence: dto.Confidence,
+		Demand:     restock.Distribution{Kind: restock.PoissonDistribution},
+		LeadTime:   restock.Distribution{Kind: restock.FixedDistribution},
+	}
+
+	if params.Trials == 0 {
+		params.Trials = defaultRiskTrials
+	}
+
+	if params.Trials < 0 || params.Trials > maxRiskTrials {
+		return params, domain.NewError("trials must be between 1 and 100000", domain.ErrBadRequest)
+	}
+
+	if params.Confidence == 0 {
+		params.Confidence = defaultRiskConfidence
+	}
+
+	if params.Confidence <= 0 || params.Confidence >= 1 {
+		return params, domain.NewError("confidence must be between 0 and 1", domain.ErrBadRequest)
+	}
+
+	if dto.Seed != nil {
+		params.Seed = *dto.Seed
+	} else {
+		params.Seed = rand.Uint64()
+	}
+
+	for _, d := range []struct {
+		name   string
+		in     DistributionDTO
+		target *restock.Distribution
+	}{
+		{"demand", dto.Demand, &params.Demand},
+		{"lead time", dto.LeadTime, &params.LeadTime},
+	} {
+		if d.in.Kind != "" {
+			d.target.Kind = restock.DistributionKind(d.in.Kind)
+		}
+		d.target.Spread = d.in.Spread
+
+		if !restock.IsValidDistributionKind(d.target.Kind) {
+			return params, domain.NewError("invalid "+d.name+" distribution", domain.ErrBadRequest)
+		}
+
+		if d.target.Spread < 0 {
+			return params, domain.NewError(d.name+" distribution spread must be non-negative", domain.ErrBadRequest)
+		}
+	}
+
+	return params, nil
+}
@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+)
+
+// EvaluateAlertRulesUseCase is the event sink of the alert rules: after
+// every change to the stock of a product, it raises an alert for each rule
+// of its tenant the product meets, unless the rule is cooling down, and
+// sends it through the channels of the rule. When a channel fails the event
+// is published again later and the alert is sent again to all of them, so
+// channels may get an alert more than once.
+type EvaluateAlertRulesUseCase struct {
+	repo     repository.IProductStockRepository
+	channels map[string]alert.IChannel
+}
+
+func NewEvaluateAlertRulesUseCase(repo repository.IProductStockRepository, channels []alert.IChannel) *EvaluateAlertRulesUseCase {
+	uc := &EvaluateAlertRulesUseCase{
+		repo:     repo,
+		channels: map[string]alert.IChannel{},
+	}
+
+	for _, c := range channels {
+		uc.channels[c.Name()] = c
+	}
+
+	return uc
+}
+
+func (uc *EvaluateAlertRulesUseCase) Name() string {
+	return "alerts"
+}
+
+func (uc *EvaluateAlertRulesUseCase) Publish(e event.Event) *domain.Error {
+	if e.Type != event.ProductCreated && e.Type != event.StockChanged && e.Type != event.ProductRestored {
+		return nil
+	}
+
+	alertRepo, ok := uc.repo.ForTenant(e.TenantID).(repository.IAlertRepository)
+	if !ok {
+		return nil
+	}
+
+	rules, err := alertRepo.GetAlertRules()
+	if err != nil {
+		return err
+	}
+
+	product := e.ProductStock()
+
+	var failures []string
+	for _, rule := range rules {
+		if !rule.Holds(product) {
+			continue
+		}
+
+		a, err := uc.raise(uc.repo.ForTenant(e.TenantID), rule, e)
+		if err != nil {
+			return err
+		}
+
+		if a == nil {
+			continue
+		}
+
+		if reason := uc.send(rule, a); reason != "" {
+			a.Fail(reason)
+			failures = append(failures, reason)
+		} else {
+			a.MarkSent(time.Now())
+		}
+
+		if err := alertRepo.UpdateAlert(a); err != nil {
+			return err
+		}
+	}
+
+	if len(failures) > 0 {
+		return domain.NewError(strings.Join(failures, "; "), domain.ErrInternal)
+	}
+
+	return nil
+}
+
+// raise records the alert of the rule for the event, or returns nil when
+// there is nothing to send: the rule is cooling down, or the alert of the
+// event was sent already. The cooldown is checked and the alert recorded
+// under the lock of the rule and the product, so two events about the
+// product, evaluated at once here or on another instance, cannot both find
+// the rule cool.
+func (uc *EvaluateAlertRulesUseCase) raise(repo repository.IProductStockRepository, rule alert.Rule, e event.Event) (*alert.Alert, *domain.Error) {
+	var raised *alert.Alert
+
+	err := withinTransaction(repo, func(repo repository.IProductStockRepository) *domain.Error {
+		alertRepo := repo.(repository.IAlertRepository)
+
+		if err := alertRepo.LockAlerts(*rule.ID, e.ProductID); err != nil {
+			return err
+		}
+
+		last, err := alertRepo.GetLastAlert(*rule.ID, e.ProductID)
+		if err != nil && err.ErrCode != domain.ErrNotFound {
+			return err
+		}
+
+		if last != nil {
+			// The event is published again after a channel failed.
+			if last.EventID == e.ID {
+				if last.Status != alert.Sent {
+					raised = last
+				}
+				return nil
+			}
+
+			// The time of the change, not of the evaluation, so an event
+			// published late is judged as it would have been on time.
+			if e.OccurredAt.Before(last.CoolsDownAt(rule)) {
+				return nil
+			}
+		}
+
+		a := alert.NewAlert(rule, e, e.OccurredAt)
+
+		id, err := alertRepo.CreateAlert(&a)
+		if err != nil {
+			return err
+		}
+
+		a.ID = id
+		raised = &a
+		return nil
+	})
+	if err != nil {
+		// Another dispatcher raised it meanwhile.
+		if err.ErrCode == domain.ErrConflict {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return raised, nil
+}
+
+// send returns why the alert could not reach some channels of the rule, if
+// it could not. Channels no longer configured are skipped.
+func (uc *EvaluateAlertRulesUseCase) send(rule alert.Rule, a *alert.Alert) string {
+	var failures []string
+
+	for _, name := range rule.Channels {
+		channel, ok := uc.channels[name]
+		if !ok {
+			log.Printf("alert rule %s notifies the %s channel, which is not configured", *rule.ID, name)
+			continue
+		}
+
+		if err := channel.Send(*a); err != nil {
+			failures = append(failures, name+": "+err.Message)
+		}
+	}
+
+	return strings.Join(failures, "; ")
+}
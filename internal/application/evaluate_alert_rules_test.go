@@ -0,0 +1,295 @@
+package usecases
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+// alertTestRepository keeps rules and alerts in memory. The product stock
+// methods are left to the nil embedded interface, since the evaluator never
+// calls them. Its transactions only hold the alert lock.
+type alertTestRepository struct {
+	repository.IProductStockRepository
+
+	mu        sync.Mutex
+	alertLock sync.Mutex
+	rules     []alert.Rule
+	alerts    []alert.Alert
+}
+
+type alertTestTransaction struct {
+	*alertTestRepository
+	locked bool
+}
+
+func (r *alertTestRepository) ForTenant(string) repository.IProductStockRepository {
+	return r
+}
+
+func (r *alertTestRepository) WithinTransaction(fn func(repo repository.IProductStockRepository) *domain.Error) *domain.Error {
+	tx := &alertTestTransaction{alertTestRepository: r}
+	defer func() {
+		if tx.locked {
+			r.alertLock.Unlock()
+		}
+	}()
+
+	return fn(tx)
+}
+
+func (r *alertTestRepository) LockAlerts(string, string) *domain.Error {
+	return nil
+}
+
+func (tx *alertTestTransaction) LockAlerts(string, string) *domain.Error {
+	tx.alertLock.Lock()
+	tx.locked = true
+
+	return nil
+}
+
+func (r *alertTestRepository) CreateAlertRule(rule *alert.Rule) (string, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := "rule-1"
+	rule.ID = &id
+	r.rules = append(r.rules, *rule)
+
+	return id, nil
+}
+
+func (r *alertTestRepository) GetAlertRules() ([]alert.Rule, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.rules), nil
+}
+
+func (r *alertTestRepository) DeleteAlertRule(string) *domain.Error {
+	return domain.NewError("not supported", domain.ErrInternal)
+}
+
+func (r *alertTestRepository) CreateAlert(a *alert.Alert) (int64, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.alerts {
+		if existing.RuleID == a.RuleID && existing.EventID == a.EventID {
+			return 0, domain.NewError("alert already raised", domain.ErrConflict)
+		}
+	}
+
+	a.ID = int64(len(r.alerts) + 1)
+	r.alerts = append(r.alerts, *a)
+
+	return a.ID, nil
+}
+
+func (r *alertTestRepository) UpdateAlert(a *alert.Alert) *domain.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.alerts[a.ID-1] = *a
+
+	return nil
+}
+
+func (r *alertTestRepository) GetLastAlert(ruleID, productID string) (*alert.Alert, *domain.Error) {
+	// Widens the window between the cooldown check and the insert.
+	time.Sleep(time.Millisecond)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.alerts) - 1; i >= 0; i-- {
+		if a := r.alerts[i]; a.RuleID == ruleID && a.ProductID == productID {
+			return &a, nil
+		}
+	}
+
+	return nil, domain.NewError("alert not found", domain.ErrNotFound)
+}
+
+func (r *alertTestRepository) GetAlerts(string, *domain.Pagination) ([]alert.Alert, *domain.Error) {
+	return nil, domain.NewError("not supported", domain.ErrInternal)
+}
+
+func (r *alertTestRepository) CountAlerts(string) (int, *domain.Error) {
+	return 0, domain.NewError("not supported", domain.ErrInternal)
+}
+
+func (r *alertTestRepository) stored() []alert.Alert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.alerts)
+}
+
+// alertTestChannel stands in for the email and Slack channels. It records
+// the subjects it was sent and fails while failing is set.
+type alertTestChannel struct {
+	mu       sync.Mutex
+	name     string
+	failing  bool
+	subjects []string
+}
+
+func (c *alertTestChannel) Name() string {
+	return c.name
+}
+
+func (c *alertTestChannel) Send(n alert.Notification) *domain.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failing {
+		return domain.NewError("connection refused", domain.ErrInternal)
+	}
+
+	c.subjects = append(c.subjects, n.Subject())
+	return nil
+}
+
+// newAlertTest creates a rule notifying both channels when a product is
+// below its minimum stock, with a one hour cooldown.
+func newAlertTest(t *testing.T) (*alertTestRepository, *alertTestChannel, *alertTestChannel, *EvaluateAlertRulesUseCase) {
+	t.Helper()
+
+	email := &alertTestChannel{name: "email"}
+	slack := &alertTestChannel{name: "slack"}
+	repo := &alertTestRepository{}
+
+	rule, err := alert.NewRule("Low oil", alert.BelowMinimum, 0, nil, []string{email.name, slack.name}, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("new rule: %s", err.Message)
+	}
+	if _, err := repo.CreateAlertRule(rule); err != nil {
+		t.Fatalf("create rule: %s", err.Message)
+	}
+
+	return repo, email, slack, NewEvaluateAlertRulesUseCase(repo, []alert.IChannel{email, slack})
+}
+
+func stockChangedEvent(id int64, currentStock int, occurredAt time.Time) event.Event {
+	return event.Event{
+		ID:         id,
+		Type:       event.StockChanged,
+		TenantID:   tenant.DefaultID,
+		ProductID:  "p1",
+		OccurredAt: occurredAt,
+		Data: event.Data{Product: event.Product{
+			Name:             "Oil Filter X",
+			Category:         "oil",
+			CurrentStock:     currentStock,
+			MinimumStock:     10,
+			CriticalityLevel: 3,
+		}},
+	}
+}
+
+func TestEvaluateAlertRulesHonorsCooldown(t *testing.T) {
+	repo, email, slack, uc := newAlertTest(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name   string
+		event  event.Event
+		alerts int
+	}{
+		{"condition not met", stockChangedEvent(1, 25, start), 0},
+		{"first time below minimum", stockChangedEvent(2, 5, start.Add(time.Minute)), 1},
+		{"again inside the cooldown", stockChangedEvent(3, 4, start.Add(31*time.Minute)), 1},
+		{"again once the cooldown passed", stockChangedEvent(4, 3, start.Add(62*time.Minute)), 2},
+	}
+
+	for _, step := range steps {
+		if err := uc.Publish(step.event); err != nil {
+			t.Fatalf("%s: publish: %s", step.name, err.Message)
+		}
+
+		if got := len(repo.stored()); got != step.alerts {
+			t.Fatalf("%s: %d alerts raised, want %d", step.name, got, step.alerts)
+		}
+		if len(email.subjects) != step.alerts || len(slack.subjects) != step.alerts {
+			t.Fatalf("%s: channels got %d and %d alerts, want %d", step.name, len(email.subjects), len(slack.subjects), step.alerts)
+		}
+	}
+
+	for _, a := range repo.stored() {
+		if a.Status != alert.Sent || a.SentAt == nil {
+			t.Fatalf("alert %d = %+v, want sent", a.ID, a)
+		}
+	}
+
+	if want := "Oil Filter X is below its minimum stock"; email.subjects[0] != want {
+		t.Fatalf("subject = %q, want %q", email.subjects[0], want)
+	}
+}
+
+func TestEvaluateAlertRulesResendsWhenTheEventIsPublishedAgain(t *testing.T) {
+	repo, email, slack, uc := newAlertTest(t)
+	e := stockChangedEvent(1, 0, time.Now())
+
+	slack.failing = true
+	if err := uc.Publish(e); err == nil {
+		t.Fatal("publish succeeded although the slack channel failed")
+	}
+
+	alerts := repo.stored()
+	if len(alerts) != 1 || alerts[0].Status != alert.Pending || alerts[0].LastError == nil {
+		t.Fatalf("alerts = %+v, want one pending alert with its error", alerts)
+	}
+
+	// The dispatcher publishes the event again once the channel is back.
+	slack.failing = false
+	if err := uc.Publish(e); err != nil {
+		t.Fatalf("publish again: %s", err.Message)
+	}
+
+	alerts = repo.stored()
+	if len(alerts) != 1 || alerts[0].Status != alert.Sent || alerts[0].LastError != nil {
+		t.Fatalf("alerts = %+v, want the same alert sent", alerts)
+	}
+	if len(email.subjects) != 2 || len(slack.subjects) != 1 {
+		t.Fatalf("channels got %d and %d alerts, want email twice and slack once", len(email.subjects), len(slack.subjects))
+	}
+
+	// Once sent, publishing the event again sends nothing.
+	if err := uc.Publish(e); err != nil {
+		t.Fatalf("publish a third time: %s", err.Message)
+	}
+	if len(repo.stored()) != 1 || len(email.subjects) != 2 || len(slack.subjects) != 1 {
+		t.Fatal("an alert already sent was sent again")
+	}
+}
+
+func TestEvaluateAlertRulesRaisesOnceForConcurrentEvents(t *testing.T) {
+	repo, email, _, uc := newAlertTest(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := uc.Publish(stockChangedEvent(int64(i+1), 5-i%3, start.Add(time.Duration(i)*time.Second))); err != nil {
+				t.Errorf("publish %d: %s", i+1, err.Message)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(repo.stored()); got != 1 || len(email.subjects) != 1 {
+		t.Fatalf("%d alerts raised and %d sent, want one inside the cooldown", got, len(email.subjects))
+	}
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+// exportBatchSize is the page size asked by the exports; the pagination
+// config of the tenant still caps it.
+const exportBatchSize = 500
+
+// ExportProductStockUseCase walks the whole filtered list page by page, so
+// only one page is held in memory at a time.
+type ExportProductStockUseCase struct {
+	list *GetAllProductStockUseCase
+}
+
+func NewExportProductStockUseCase(list *GetAllProductStockUseCase) *ExportProductStockUseCase {
+	return &ExportProductStockUseCase{
+		list: list,
+	}
+}
+
+// Execute calls yield with each page of products. An error returned by yield
+// stops the export and is returned.
+func (uc *ExportProductStockUseCase) Execute(t tenant.Tenant, filter ProductStockFilterDTO, yield func([]*entities.ProductStock) *domain.Error) *domain.Error {
+	pagination := domain.Pagination{Limit: exportBatchSize}
+
+	for {
+		page, err := uc.list.Execute(GetAllProductStockDTO{
+			Tenant:     t,
+			Filter:     filter,
+			Pagination: pagination,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(page.Items) > 0 {
+			if err := yield(page.Items); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+
+		pagination.Cursor = page.NextCursor
+	}
+}
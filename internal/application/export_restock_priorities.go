@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+// ExportRestockPrioritiesUseCase walks the restock priorities page by page,
+// from the same source as GET /restock/priorities.
+type ExportRestockPrioritiesUseCase struct {
+	list *GetProductPriorityUseCase
+}
+
+func NewExportRestockPrioritiesUseCase(list *GetProductPriorityUseCase) *ExportRestockPrioritiesUseCase {
+	return &ExportRestockPrioritiesUseCase{
+		list: list,
+	}
+}
+
+func (uc *ExportRestockPrioritiesUseCase) Execute(t tenant.Tenant, yield func([]restock.Priority) *domain.Error) *domain.Error {
+	pagination := domain.Pagination{Limit: exportBatchSize}
+
+	for {
+		page, err := uc.list.Execute(t, pagination)
+		if err != nil {
+			return err
+		}
+
+		if len(page.Items) > 0 {
+			if err := yield(page.Items); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+
+		pagination.Cursor = page.NextCursor
+	}
+}
@@ -1,30 +1,174 @@
 package usecases
 
 import (
+	"strings"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type GetAllProductStockUseCase struct {
-	repo             repository.IProductStockRepository
-	paginationConfig domain.PaginationConfig
+	repo repository.IProductStockRepository
 }
 
-func NewGetAllProductStockUseCase(repo repository.IProductStockRepository, paginationConfig domain.PaginationConfig) *GetAllProductStockUseCase {
+func NewGetAllProductStockUseCase(repo repository.IProductStockRepository) *GetAllProductStockUseCase {
 	return &GetAllProductStockUseCase{
-		repo:             repo,
-		paginationConfig: paginationConfig,
+		repo: repo,
+	}
+}
+
+// ProductStockFilterDTO mirrors repository.ProductStockFilter with raw
+// values. Sort is a comma separated list of fields, each optionally
+// prefixed with "-" for descending order, e.g. "-unit_cost,name".
+type ProductStockFilterDTO struct {
+	NameContains   string
+	Categories     []string
+	MinCriticality *int
+	MaxCriticality *int
+	MinUnitCost    *float64
+	MaxUnitCost    *float64
+	MinStock       *int
+	MaxStock       *int
+	BelowMinimum   *bool
+	NeedsRestock   *bool
+	IncludeDeleted bool
+	Sort           string
+}
+
+type GetAllProductStockDTO struct {
+	Tenant     tenant.Tenant
+	Filter     ProductStockFilterDTO
+	Pagination domain.Pagination
+}
+
+func (uc *GetAllProductStockUseCase) Execute(dto GetAllProductStockDTO) (*domain.Page[*entities.ProductStock], *domain.Error) {
+	query, err := newProductStockQuery(dto.Tenant, dto.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+
+	return listProductStocks(uc.repo.ForTenant(dto.Tenant.ID), query, dto.Pagination)
+}
+
+func listProductStocks(repo repository.IProductStockRepository, query *repository.ProductStockQuery, pagination domain.Pagination) (*domain.Page[*entities.ProductStock], *domain.Error) {
+	scope := query.CursorScope()
+	if err := domain.ApplyCursor(&pagination, scope, query.SortKeyLength()); err != nil {
+		return nil, err
+	}
+
+	products, err := repo.GetAll(query, pagination.Lookahead())
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(products, pagination, scope, query.SortKey)
+
+	if pagination.IncludeTotal {
+		total, err := repo.Count(query)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
 	}
+
+	return &page, nil
 }
 
-func (uc *GetAllProductStockUseCase) Execute(pagination domain.Pagination) ([]*entities.ProductStock, *domain.Error) {
-	domain.ApplyPaginationRules(&pagination, uc.paginationConfig)
+func newProductStockQuery(t tenant.Tenant, dto ProductStockFilterDTO) (*repository.ProductStockQuery, *domain.Error) {
+	filter := repository.ProductStockFilter{
+		NameContains: strings.TrimSpace(dto.NameContains),
+		MinUnitCost:  dto.MinUnitCost,
+		MaxUnitCost:  dto.MaxUnitCost,
+		MinStock:     dto.MinStock,
+		MaxStock:     dto.MaxStock,
+		BelowMinimum: dto.BelowMinimum,
+		NeedsRestock: dto.NeedsRestock,
 
-	products, err := uc.repo.GetAll(&pagination)
+		IncludeDeleted: dto.IncludeDeleted,
+	}
+
+	for _, c := range dto.Categories {
+		category := entities.ProductCategory(c)
+		if !t.AllowsCategory(category) {
+			return nil, domain.NewError("invalid product category: "+c, domain.ErrBadRequest)
+		}
+		filter.Categories = append(filter.Categories, category)
+	}
+
+	for _, bound := range []struct {
+		in  *int
+		out **entities.CriticalityLevel
+	}{
+		{dto.MinCriticality, &filter.MinCriticality},
+		{dto.MaxCriticality, &filter.MaxCriticality},
+	} {
+		if bound.in == nil {
+			continue
+		}
+
+		level := entities.CriticalityLevel(*bound.in)
+		if !entities.IsValidCriticalityLevel(level) {
+			return nil, domain.NewError("criticality level must be between 1 and 5", domain.ErrBadRequest)
+		}
+		*bound.out = &level
+	}
+
+	if filter.MinCriticality != nil && filter.MaxCriticality != nil && *filter.MinCriticality > *filter.MaxCriticality {
+		return nil, domain.NewError("minimum criticality must not exceed maximum criticality", domain.ErrBadRequest)
+	}
+
+	if (filter.MinUnitCost != nil && *filter.MinUnitCost < 0) || (filter.MaxUnitCost != nil && *filter.MaxUnitCost < 0) {
+		return nil, domain.NewError("unit cost range must be non-negative", domain.ErrBadRequest)
+	}
+
+	if filter.MinUnitCost != nil && filter.MaxUnitCost != nil && *filter.MinUnitCost > *filter.MaxUnitCost {
+		return nil, domain.NewError("minimum unit cost must not exceed maximum unit cost", domain.ErrBadRequest)
+	}
+
+	if filter.MinStock != nil && filter.MaxStock != nil && *filter.MinStock > *filter.MaxStock {
+		return nil, domain.NewError("minimum stock must not exceed maximum stock", domain.ErrBadRequest)
+	}
+
+	sort, err := parseProductStockSort(dto.Sort)
 	if err != nil {
 		return nil, err
 	}
 
-	return products, nil
+	return &repository.ProductStockQuery{Filter: filter, Sort: sort}, nil
+}
+
+func parseProductStockSort(raw string) ([]repository.ProductStockSort, *domain.Error) {
+	var sort []repository.ProductStockSort
+	seen := map[repository.ProductStockSortField]bool{}
+
+	for part := range strings.SplitSeq(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		s := repository.ProductStockSort{}
+		if name, ok := strings.CutPrefix(part, "-"); ok {
+			s.Descending = true
+			part = name
+		}
+		s.Field = repository.ProductStockSortField(strings.TrimPrefix(part, "+"))
+
+		if !repository.IsValidProductStockSortField(s.Field) {
+			return nil, domain.NewError("invalid sort field: "+string(s.Field), domain.ErrBadRequest)
+		}
+
+		if seen[s.Field] {
+			return nil, domain.NewError("duplicated sort field: "+string(s.Field), domain.ErrBadRequest)
+		}
+		seen[s.Field] = true
+
+		sort = append(sort, s)
+	}
+
+	return sort, nil
 }
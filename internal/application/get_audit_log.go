@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const auditCursorScope = "audit"
+
+type GetAuditLogUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetAuditLogUseCase(repo repository.IProductStockRepository) *GetAuditLogUseCase {
+	return &GetAuditLogUseCase{
+		repo: repo,
+	}
+}
+
+type GetAuditLogDTO struct {
+	Tenant     tenant.Tenant
+	EntityID   string
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+	Pagination domain.Pagination
+}
+
+func (uc *GetAuditLogUseCase) Execute(dto GetAuditLogDTO) (*domain.Page[audit.Entry], *domain.Error) {
+	auditRepo, ok := uc.repo.ForTenant(dto.Tenant.ID).(repository.IAuditLogRepository)
+	if !ok {
+		return nil, domain.NewError("the audit log is not supported by the configured repository", domain.ErrInternal)
+	}
+
+	if dto.From != nil && dto.To != nil && !dto.From.Before(*dto.To) {
+		return nil, domain.NewError("from must be before to", domain.ErrBadRequest)
+	}
+
+	query := repository.AuditQuery{
+		EntityID: strings.TrimSpace(dto.EntityID),
+		Actor:    strings.TrimSpace(dto.Actor),
+		From:     dto.From,
+		To:       dto.To,
+	}
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+	if err := domain.ApplyCursor(&dto.Pagination, auditCursorScope, 1); err != nil {
+		return nil, err
+	}
+
+	entries, err := auditRepo.GetAuditEntries(query, dto.Pagination.Lookahead())
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(entries, dto.Pagination, auditCursorScope, func(e audit.Entry) []any {
+		return []any{e.ID}
+	})
+
+	if dto.Pagination.IncludeTotal {
+		total, err := auditRepo.CountAuditEntries(query)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return &page, nil
+}
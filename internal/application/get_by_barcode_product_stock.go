@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type GetByBarcodeProductStockUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetByBarcodeProductStockUseCase(repo repository.IProductStockRepository) *GetByBarcodeProductStockUseCase {
+	return &GetByBarcodeProductStockUseCase{
+		repo: repo,
+	}
+}
+
+// Execute accepts the barcode either as EAN-13 or as UPC-A.
+func (uc *GetByBarcodeProductStockUseCase) Execute(t tenant.Tenant, code string) (*entities.ProductStock, *domain.Error) {
+	barcode, ok := entities.NormalizeBarcode(code)
+	if !ok {
+		return nil, domain.NewError("invalid barcode", domain.ErrBadRequest)
+	}
+
+	return uc.repo.ForTenant(t.ID).GetOneByBarcode(barcode)
+}
@@ -4,38 +4,37 @@ import (
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type GetByCategoryProductStockUseCase struct {
-	repo             repository.IProductStockRepository
-	paginationConfig domain.PaginationConfig
+	repo repository.IProductStockRepository
 }
 
-func NewGetByCategoryProductStockUseCase(repo repository.IProductStockRepository, config domain.PaginationConfig) *GetByCategoryProductStockUseCase {
+func NewGetByCategoryProductStockUseCase(repo repository.IProductStockRepository) *GetByCategoryProductStockUseCase {
 	return &GetByCategoryProductStockUseCase{
-		repo:             repo,
-		paginationConfig: config,
+		repo: repo,
 	}
 }
 
 type GetByCategoryDTO struct {
+	Tenant     tenant.Tenant
 	Category   string
 	Pagination domain.Pagination
 }
 
-func (uc *GetByCategoryProductStockUseCase) Execute(dto GetByCategoryDTO) ([]*entities.ProductStock, *domain.Error) {
+func (uc *GetByCategoryProductStockUseCase) Execute(dto GetByCategoryDTO) (*domain.Page[*entities.ProductStock], *domain.Error) {
 	category := entities.ProductCategory(dto.Category)
 
-	if !entities.IsValidProductCategory(category) {
+	if !dto.Tenant.AllowsCategory(category) {
 		return nil, domain.NewError("invalid product category", domain.ErrBadRequest)
 	}
 
-	domain.ApplyPaginationRules(&dto.Pagination, uc.paginationConfig)
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
 
-	products, err := uc.repo.GetByCategory(category, &dto.Pagination)
-	if err != nil {
-		return nil, err
+	query := &repository.ProductStockQuery{
+		Filter: repository.ProductStockFilter{Categories: []entities.ProductCategory{category}},
 	}
 
-	return products, nil
+	return listProductStocks(uc.repo.ForTenant(dto.Tenant.ID), query, dto.Pagination)
 }
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type GetBySKUProductStockUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetBySKUProductStockUseCase(repo repository.IProductStockRepository) *GetBySKUProductStockUseCase {
+	return &GetBySKUProductStockUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetBySKUProductStockUseCase) Execute(t tenant.Tenant, sku string) (*entities.ProductStock, *domain.Error) {
+	sku = entities.NormalizeSKU(sku)
+	if sku == "" {
+		return nil, domain.NewError("sku is required", domain.ErrBadRequest)
+	}
+
+	return uc.repo.ForTenant(t.ID).GetOneBySKU(sku)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type GetOneProductStockUseCase struct {
@@ -16,12 +17,24 @@ func NewGetOneProductStockUseCase(repo repository.IProductStockRepository) *GetO
 	}
 }
 
-func (uc *GetOneProductStockUseCase) Execute(id string) (*entities.ProductStock, *domain.Error) {
-	if id == "" {
+type GetOneProductStockDTO struct {
+	Tenant         tenant.Tenant
+	ID             string
+	IncludeDeleted bool
+}
+
+func (uc *GetOneProductStockUseCase) Execute(dto GetOneProductStockDTO) (*entities.ProductStock, *domain.Error) {
+	if dto.ID == "" {
 		return nil, domain.NewError("id is required", domain.ErrBadRequest)
 	}
 
-	product, err := uc.repo.GetOneByID(id)
+	repo := uc.repo.ForTenant(dto.Tenant.ID)
+
+	if dto.IncludeDeleted {
+		return repo.GetOneByIDIncludingDeleted(dto.ID)
+	}
+
+	product, err := repo.GetOneByID(dto.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type GetProductStockHistoryUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetProductStockHistoryUseCase(repo repository.IProductStockRepository) *GetProductStockHistoryUseCase {
+	return &GetProductStockHistoryUseCase{
+		repo: repo,
+	}
+}
+
+// GetProductStockHistoryDTO asks for the latest audit entries of several
+// products at once. Limit applies to each product and follows the tenant
+// pagination rules.
+type GetProductStockHistoryDTO struct {
+	Tenant     tenant.Tenant
+	ProductIDs []string
+	Limit      int
+}
+
+func (uc *GetProductStockHistoryUseCase) Execute(dto GetProductStockHistoryDTO) (map[string][]audit.Entry, *domain.Error) {
+	auditRepo, ok := uc.repo.ForTenant(dto.Tenant.ID).(repository.IAuditLogRepository)
+	if !ok {
+		return nil, domain.NewError("the audit log is not supported by the configured repository", domain.ErrInternal)
+	}
+
+	pagination := domain.Pagination{Limit: dto.Limit}
+	domain.ApplyPaginationRules(&pagination, dto.Tenant.Pagination)
+
+	return auditRepo.GetLatestAuditEntries(dto.ProductIDs, pagination.Limit)
+}
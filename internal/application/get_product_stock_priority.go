@@ -1,84 +1,147 @@
 package usecases
 
 import (
-	"sort"
-	"strings"
-	"sync"
+	"time"
 
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type GetProductPriorityUseCase struct {
-	repo             repository.IProductStockRepository
-	paginationConfig domain.PaginationConfig
+	repo repository.IProductStockRepository
 }
 
-func NewGetProductPriorityUseCase(repo repository.IProductStockRepository, paginationConfig domain.PaginationConfig) *GetProductPriorityUseCase {
+// NewGetProductPriorityUseCase serves the priorities from the snapshot of
+// repositories keeping one, building it on the first call, and computes
+// them on every call otherwise.
+func NewGetProductPriorityUseCase(repo repository.IProductStockRepository) *GetProductPriorityUseCase {
 	return &GetProductPriorityUseCase{
-		repo:             repo,
-		paginationConfig: paginationConfig,
+		repo: repo,
 	}
 }
 
-type ProductStockPriority struct {
-	ExpectedConsumption int
-	ProjectedStock      int
-	UrgencyScore        int
-	ProductStock        *entities.ProductStock
+type RestockPriorities struct {
+	domain.Page[restock.Priority]
+	ComputedAt time.Time
 }
 
-func (uc *GetProductPriorityUseCase) Execute(pagination domain.Pagination) ([]ProductStockPriority, *domain.Error) {
-	products, err := uc.repo.GetAll(nil)
+func (uc *GetProductPriorityUseCase) Execute(t tenant.Tenant, pagination domain.Pagination) (*RestockPriorities, *domain.Error) {
+	domain.ApplyPaginationRules(&pagination, t.Pagination)
+
+	if err := domain.ApplyCursor(&pagination, restock.CursorScope, restock.SortKeyLength); err != nil {
+		return nil, err
+	}
+
+	repo := uc.repo.ForTenant(t.ID)
+
+	var items []restock.Priority
+	var computedAt time.Time
+	var err *domain.Error
+
+	if snapshotRepo, ok := repo.(repository.IRestockPrioritySnapshotRepository); ok {
+		items, computedAt, err = getRestockPrioritySnapshot(snapshotRepo, pagination.Lookahead())
+	} else {
+		computedAt = time.Now()
+		items, err = listRestockPriorities(repo, nil, pagination.Lookahead())
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var priorityList []ProductStockPriority
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, p := range products {
-		wg.Go(func() {
-			expectedConsumption := p.AverageDailySales * p.LeadTimeDays
-			projectedStock := p.CurrentStock - expectedConsumption
-			isRepositionNeeded := projectedStock < p.MinimumStock
-
-			if isRepositionNeeded {
-				mu.Lock()
-				priorityList = append(priorityList, ProductStockPriority{
-					ProductStock:        p,
-					ExpectedConsumption: expectedConsumption,
-					ProjectedStock:      projectedStock,
-					UrgencyScore:        (p.MinimumStock - projectedStock) * int(p.CriticalityLevel),
-				})
-				mu.Unlock()
-			}
-		})
-	}
-	wg.Wait()
-
-	sort.Slice(priorityList, func(i, j int) bool {
-		x := priorityList[i]
-		y := priorityList[j]
-
-		if x.UrgencyScore != y.UrgencyScore {
-			return x.UrgencyScore > y.UrgencyScore
-		}
+	priorities := &RestockPriorities{
+		Page:       domain.NewPage(items, pagination, restock.CursorScope, restock.Priority.SortKey),
+		ComputedAt: computedAt,
+	}
 
-		if x.ProductStock.CriticalityLevel != y.ProductStock.CriticalityLevel {
-			return x.ProductStock.CriticalityLevel > y.ProductStock.CriticalityLevel
+	if pagination.IncludeTotal {
+		total, err := countRestockPriorities(repo)
+		if err != nil {
+			return nil, err
 		}
+		priorities.Total = &total
+	}
 
-		if x.ProductStock.AverageDailySales != y.ProductStock.AverageDailySales {
-			return x.ProductStock.AverageDailySales > y.ProductStock.AverageDailySales
-		}
+	return priorities, nil
+}
 
-		return strings.ToLower(x.ProductStock.Name) < strings.ToLower(y.ProductStock.Name)
-	})
+// getRestockPrioritySnapshot builds the snapshot when the tenant has none
+// yet.
+func getRestockPrioritySnapshot(repo repository.IRestockPrioritySnapshotRepository, pagination *domain.Pagination) ([]restock.Priority, time.Time, *domain.Error) {
+	items, computedAt, err := repo.GetRestockPrioritySnapshot(pagination)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if computedAt != nil {
+		return items, *computedAt, nil
+	}
+
+	if err := repo.RebuildRestockPrioritySnapshot(time.Now()); err != nil {
+		return nil, time.Time{}, err
+	}
 
-	domain.ApplyPaginationRules(&pagination, uc.paginationConfig)
+	items, computedAt, err = repo.GetRestockPrioritySnapshot(pagination)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if computedAt == nil {
+		return nil, time.Time{}, domain.NewError("restock priority snapshot was not built", domain.ErrInternal)
+	}
+
+	return items, *computedAt, nil
+}
+
+func countRestockPriorities(repo repository.IProductStockRepository) (int, *domain.Error) {
+	if snapshotRepo, ok := repo.(repository.IRestockPrioritySnapshotRepository); ok {
+		return snapshotRepo.CountRestockPrioritySnapshot()
+	}
+
+	if priorityRepo, ok := repo.(repository.IRestockPriorityRepository); ok {
+		return priorityRepo.CountRestockPriorities()
+	}
+
+	priorities, err := listRestockPriorities(repo, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(priorities), nil
+}
+
+// listRestockPriorities lists the priorities of the products of the given
+// categories, or of every category when there are none.
+func listRestockPriorities(repo repository.IProductStockRepository, categories []entities.ProductCategory, pagination *domain.Pagination) ([]restock.Priority, *domain.Error) {
+	if priorityRepo, ok := repo.(repository.IRestockPriorityRepository); ok {
+		return priorityRepo.GetRestockPriorities(categories, pagination)
+	}
+
+	var query *repository.ProductStockQuery
+	if len(categories) > 0 {
+		query = &repository.ProductStockQuery{Filter: repository.ProductStockFilter{Categories: categories}}
+	}
+
+	products, err := repo.GetAll(query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := restock.Prioritize(products)
+	if pagination == nil {
+		return priorities, nil
+	}
+
+	if pagination.After == nil {
+		return domain.PaginatedSlice(priorities, pagination), nil
+	}
+
+	priorities, ok := restock.After(priorities, pagination.After)
+	if !ok {
+		return nil, domain.NewError("invalid cursor", domain.ErrBadRequest)
+	}
 
-	return domain.PaginatedSlice(priorityList, &pagination), nil
+	return priorities[:min(pagination.Limit, len(priorities))], nil
 }
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// IdempotentRequestUseCase deduplicates retried requests: the first request
+// with a key reserves it and stores its response, the retries get that
+// response back. Keys belong to the tenant and actor that sent them, and are
+// bound to the fingerprint of the request they were first used with.
+type IdempotentRequestUseCase struct {
+	repo repository.IIdempotencyKeyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotentRequestUseCase(repo repository.IIdempotencyKeyRepository, ttl time.Duration) *IdempotentRequestUseCase {
+	return &IdempotentRequestUseCase{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+type BeginIdempotentRequestDTO struct {
+	Tenant      tenant.Tenant
+	Actor       string
+	Key         string
+	Fingerprint string
+}
+
+// ID returns the identity under which the key is stored.
+func (dto BeginIdempotentRequestDTO) ID() repository.IdempotencyKeyID {
+	return repository.IdempotencyKeyID{
+		TenantID: dto.Tenant.ID,
+		Actor:    dto.Actor,
+		Key:      dto.Key,
+	}
+}
+
+// Begin returns the stored response of a retried request. A nil response
+// means the key was reserved and the request must be processed, then
+// completed or released.
+func (uc *IdempotentRequestUseCase) Begin(dto BeginIdempotentRequestDTO) (*repository.IdempotentResponse, *domain.Error) {
+	if dto.Key == "" || len(dto.Key) > maxIdempotencyKeyLength {
+		return nil, domain.NewError("idempotency key must have between 1 and 255 characters", domain.ErrBadRequest)
+	}
+
+	now := time.Now()
+	existing, err := uc.repo.ReserveIdempotencyKey(repository.IdempotencyKey{
+		IdempotencyKeyID: dto.ID(),
+		Fingerprint:      dto.Fingerprint,
+		ExpiresAt:        now.Add(uc.ttl),
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, nil
+	}
+
+	if existing.Fingerprint != dto.Fingerprint {
+		return nil, domain.NewError("idempotency key was already used with a different request", domain.ErrUnprocessable)
+	}
+
+	if existing.Response == nil {
+		return nil, domain.NewError("a request with this idempotency key is still being processed", domain.ErrConflict)
+	}
+
+	return existing.Response, nil
+}
+
+func (uc *IdempotentRequestUseCase) Complete(id repository.IdempotencyKeyID, response repository.IdempotentResponse) *domain.Error {
+	return uc.repo.CompleteIdempotencyKey(id, response)
+}
+
+// Release frees a reserved key whose request failed unexpectedly, so the
+// client can retry it.
+func (uc *IdempotentRequestUseCase) Release(id repository.IdempotencyKeyID) *domain.Error {
+	return uc.repo.ReleaseIdempotencyKey(id)
+}
+
+func (uc *IdempotentRequestUseCase) PurgeExpired() (int, *domain.Error) {
+	return uc.repo.DeleteExpiredIdempotencyKeys(time.Now())
+}
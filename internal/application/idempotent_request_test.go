@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/memory"
+)
+
+func TestIdempotencyKeysAreScopedToTenantAndActor(t *testing.T) {
+	uc := NewIdempotentRequestUseCase(memory.NewIdempotencyKeyRepository(), time.Hour)
+
+	first := BeginIdempotentRequestDTO{Tenant: tenant.Tenant{ID: "north"}, Actor: "jane", Key: "k", Fingerprint: "a"}
+	if stored, err := uc.Begin(first); err != nil || stored != nil {
+		t.Fatalf("first request = %v, %v, want the key reserved", stored, err)
+	}
+	if err := uc.Complete(first.ID(), repository.IdempotentResponse{StatusCode: 201}); err != nil {
+		t.Fatalf("complete: %v", err.Message)
+	}
+
+	for _, other := range []BeginIdempotentRequestDTO{
+		{Tenant: tenant.Tenant{ID: "south"}, Actor: "jane", Key: "k", Fingerprint: "b"},
+		{Tenant: tenant.Tenant{ID: "north"}, Actor: "john", Key: "k", Fingerprint: "b"},
+	} {
+		if stored, err := uc.Begin(other); err != nil || stored != nil {
+			t.Fatalf("same key from %s/%s = %v, %v, want the key reserved", other.Tenant.ID, other.Actor, stored, err)
+		}
+	}
+
+	stored, err := uc.Begin(first)
+	if err != nil || stored == nil || stored.StatusCode != 201 {
+		t.Fatalf("retry = %v, %v, want the stored response", stored, err)
+	}
+
+	first.Fingerprint = "b"
+	if _, err := uc.Begin(first); err == nil || err.ErrCode != domain.ErrUnprocessable {
+		t.Fatalf("reuse with another request = %v, want unprocessable", err)
+	}
+}
@@ -0,0 +1,421 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type ImportAction string
+
+const (
+	ImportCreate ImportAction = "create"
+	ImportUpdate ImportAction = "update"
+)
+
+const (
+	maxImportRows           = 10_000
+	importExternalIDPrefix  = "external_id."
+	importBarcodeSeparators = " |;"
+)
+
+var (
+	importRequiredColumns = []string{"name", "category", "unit_cost", "criticality_level"}
+	importOptionalColumns = []string{"current_stock", "minimum_stock", "average_daily_sales", "lead_time_days", "sku", "barcodes"}
+)
+
+type ImportProductStockUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewImportProductStockUseCase(repo repository.IProductStockRepository) *ImportProductStockUseCase {
+	return &ImportProductStockUseCase{
+		repo: repo,
+	}
+}
+
+// ImportProductStockDTO is a table already read from the uploaded file: the
+// header names the columns and each row is a product. Rows with an SKU of
+// an existing product update it, the others create a product.
+type ImportProductStockDTO struct {
+	Tenant tenant.Tenant
+	Header []string
+	Rows   [][]string
+	DryRun bool
+	Actor  string
+}
+
+// ImportRowResult reports a row by its position in the file, counting the
+// header as row 1.
+type ImportRowResult struct {
+	Row    int
+	Action ImportAction
+	ID     *string
+	SKU    *string
+	Errors []string
+}
+
+// ImportReport is returned whether or not the import was written: nothing
+// is written in a dry run or when any row failed.
+type ImportReport struct {
+	DryRun    bool
+	Committed bool
+	Created   int
+	Updated   int
+	Failed    int
+	Rows      []ImportRowResult
+}
+
+func (uc *ImportProductStockUseCase) Execute(dto ImportProductStockDTO) (*ImportReport, *domain.Error) {
+	columns, err := newImportColumns(dto.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dto.Rows) == 0 {
+		return nil, domain.NewError("the file has no rows", domain.ErrBadRequest)
+	}
+
+	if len(dto.Rows) > maxImportRows {
+		return nil, domain.NewError(fmt.Sprintf("the file has more than %d rows", maxImportRows), domain.ErrBadRequest)
+	}
+
+	repo := uc.repo.ForTenant(dto.Tenant.ID)
+
+	// A dry run only validates. Otherwise the rows are validated and written
+	// in a single pass, in a transaction rolled back when any row fails;
+	// without transactions the file is validated first, so an invalid file
+	// writes nothing.
+	var report *ImportReport
+
+	if _, transactional := repo.(repository.ITransactionalRepository); dto.DryRun || !transactional {
+		report, err = importRows(repo, dto.Tenant, columns, dto.Rows, false, dto.Actor)
+		if err != nil {
+			return nil, err
+		}
+
+		report.DryRun = dto.DryRun
+		if dto.DryRun || report.Failed > 0 {
+			return report, nil
+		}
+	}
+
+	run := func(repo repository.IProductStockRepository) *domain.Error {
+		report, err = importRows(repo, dto.Tenant, columns, dto.Rows, true, dto.Actor)
+		if err != nil {
+			return err
+		}
+
+		if report.Failed > 0 {
+			return domain.NewError("import has invalid rows", domain.ErrBadRequest)
+		}
+
+		return nil
+	}
+
+	// Without transactions a row failing to be written leaves the rows
+	// before it written.
+	err = withinTransaction(repo, run)
+
+	if report != nil && report.Failed > 0 {
+		return report, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	report.Committed = true
+	return report, nil
+}
+
+type importColumns struct {
+	index       map[string]int
+	externalIDs map[string]int
+}
+
+func newImportColumns(header []string) (importColumns, *domain.Error) {
+	columns := importColumns{
+		index:       map[string]int{},
+		externalIDs: map[string]int{},
+	}
+
+	known := map[string]bool{}
+	for _, name := range append(importRequiredColumns, importOptionalColumns...) {
+		known[name] = true
+	}
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if system, ok := strings.CutPrefix(name, importExternalIDPrefix); ok && system != "" {
+			if _, exists := columns.externalIDs[system]; exists {
+				return columns, domain.NewError("duplicate column: "+name, domain.ErrBadRequest)
+			}
+			columns.externalIDs[system] = i
+			continue
+		}
+
+		if !known[name] {
+			return columns, domain.NewError("unknown column: "+name, domain.ErrBadRequest)
+		}
+
+		if _, exists := columns.index[name]; exists {
+			return columns, domain.NewError("duplicate column: "+name, domain.ErrBadRequest)
+		}
+		columns.index[name] = i
+	}
+
+	for _, name := range importRequiredColumns {
+		if _, ok := columns.index[name]; !ok {
+			return columns, domain.NewError("missing required column: "+name, domain.ErrBadRequest)
+		}
+	}
+
+	return columns, nil
+}
+
+func (c importColumns) value(row []string, name string) (string, bool) {
+	i, ok := c.index[name]
+	if !ok {
+		return "", false
+	}
+
+	if i >= len(row) {
+		return "", true
+	}
+
+	return strings.TrimSpace(row[i]), true
+}
+
+// importRows validates every row and, when write is set, writes the valid
+// ones on behalf of the actor until a row fails, since the import is then
+// rolled back. Categories must be among the tenant's. A failed write is
+// reported on its row like a validation error, so a single run reports
+// every problem of the file.
+func importRows(repo repository.IProductStockRepository, t tenant.Tenant, columns importColumns, rows [][]string, write bool, actor string) (*ImportReport, *domain.Error) {
+	report := &ImportReport{Rows: make([]ImportRowResult, len(rows))}
+	skuRows := map[string]int{}
+	barcodeRows := map[string]int{}
+
+	for i, row := range rows {
+		result := &report.Rows[i]
+		result.Row = i + 2
+
+		p, before, err := importRow(repo, t, columns, row, result)
+		if err != nil {
+			return nil, err
+		}
+
+		if p != nil && p.Identifiers.SKU != nil {
+			if first, ok := skuRows[*p.Identifiers.SKU]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("sku already used in row %d", first))
+			} else {
+				skuRows[*p.Identifiers.SKU] = result.Row
+			}
+		}
+
+		if p != nil {
+			for _, barcode := range p.Identifiers.Barcodes {
+				if first, ok := barcodeRows[barcode]; ok {
+					result.Errors = append(result.Errors, fmt.Sprintf("barcode %s already used in row %d", barcode, first))
+				} else {
+					barcodeRows[barcode] = result.Row
+				}
+			}
+		}
+
+		if write && report.Failed == 0 && len(result.Errors) == 0 {
+			if err := writeImportedProduct(repo, p, before, result, actor); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			report.Failed++
+			continue
+		}
+
+		if result.Action == ImportCreate {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+
+	return report, nil
+}
+
+// importRow builds the product of a row, starting from the existing product
+// with the same SKU, which is returned as well. Columns missing from the
+// header keep the existing values on update and are zero on create. Row
+// problems are appended to the result; only repository failures are
+// returned.
+func importRow(repo repository.IProductStockRepository, t tenant.Tenant, columns importColumns, row []string, result *ImportRowResult) (*entities.ProductStock, *entities.ProductStock, *domain.Error) {
+	p := &entities.ProductStock{}
+	var before *entities.ProductStock
+	result.Action = ImportCreate
+
+	if sku, ok := columns.value(row, "sku"); ok && sku != "" {
+		sku = entities.NormalizeSKU(sku)
+		result.SKU = &sku
+
+		existing, err := repo.GetOneBySKUIncludingDeleted(sku)
+		switch {
+		case err == nil && existing.DeletedAt != nil:
+			result.Errors = append(result.Errors, deletedOwnerMessage("sku", sku, *existing.ID))
+		case err == nil:
+			original := *existing
+			before = &original
+			p = existing
+			result.Action = ImportUpdate
+			result.ID = existing.ID
+		case err.ErrCode != domain.ErrNotFound:
+			return nil, nil, err
+		}
+
+		p.Identifiers.SKU = &sku
+	}
+
+	name, _ := columns.value(row, "name")
+	category, _ := columns.value(row, "category")
+
+	fail := func(column, message string) {
+		result.Errors = append(result.Errors, column+": "+message)
+	}
+
+	if category != "" && !t.AllowsCategory(entities.ProductCategory(category)) {
+		fail("category", "invalid product category")
+	}
+
+	ints := []struct {
+		column string
+		target *int
+	}{
+		{"current_stock", &p.CurrentStock},
+		{"minimum_stock", &p.MinimumStock},
+		{"average_daily_sales", &p.AverageDailySales},
+		{"lead_time_days", &p.LeadTimeDays},
+	}
+	for _, field := range ints {
+		if raw, ok := columns.value(row, field.column); ok {
+			value, err := parseImportInt(raw)
+			if err != nil {
+				fail(field.column, "must be an integer")
+				continue
+			}
+			*field.target = value
+		}
+	}
+
+	unitCostRaw, _ := columns.value(row, "unit_cost")
+	unitCost, err := strconv.ParseFloat(unitCostRaw, 64)
+	if err != nil {
+		fail("unit_cost", "must be a number")
+	}
+
+	criticalityRaw, _ := columns.value(row, "criticality_level")
+	criticality, err := strconv.Atoi(criticalityRaw)
+	if err != nil {
+		fail("criticality_level", "must be an integer")
+	}
+
+	if raw, ok := columns.value(row, "barcodes"); ok {
+		p.Identifiers.Barcodes = strings.FieldsFunc(raw, func(r rune) bool {
+			return strings.ContainsRune(importBarcodeSeparators, r)
+		})
+	}
+
+	externalIDs := map[string]string{}
+	for system, id := range p.Identifiers.ExternalIDs {
+		externalIDs[system] = id
+	}
+	for system, i := range columns.externalIDs {
+		delete(externalIDs, system)
+		if i < len(row) && strings.TrimSpace(row[i]) != "" {
+			externalIDs[system] = row[i]
+		}
+	}
+	p.Identifiers.ExternalIDs = externalIDs
+
+	if len(result.Errors) > 0 {
+		return nil, nil, nil
+	}
+
+	product, domainErr := entities.NewProductStock(
+		p.ID,
+		name,
+		p.Identifiers,
+		entities.ProductCategory(category),
+		p.CurrentStock,
+		p.MinimumStock,
+		p.AverageDailySales,
+		p.LeadTimeDays,
+		unitCost,
+		entities.CriticalityLevel(criticality),
+	)
+	if domainErr != nil {
+		result.Errors = append(result.Errors, domainErr.Message)
+		return nil, nil, nil
+	}
+
+	for _, barcode := range product.Identifiers.Barcodes {
+		owner, err := repo.GetOneByBarcodeIncludingDeleted(barcode)
+		if err != nil {
+			if err.ErrCode == domain.ErrNotFound {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		if owner.DeletedAt != nil {
+			result.Errors = append(result.Errors, deletedOwnerMessage("barcode", barcode, *owner.ID))
+		} else if product.ID == nil || *owner.ID != *product.ID {
+			result.Errors = append(result.Errors, "barcode "+barcode+" already belongs to product "+*owner.ID)
+		}
+	}
+
+	return product, before, nil
+}
+
+func writeImportedProduct(repo repository.IProductStockRepository, p, before *entities.ProductStock, result *ImportRowResult, actor string) *domain.Error {
+	var err *domain.Error
+
+	if result.Action == ImportCreate {
+		var id string
+		if id, err = repo.Create(p); err == nil {
+			p.ID = &id
+			result.ID = &id
+			err = recordProductStockChange(repo, actor, audit.Create, nil, p, time.Now())
+		}
+	} else if err = repo.Update(p); err == nil {
+		err = recordProductStockChange(repo, actor, audit.Update, before, p, time.Now())
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	if err.ErrCode == domain.ErrInternal {
+		return err
+	}
+
+	result.Errors = append(result.Errors, err.Message)
+	return nil
+}
+
+// parseImportInt treats an empty cell as zero, like a missing column.
+func parseImportInt(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(raw)
+}
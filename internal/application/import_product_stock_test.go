@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+// importTestRepository is a transactional repository keeping products by
+// SKU and counting the identifier lookups. A rolled back transaction drops
+// the products created in it.
+type importTestRepository struct {
+	repository.IProductStockRepository
+
+	products map[string]*entities.ProductStock
+	lookups  int
+}
+
+func (r *importTestRepository) ForTenant(string) repository.IProductStockRepository {
+	return r
+}
+
+func (r *importTestRepository) WithinTransaction(fn func(repo repository.IProductStockRepository) *domain.Error) *domain.Error {
+	saved := map[string]*entities.ProductStock{}
+	for sku, p := range r.products {
+		saved[sku] = p
+	}
+
+	if err := fn(r); err != nil {
+		r.products = saved
+		return err
+	}
+
+	return nil
+}
+
+func (r *importTestRepository) GetOneBySKUIncludingDeleted(sku string) (*entities.ProductStock, *domain.Error) {
+	r.lookups++
+
+	p, ok := r.products[sku]
+	if !ok {
+		return nil, domain.NewError("product not found", domain.ErrNotFound)
+	}
+
+	copied := *p
+	return &copied, nil
+}
+
+func (r *importTestRepository) GetOneByBarcodeIncludingDeleted(string) (*entities.ProductStock, *domain.Error) {
+	r.lookups++
+	return nil, domain.NewError("product not found", domain.ErrNotFound)
+}
+
+func (r *importTestRepository) Create(p *entities.ProductStock) (string, *domain.Error) {
+	id := "p" + strconv.Itoa(len(r.products)+1)
+	created := *p
+	created.ID = &id
+	r.products[*p.Identifiers.SKU] = &created
+
+	return id, nil
+}
+
+func (r *importTestRepository) Update(p *entities.ProductStock) *domain.Error {
+	updated := *p
+	r.products[*p.Identifiers.SKU] = &updated
+
+	return nil
+}
+
+func TestImportProductStockLooksUpEachRowOnce(t *testing.T) {
+	tests := []struct {
+		name      string
+		dryRun    bool
+		rows      [][]string
+		committed bool
+		failed    int
+	}{
+		{"valid file", false, [][]string{{"A-1", "Filter", "oil", "10", "3"}, {"A-2", "Pump", "engine", "20", "4"}}, true, 0},
+		{"invalid row", false, [][]string{{"A-1", "Filter", "oil", "10", "3"}, {"A-2", "Pump", "engine", "x", "4"}}, false, 1},
+		{"dry run", true, [][]string{{"A-1", "Filter", "oil", "10", "3"}, {"A-2", "Pump", "engine", "20", "4"}}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &importTestRepository{products: map[string]*entities.ProductStock{}}
+
+			report, err := NewImportProductStockUseCase(repo).Execute(ImportProductStockDTO{
+				Tenant: tenant.Tenant{ID: tenant.DefaultID, Categories: []entities.ProductCategory{"oil", "engine"}},
+				Header: []string{"sku", "name", "category", "unit_cost", "criticality_level"},
+				Rows:   tt.rows,
+				DryRun: tt.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("import: %s", err.Message)
+			}
+
+			if report.Committed != tt.committed || report.Failed != tt.failed {
+				t.Fatalf("report = %+v, want committed %v with %d failed rows", report, tt.committed, tt.failed)
+			}
+
+			if repo.lookups != len(tt.rows) {
+				t.Fatalf("looked up identifiers %d times for %d rows", repo.lookups, len(tt.rows))
+			}
+
+			if want := map[bool]int{true: len(tt.rows)}[tt.committed]; len(repo.products) != want {
+				t.Fatalf("%d products stored, want %d", len(repo.products), want)
+			}
+		})
+	}
+}
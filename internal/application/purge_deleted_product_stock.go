@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+)
+
+// PurgeDeletedProductStockUseCase removes for good the products deleted
+// longer than the retention ago. Until then they can be restored.
+type PurgeDeletedProductStockUseCase struct {
+	repo      repository.IProductStockRepository
+	tenants   repository.ITenantRepository
+	retention time.Duration
+}
+
+func NewPurgeDeletedProductStockUseCase(repo repository.IProductStockRepository, tenants repository.ITenantRepository, retention time.Duration) *PurgeDeletedProductStockUseCase {
+	return &PurgeDeletedProductStockUseCase{
+		repo:      repo,
+		tenants:   tenants,
+		retention: retention,
+	}
+}
+
+// Execute purges the products of every tenant, each in its own transaction.
+func (uc *PurgeDeletedProductStockUseCase) Execute() (int, *domain.Error) {
+	tenants, err := uc.tenants.GetTenants()
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, t := range tenants {
+		n, err := uc.purge(uc.repo.ForTenant(t.ID))
+		if err != nil {
+			return purged, err
+		}
+		purged += n
+	}
+
+	return purged, nil
+}
+
+func (uc *PurgeDeletedProductStockUseCase) purge(repo repository.IProductStockRepository) (int, *domain.Error) {
+	purged := 0
+
+	err := withinTransaction(repo, func(repo repository.IProductStockRepository) *domain.Error {
+		now := time.Now()
+
+		ids, err := repo.PurgeDeletedProductStocks(now.Add(-uc.retention))
+		if err != nil {
+			return err
+		}
+
+		entries := make([]audit.Entry, len(ids))
+		for i, id := range ids {
+			entries[i] = audit.NewProductStockEntry(audit.SystemActor, audit.Purge, nil, nil, now)
+			entries[i].EntityID = id
+		}
+
+		purged = len(ids)
+		return recordAudit(repo, entries...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
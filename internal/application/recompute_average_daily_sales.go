@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"math"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const (
+	// salesWindow is the period over which the average daily sales are
+	// computed.
+	salesWindow = 30 * 24 * time.Hour
+	// minSalesHistory keeps the average of the products recorded for less
+	// than this, which says too little about their sales.
+	minSalesHistory = 7 * 24 * time.Hour
+)
+
+// RecomputeAverageDailySalesUseCase sets the average daily sales of the
+// products to the stock taken out of them per day over the last 30 days,
+// or since they were first recorded in the audit log when more recently.
+// Every decrease of the current stock counts as sold.
+type RecomputeAverageDailySalesUseCase struct {
+	repo     repository.IProductStockRepository
+	tenants  repository.ITenantRepository
+	updateUC *UpdateProductStockUseCase
+}
+
+func NewRecomputeAverageDailySalesUseCase(repo repository.IProductStockRepository, tenants repository.ITenantRepository, updateUC *UpdateProductStockUseCase) *RecomputeAverageDailySalesUseCase {
+	return &RecomputeAverageDailySalesUseCase{
+		repo:     repo,
+		tenants:  tenants,
+		updateUC: updateUC,
+	}
+}
+
+// Execute returns the number of products whose average changed.
+func (uc *RecomputeAverageDailySalesUseCase) Execute() (int, *domain.Error) {
+	tenants, err := uc.tenants.GetTenants()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, t := range tenants {
+		n, err := uc.recompute(t, time.Now())
+		updated += n
+		if err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
+
+func (uc *RecomputeAverageDailySalesUseCase) recompute(t tenant.Tenant, now time.Time) (int, *domain.Error) {
+	repo := uc.repo.ForTenant(t.ID)
+
+	auditRepo, ok := repo.(repository.IAuditLogRepository)
+	if !ok {
+		return 0, domain.NewError("the audit log is not supported by the configured repository", domain.ErrInternal)
+	}
+
+	outflows, err := auditRepo.GetStockOutflows(now.Add(-salesWindow))
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, outflow := range outflows {
+		recorded := min(now.Sub(outflow.FirstRecordedAt), salesWindow)
+		if recorded < minSalesHistory {
+			continue
+		}
+
+		average := int(math.Round(float64(outflow.Quantity) / recorded.Hours() * 24))
+		if outflow.AverageDailySales == average {
+			continue
+		}
+
+		_, err := uc.updateUC.execute(repo, UpdateProductStockDTO{
+			Tenant:            t,
+			ID:                outflow.ProductID,
+			AverageDailySales: &average,
+			Actor:             audit.SystemActor,
+		})
+		// Deleted since.
+		if err != nil && err.ErrCode != domain.ErrNotFound {
+			return updated, err
+		}
+
+		if err == nil {
+			updated++
+		}
+	}
+
+	return updated, nil
+}
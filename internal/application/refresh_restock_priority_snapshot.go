@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+)
+
+// RefreshRestockPrioritySnapshotUseCase rebuilds the restock priority
+// snapshot of a tenant. The mutating use cases keep it up to date in the
+// meantime, see refreshRestockPrioritySnapshot.
+type RefreshRestockPrioritySnapshotUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewRefreshRestockPrioritySnapshotUseCase(repo repository.IProductStockRepository) *RefreshRestockPrioritySnapshotUseCase {
+	return &RefreshRestockPrioritySnapshotUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *RefreshRestockPrioritySnapshotUseCase) Execute(tenantID string) (time.Time, *domain.Error) {
+	snapshotRepo, ok := uc.repo.ForTenant(tenantID).(repository.IRestockPrioritySnapshotRepository)
+	if !ok {
+		return time.Time{}, domain.NewError("restock priority snapshots are not supported by the configured repository", domain.ErrInternal)
+	}
+
+	computedAt := time.Now()
+	if err := snapshotRepo.RebuildRestockPrioritySnapshot(computedAt); err != nil {
+		return time.Time{}, err
+	}
+
+	return computedAt, nil
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+// ResolveTenantUseCase picks the tenant a request acts on: the one the
+// caller is bound to, else the one it asks for, else the default tenant.
+// Only admins not bound to a tenant, and unauthenticated local callers such
+// as the CLI, can ask for a tenant other than the default one.
+type ResolveTenantUseCase struct {
+	tenants repository.ITenantRepository
+}
+
+func NewResolveTenantUseCase(tenants repository.ITenantRepository) *ResolveTenantUseCase {
+	return &ResolveTenantUseCase{
+		tenants: tenants,
+	}
+}
+
+type ResolveTenantDTO struct {
+	Principal *auth.Principal
+	Requested string
+}
+
+func (uc *ResolveTenantUseCase) Execute(dto ResolveTenantDTO) (*tenant.Tenant, *domain.Error) {
+	id := strings.TrimSpace(dto.Requested)
+
+	if dto.Principal != nil && dto.Principal.Tenant != "" {
+		if id != "" && id != dto.Principal.Tenant {
+			return nil, domain.NewError("the caller has no access to tenant "+id, domain.ErrForbidden)
+		}
+		id = dto.Principal.Tenant
+	}
+
+	if dto.Principal != nil && dto.Principal.Tenant == "" && dto.Principal.Role != auth.Admin && id != "" && id != tenant.DefaultID {
+		return nil, domain.NewError("only admins not bound to a tenant can choose one", domain.ErrForbidden)
+	}
+
+	if id == "" {
+		id = tenant.DefaultID
+	}
+
+	t, err := uc.tenants.GetTenant(id)
+	if err != nil {
+		if err.ErrCode == domain.ErrNotFound {
+			return nil, domain.NewError("unknown tenant "+id, domain.ErrForbidden)
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/memory"
+)
+
+func TestResolveTenant(t *testing.T) {
+	uc := NewResolveTenantUseCase(memory.NewTenantRepository([]tenant.Tenant{{ID: tenant.DefaultID}, {ID: "north"}, {ID: "south"}}))
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		requested string
+		want      string
+		wantErr   domain.ErrorCode
+	}{
+		{"bound caller", &auth.Principal{Role: auth.Viewer, Tenant: "north"}, "", "north", 0},
+		{"bound caller asking for another tenant", &auth.Principal{Role: auth.Admin, Tenant: "north"}, "south", "", domain.ErrForbidden},
+		{"unbound admin", &auth.Principal{Role: auth.Admin}, "south", "south", 0},
+		{"unbound admin without header", &auth.Principal{Role: auth.Admin}, "", tenant.DefaultID, 0},
+		{"unbound clerk", &auth.Principal{Role: auth.Clerk}, "", tenant.DefaultID, 0},
+		{"unbound clerk asking for default", &auth.Principal{Role: auth.Clerk}, tenant.DefaultID, tenant.DefaultID, 0},
+		{"unbound clerk asking for another tenant", &auth.Principal{Role: auth.Clerk}, "north", "", domain.ErrForbidden},
+		{"local caller", nil, "north", "north", 0},
+		{"unknown tenant", &auth.Principal{Role: auth.Admin}, "west", "", domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Execute(ResolveTenantDTO{Principal: tt.principal, Requested: tt.requested})
+
+			if tt.wantErr != 0 {
+				if err == nil || err.ErrCode != tt.wantErr {
+					t.Fatalf("error = %v, want code %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err.Message)
+			}
+			if got.ID != tt.want {
+				t.Fatalf("tenant = %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const jobRunCursorScope = "job_runs"
+
+// ScheduleJobUseCase runs the jobs on their schedules and records their
+// runs. A job runs on one instance at a time: the others skip it while it
+// runs, and for half a period after it started, as their clocks may be a
+// little off.
+type ScheduleJobUseCase struct {
+	repo      repository.IJobRepository
+	scheduler job.IScheduler
+	instance  string
+	jobs      []job.Job
+}
+
+// NewScheduleJobUseCase records the runs as made by the instance, e.g. its
+// host name.
+func NewScheduleJobUseCase(repo repository.IJobRepository, scheduler job.IScheduler, instance string) *ScheduleJobUseCase {
+	return &ScheduleJobUseCase{
+		repo:      repo,
+		scheduler: scheduler,
+		instance:  instance,
+	}
+}
+
+func (uc *ScheduleJobUseCase) Execute(j job.Job) {
+	uc.jobs = append(uc.jobs, j)
+
+	uc.scheduler.Schedule(j.Schedule, func() {
+		if err := uc.run(j); err != nil {
+			log.Printf("failed to run job %s: %s", j.Name, err.Message)
+		}
+	})
+}
+
+// Jobs returns the scheduled jobs, in the order they were scheduled.
+func (uc *ScheduleJobUseCase) Jobs() []job.Job {
+	return uc.jobs
+}
+
+func (uc *ScheduleJobUseCase) job(name string) (*job.Job, *domain.Error) {
+	for _, j := range uc.jobs {
+		if j.Name == name {
+			return &j, nil
+		}
+	}
+
+	return nil, domain.NewError("job not found", domain.ErrNotFound)
+}
+
+func (uc *ScheduleJobUseCase) run(j job.Job) *domain.Error {
+	unlock, locked, err := uc.repo.TryJobLock(j.Name)
+	if err != nil {
+		return err
+	}
+
+	if !locked {
+		return nil
+	}
+	defer unlock()
+
+	now := time.Now()
+
+	last, err := uc.repo.GetLastJobRun(j.Name)
+	if err != nil && err.ErrCode != domain.ErrNotFound {
+		return err
+	}
+
+	if last != nil {
+		if last.StartedAt.After(now.Add(-j.Period(now) / 2)) {
+			return nil
+		}
+
+		// Nobody else holds the lock, so the instance running it stopped.
+		if last.Status == job.Running {
+			last.Fail("interrupted", now)
+			if err := uc.repo.UpdateJobRun(last); err != nil {
+				return err
+			}
+		}
+	}
+
+	run := job.NewRun(j.Name, uc.instance, now)
+
+	id, err := uc.repo.CreateJobRun(&run)
+	if err != nil {
+		return err
+	}
+	run.ID = id
+
+	result, taskErr := runTask(j.Task)
+	if taskErr != nil {
+		log.Printf("job %s failed: %s", j.Name, taskErr.Message)
+		run.Fail(taskErr.Message, time.Now())
+	} else {
+		run.Succeed(result, time.Now())
+	}
+
+	return uc.repo.UpdateJobRun(&run)
+}
+
+func runTask(task job.Task) (result string, err *domain.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = domain.NewError(fmt.Sprintf("panic: %v", r), domain.ErrInternal)
+		}
+	}()
+
+	return task()
+}
+
+// JobStatus is a scheduled job with its next and last runs.
+type JobStatus struct {
+	Job       job.Job
+	NextRunAt time.Time
+	LastRun   *job.Run
+}
+
+type GetJobsUseCase struct {
+	repo     repository.IJobRepository
+	schedule *ScheduleJobUseCase
+}
+
+func NewGetJobsUseCase(repo repository.IJobRepository, schedule *ScheduleJobUseCase) *GetJobsUseCase {
+	return &GetJobsUseCase{
+		repo:     repo,
+		schedule: schedule,
+	}
+}
+
+func (uc *GetJobsUseCase) Execute() ([]JobStatus, *domain.Error) {
+	now := time.Now()
+
+	jobs := uc.schedule.Jobs()
+	statuses := make([]JobStatus, len(jobs))
+	for i, j := range jobs {
+		last, err := uc.repo.GetLastJobRun(j.Name)
+		if err != nil && err.ErrCode != domain.ErrNotFound {
+			return nil, err
+		}
+
+		statuses[i] = JobStatus{
+			Job:       j,
+			NextRunAt: j.Schedule.Next(now),
+			LastRun:   last,
+		}
+	}
+
+	return statuses, nil
+}
+
+type GetJobRunsUseCase struct {
+	repo     repository.IJobRepository
+	schedule *ScheduleJobUseCase
+}
+
+func NewGetJobRunsUseCase(repo repository.IJobRepository, schedule *ScheduleJobUseCase) *GetJobRunsUseCase {
+	return &GetJobRunsUseCase{
+		repo:     repo,
+		schedule: schedule,
+	}
+}
+
+// GetJobRunsDTO takes the tenant of the caller for its pagination rules
+// only: jobs belong to no tenant.
+type GetJobRunsDTO struct {
+	Tenant     tenant.Tenant
+	Name       string
+	Pagination domain.Pagination
+}
+
+// Execute lists the runs of a scheduled job, newest first.
+func (uc *GetJobRunsUseCase) Execute(dto GetJobRunsDTO) (*domain.Page[job.Run], *domain.Error) {
+	if _, err := uc.schedule.job(dto.Name); err != nil {
+		return nil, err
+	}
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+	if err := domain.ApplyCursor(&dto.Pagination, jobRunCursorScope, 1); err != nil {
+		return nil, err
+	}
+
+	runs, err := uc.repo.GetJobRuns(dto.Name, dto.Pagination.Lookahead())
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(runs, dto.Pagination, jobRunCursorScope, func(r job.Run) []any {
+		return []any{r.ID}
+	})
+
+	if dto.Pagination.IncludeTotal {
+		total, err := uc.repo.CountJobRuns(dto.Name)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return &page, nil
+}
+
+// PurgeJobRunsUseCase deletes the runs finished longer than the retention
+// ago.
+type PurgeJobRunsUseCase struct {
+	repo      repository.IJobRepository
+	retention time.Duration
+}
+
+func NewPurgeJobRunsUseCase(repo repository.IJobRepository, retention time.Duration) *PurgeJobRunsUseCase {
+	return &PurgeJobRunsUseCase{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+func (uc *PurgeJobRunsUseCase) Execute() (int, *domain.Error) {
+	return uc.repo.PurgeJobRuns(time.Now().Add(-uc.retention))
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/search"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const maxSearchQueryLength = 100
+
+type SearchProductStockUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewSearchProductStockUseCase(repo repository.IProductStockRepository) *SearchProductStockUseCase {
+	return &SearchProductStockUseCase{
+		repo: repo,
+	}
+}
+
+// SearchProductStockDTO is paginated by page only: relevance depends on the
+// query, so there is no stable key to build a cursor from.
+type SearchProductStockDTO struct {
+	Tenant     tenant.Tenant
+	Query      string
+	Pagination domain.Pagination
+}
+
+func (uc *SearchProductStockUseCase) Execute(dto SearchProductStockDTO) ([]repository.ProductStockSearchResult, *domain.Error) {
+	query := strings.TrimSpace(dto.Query)
+
+	if len(search.Tokenize(query)) == 0 {
+		return nil, domain.NewError("search query is required", domain.ErrBadRequest)
+	}
+
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, domain.NewError("search query must have at most 100 characters", domain.ErrBadRequest)
+	}
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+
+	repo := uc.repo.ForTenant(dto.Tenant.ID)
+	if searchRepo, ok := repo.(repository.IProductStockSearchRepository); ok {
+		return searchRepo.Search(query, &dto.Pagination)
+	}
+
+	products, err := repo.GetAll(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]repository.ProductStockSearchResult, 0)
+	for _, p := range products {
+		score := search.Score(query, p.Name)
+		if p.Identifiers.SKU != nil && *p.Identifiers.SKU == entities.NormalizeSKU(query) {
+			score = 1
+		}
+
+		if score >= search.MinScore {
+			results = append(results, repository.ProductStockSearchResult{
+				Score:        score,
+				ProductStock: p,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return domain.PaginatedSlice(results, &dto.Pagination), nil
+}
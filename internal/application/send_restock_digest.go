@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+// restockDigestSize is the number of priorities listed in a digest.
+const restockDigestSize = 20
+
+// SendRestockDigestUseCase sends, for every tenant with products needing a
+// reposition, a digest of the most urgent ones through the channels.
+type SendRestockDigestUseCase struct {
+	tenants       repository.ITenantRepository
+	getPriorityUC *GetProductPriorityUseCase
+	channels      []alert.IChannel
+}
+
+func NewSendRestockDigestUseCase(tenants repository.ITenantRepository, getPriorityUC *GetProductPriorityUseCase, channels []alert.IChannel) *SendRestockDigestUseCase {
+	return &SendRestockDigestUseCase{
+		tenants:       tenants,
+		getPriorityUC: getPriorityUC,
+		channels:      channels,
+	}
+}
+
+// Execute returns the number of digests sent. A failing channel does not
+// keep the digests from the others.
+func (uc *SendRestockDigestUseCase) Execute() (int, *domain.Error) {
+	tenants, err := uc.tenants.GetTenants()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var failures []string
+	for _, t := range tenants {
+		priorities, err := uc.getPriorityUC.Execute(t, domain.Pagination{Limit: restockDigestSize, IncludeTotal: true})
+		if err != nil {
+			return sent, err
+		}
+
+		if len(priorities.Items) == 0 {
+			continue
+		}
+
+		digest := restock.Digest{
+			TenantID:   t.ID,
+			Priorities: priorities.Items,
+			Total:      len(priorities.Items),
+			CreatedAt:  time.Now(),
+		}
+		if priorities.Total != nil {
+			digest.Total = *priorities.Total
+		}
+
+		for _, channel := range uc.channels {
+			if err := channel.Send(digest); err != nil {
+				failures = append(failures, t.ID+" through "+channel.Name()+": "+err.Message)
+			}
+		}
+		sent++
+	}
+
+	if len(failures) > 0 {
+		return sent, domain.NewError("failed to send digests: "+strings.Join(failures, "; "), domain.ErrInternal)
+	}
+
+	return sent, nil
+}
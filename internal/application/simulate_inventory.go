@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const maxSimulationDays = 365
+
+type SimulateInventoryUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewSimulateInventoryUseCase(repo repository.IProductStockRepository) *SimulateInventoryUseCase {
+	return &SimulateInventoryUseCase{
+		repo: repo,
+	}
+}
+
+// SimulateInventoryDTO selects either a single product or a whole category.
+// MinimumStock, LeadTimeDays and AverageDailySales override the stored
+// values so the policy can be tuned before changing the product.
+type SimulateInventoryDTO struct {
+	Tenant            tenant.Tenant
+	ProductID         string
+	Category          string
+	Days              int
+	DemandCurve       []int
+	MinimumStock      *int
+	LeadTimeDays      *int
+	AverageDailySales *int
+}
+
+type ProductSimulation struct {
+	ProductStock     *entities.ProductStock
+	TotalStockout    int
+	FirstStockoutDay int
+	Series           []restock.SimulationDay
+}
+
+type InventorySimulation struct {
+	Days     int
+	Totals   []restock.SimulationDay
+	Products []ProductSimulation
+}
+
+func (uc *SimulateInventoryUseCase) Execute(dto SimulateInventoryDTO) (*InventorySimulation, *domain.Error) {
+	if (dto.ProductID == "") == (dto.Category == "") {
+		return nil, domain.NewError("either product id or category is required", domain.ErrBadRequest)
+	}
+
+	if dto.Days <= 0 || dto.Days > maxSimulationDays {
+		return nil, domain.NewError("days must be between 1 and 365", domain.ErrBadRequest)
+	}
+
+	for _, demand := range dto.DemandCurve {
+		if demand < 0 {
+			return nil, domain.NewError("demand curve must be non-negative", domain.ErrBadRequest)
+		}
+	}
+
+	products, err := uc.loadProducts(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := &InventorySimulation{
+		Days:     dto.Days,
+		Totals:   make([]restock.SimulationDay, dto.Days),
+		Products: make([]ProductSimulation, 0, len(products)),
+	}
+
+	for i := range simulation.Totals {
+		simulation.Totals[i].Day = i + 1
+	}
+
+	for _, p := range products {
+		p, err := applySimulationOverrides(p, dto)
+		if err != nil {
+			return nil, err
+		}
+
+		series := restock.Simulate(restock.SimulationParams{
+			Product:     p,
+			Days:        dto.Days,
+			DemandCurve: dto.DemandCurve,
+		})
+
+		result := ProductSimulation{
+			ProductStock: p,
+			Series:       series,
+		}
+
+		for i, day := range series {
+			result.TotalStockout += day.Stockout
+			if day.Stockout > 0 && result.FirstStockoutDay == 0 {
+				result.FirstStockoutDay = day.Day
+			}
+
+			total := &simulation.Totals[i]
+			total.Demand += day.Demand
+			total.Sold += day.Sold
+			total.Stockout += day.Stockout
+			total.Received += day.Received
+			total.Ordered += day.Ordered
+			total.OnHand += day.OnHand
+			total.OnOrder += day.OnOrder
+		}
+
+		simulation.Products = append(simulation.Products, result)
+	}
+
+	return simulation, nil
+}
+
+func (uc *SimulateInventoryUseCase) loadProducts(dto SimulateInventoryDTO) ([]*entities.ProductStock, *domain.Error) {
+	repo := uc.repo.ForTenant(dto.Tenant.ID)
+
+	if dto.ProductID != "" {
+		p, err := repo.GetOneByID(dto.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*entities.ProductStock{p}, nil
+	}
+
+	category := entities.ProductCategory(dto.Category)
+	if !dto.Tenant.AllowsCategory(category) {
+		return nil, domain.NewError("invalid product category", domain.ErrBadRequest)
+	}
+
+	return repo.GetByCategory(category, nil)
+}
+
+func applySimulationOverrides(p *entities.ProductStock, dto SimulateInventoryDTO) (*entities.ProductStock, *domain.Error) {
+	minimumStock := p.MinimumStock
+	if dto.MinimumStock != nil {
+		minimumStock = *dto.MinimumStock
+	}
+
+	leadTimeDays := p.LeadTimeDays
+	if dto.LeadTimeDays != nil {
+		leadTimeDays = *dto.LeadTimeDays
+	}
+
+	averageDailySales := p.AverageDailySales
+	if dto.AverageDailySales != nil {
+		averageDailySales = *dto.AverageDailySales
+	}
+
+	return entities.NewProductStock(
+		p.ID,
+		p.Name,
+		p.Identifiers,
+		p.Category,
+		p.CurrentStock,
+		minimumStock,
+		averageDailySales,
+		leadTimeDays,
+		p.UnitCost,
+		p.CriticalityLevel,
+	)
+}
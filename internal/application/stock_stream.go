@@ -0,0 +1,414 @@
+package usecases
+
+import (
+	"log"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type StreamTopic string
+
+const (
+	// StockTopic streams the events of the products.
+	StockTopic StreamTopic = "stock"
+	// PrioritiesTopic streams the restock priorities, first as they are
+	// and then whenever they change.
+	PrioritiesTopic StreamTopic = "priorities"
+)
+
+const (
+	PrioritiesMessage = "priorities"
+	// ResetMessage tells a resuming client that it missed too many events
+	// to replay, so it should reload what it shows.
+	ResetMessage = "reset"
+)
+
+const (
+	streamReplayLimit = 1000
+	streamBufferSize  = 256
+	streamPollLimit   = 500
+	// streamGapGrace is how long an event is waited for when events with
+	// greater ids were committed before it. Ids are assigned when events
+	// are written but become visible when their transaction commits, so a
+	// long transaction commits its events after newer ones.
+	streamGapGrace = time.Minute
+	streamMaxGaps  = 10000
+)
+
+// StreamMessage is either an event of the products, whose ID resumes the
+// stream, or the restock priorities.
+type StreamMessage struct {
+	ID         int64
+	Type       string
+	Event      *event.Event
+	Priorities []restock.Priority
+}
+
+// StockStreamUseCase pushes the events of the products to the subscribers
+// as they are committed, along with the restock priorities when they
+// change. It follows the outbox, so it sees the changes made through every
+// instance; Poll reads it once for all the subscribers of the instance. The
+// priorities are read from the repository for the same reason, once for all
+// the subscribers streaming the same ones.
+type StockStreamUseCase struct {
+	repo repository.IProductStockRepository
+
+	// pollMu keeps a single Poll following the outbox at a time. mu guards
+	// the rest and is not held while the repository is read, except to
+	// replay events to a new subscriber.
+	pollMu      sync.Mutex
+	mu          sync.Mutex
+	subscribers map[*StockStreamSubscription]struct{}
+	following   bool
+	last        int64
+	gaps        map[int64]time.Time
+}
+
+func NewStockStreamUseCase(repo repository.IProductStockRepository) *StockStreamUseCase {
+	return &StockStreamUseCase{
+		repo:        repo,
+		subscribers: map[*StockStreamSubscription]struct{}{},
+		gaps:        map[int64]time.Time{},
+	}
+}
+
+type StockStreamDTO struct {
+	Tenant     tenant.Tenant
+	Topics     []string
+	Categories []string
+	// Limit is the number of restock priorities to stream.
+	Limit int
+	// LastEventID is the ID of the last message received before
+	// reconnecting, if any. The events after it are replayed.
+	LastEventID string
+}
+
+// StockStreamSubscription receives the messages of a subscriber. Its
+// channel is closed when the subscriber falls too far behind, and the
+// client should then reconnect to resume.
+type StockStreamSubscription struct {
+	uc         *StockStreamUseCase
+	messages   chan StreamMessage
+	tenant     tenant.Tenant
+	topics     []StreamTopic
+	categories []entities.ProductCategory
+	limit      int
+	replayed   map[int64]bool
+	priorities []restock.Priority
+	// priorityKey is shared by the subscribers streaming the same restock
+	// priorities.
+	priorityKey string
+}
+
+func (s *StockStreamSubscription) Messages() <-chan StreamMessage {
+	return s.messages
+}
+
+func (s *StockStreamSubscription) Close() {
+	s.uc.mu.Lock()
+	defer s.uc.mu.Unlock()
+
+	s.uc.unsubscribe(s)
+}
+
+// Subscribe replays the events after the last one received, when resuming,
+// and sends the current restock priorities before following the changes.
+func (uc *StockStreamUseCase) Subscribe(dto StockStreamDTO) (*StockStreamSubscription, *domain.Error) {
+	outbox, ok := uc.repo.(repository.IOutboxRepository)
+	if !ok {
+		return nil, domain.NewError("stock streams are not supported by the configured repository", domain.ErrInternal)
+	}
+
+	s := &StockStreamSubscription{
+		uc:       uc,
+		tenant:   dto.Tenant,
+		replayed: map[int64]bool{},
+	}
+
+	for _, raw := range dto.Topics {
+		topic := StreamTopic(strings.TrimSpace(raw))
+		if topic != StockTopic && topic != PrioritiesTopic {
+			return nil, domain.NewError("topic must be stock or priorities", domain.ErrBadRequest)
+		}
+
+		if !slices.Contains(s.topics, topic) {
+			s.topics = append(s.topics, topic)
+		}
+	}
+
+	if len(s.topics) == 0 {
+		s.topics = []StreamTopic{StockTopic, PrioritiesTopic}
+	}
+
+	for _, c := range dto.Categories {
+		category := entities.ProductCategory(strings.TrimSpace(c))
+		if !dto.Tenant.AllowsCategory(category) {
+			return nil, domain.NewError("invalid product category: "+c, domain.ErrBadRequest)
+		}
+		s.categories = append(s.categories, category)
+	}
+
+	pagination := domain.Pagination{Limit: dto.Limit}
+	domain.ApplyPaginationRules(&pagination, dto.Tenant.Pagination)
+	s.limit = pagination.Limit
+
+	categories := make([]string, len(s.categories))
+	for i, c := range s.categories {
+		categories[i] = string(c)
+	}
+	slices.Sort(categories)
+	s.priorityKey = dto.Tenant.ID + "\n" + strconv.Itoa(s.limit) + "\n" + strings.Join(categories, ",")
+
+	var lastEventID *int64
+	if dto.LastEventID != "" {
+		id, err := strconv.ParseInt(strings.TrimSpace(dto.LastEventID), 10, 64)
+		if err != nil || id < 0 {
+			return nil, domain.NewError("last event id must be a non-negative integer", domain.ErrBadRequest)
+		}
+		lastEventID = &id
+	}
+
+	// Priorities changed after this read are sent by the next Poll.
+	if s.wants(PrioritiesTopic) {
+		priorities, err := s.readPriorities()
+		if err != nil {
+			return nil, err
+		}
+		s.priorities = priorities
+	}
+
+	// The lock keeps Poll from broadcasting before the subscriber has its
+	// replay, and the outbox is followed from before the replay is read, so
+	// no event falls between them.
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if err := uc.follow(outbox); err != nil {
+		return nil, err
+	}
+
+	var backlog []StreamMessage
+
+	if lastEventID != nil && s.wants(StockTopic) {
+		tenantOutbox := uc.repo.ForTenant(dto.Tenant.ID).(repository.IOutboxRepository)
+
+		events, err := tenantOutbox.GetOutboxEvents(*lastEventID, nil, streamReplayLimit+1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(events) > streamReplayLimit {
+			backlog = append(backlog, StreamMessage{Type: ResetMessage})
+			events = nil
+		}
+
+		for _, e := range events {
+			s.replayed[e.ID] = true
+			if s.matches(e) {
+				backlog = append(backlog, newEventMessage(e))
+			}
+		}
+	}
+
+	if s.wants(PrioritiesTopic) {
+		backlog = append(backlog, StreamMessage{Type: PrioritiesMessage, Priorities: s.priorities})
+	}
+
+	s.messages = make(chan StreamMessage, streamBufferSize+len(backlog))
+	for _, m := range backlog {
+		s.messages <- m
+	}
+
+	uc.subscribers[s] = struct{}{}
+
+	return s, nil
+}
+
+// Poll broadcasts the events committed since the last poll. The outbox is
+// only followed while there are subscribers.
+func (uc *StockStreamUseCase) Poll() {
+	uc.pollMu.Lock()
+	defer uc.pollMu.Unlock()
+
+	outbox, ok := uc.repo.(repository.IOutboxRepository)
+
+	uc.mu.Lock()
+	if !ok || len(uc.subscribers) == 0 {
+		uc.following = false
+		uc.mu.Unlock()
+		return
+	}
+
+	// Only Poll moves last while following, and it stays following until
+	// the next Poll, so the values read here are still current below.
+	last := uc.last
+	gaps := make([]int64, 0, len(uc.gaps))
+	for id := range uc.gaps {
+		gaps = append(gaps, id)
+	}
+	uc.mu.Unlock()
+
+	events, err := outbox.GetOutboxEvents(last, gaps, streamPollLimit)
+	if err != nil {
+		log.Printf("failed to follow the outbox: %s", err.Message)
+		return
+	}
+
+	uc.mu.Lock()
+	changedTenants := uc.broadcastEvents(events)
+	uc.mu.Unlock()
+
+	uc.broadcastPriorities(changedTenants)
+}
+
+// broadcastEvents sends the events read from the outbox and moves last past
+// them, returning the tenants they changed.
+func (uc *StockStreamUseCase) broadcastEvents(events []event.Event) map[string]bool {
+	now := time.Now()
+	changedTenants := map[string]bool{}
+
+	for _, e := range events {
+		delete(uc.gaps, e.ID)
+
+		if e.ID > uc.last {
+			if e.ID-uc.last-1+int64(len(uc.gaps)) <= streamMaxGaps {
+				for id := uc.last + 1; id < e.ID; id++ {
+					uc.gaps[id] = now
+				}
+			}
+			uc.last = e.ID
+		}
+
+		changedTenants[e.TenantID] = true
+		uc.broadcast(e)
+	}
+
+	for id, since := range uc.gaps {
+		if now.Sub(since) > streamGapGrace {
+			delete(uc.gaps, id)
+		}
+	}
+
+	// Replayed events the outbox no longer returns would otherwise be kept
+	// for as long as the subscriber stays.
+	for s := range uc.subscribers {
+		for id := range s.replayed {
+			if _, waited := uc.gaps[id]; id <= uc.last && !waited {
+				delete(s.replayed, id)
+			}
+		}
+	}
+
+	return changedTenants
+}
+
+// follow starts following the outbox from its end.
+func (uc *StockStreamUseCase) follow(outbox repository.IOutboxRepository) *domain.Error {
+	if uc.following {
+		return nil
+	}
+
+	last, err := outbox.GetLastOutboxEventID()
+	if err != nil {
+		return err
+	}
+
+	uc.following = true
+	uc.last = last
+	clear(uc.gaps)
+
+	return nil
+}
+
+func (uc *StockStreamUseCase) broadcast(e event.Event) {
+	for s := range uc.subscribers {
+		if s.replayed[e.ID] {
+			delete(s.replayed, e.ID)
+			continue
+		}
+
+		if s.tenant.ID == e.TenantID && s.wants(StockTopic) && s.matches(e) {
+			uc.send(s, newEventMessage(e))
+		}
+	}
+}
+
+// broadcastPriorities sends the restock priorities of the changed tenants to
+// the subscribers that see them changed. They are read once per priorityKey.
+func (uc *StockStreamUseCase) broadcastPriorities(changedTenants map[string]bool) {
+	groups := map[string][]*StockStreamSubscription{}
+
+	uc.mu.Lock()
+	for s := range uc.subscribers {
+		if changedTenants[s.tenant.ID] && s.wants(PrioritiesTopic) {
+			groups[s.priorityKey] = append(groups[s.priorityKey], s)
+		}
+	}
+	uc.mu.Unlock()
+
+	for _, group := range groups {
+		priorities, err := group[0].readPriorities()
+		if err != nil {
+			log.Printf("failed to compute the restock priorities of tenant %s: %s", group[0].tenant.ID, err.Message)
+			continue
+		}
+
+		uc.mu.Lock()
+		for _, s := range group {
+			if _, subscribed := uc.subscribers[s]; !subscribed || reflect.DeepEqual(priorities, s.priorities) {
+				continue
+			}
+
+			s.priorities = priorities
+			uc.send(s, StreamMessage{Type: PrioritiesMessage, Priorities: priorities})
+		}
+		uc.mu.Unlock()
+	}
+}
+
+// send drops the subscriber when it is too far behind to take the message.
+func (uc *StockStreamUseCase) send(s *StockStreamSubscription, m StreamMessage) {
+	select {
+	case s.messages <- m:
+	default:
+		uc.unsubscribe(s)
+	}
+}
+
+func (uc *StockStreamUseCase) unsubscribe(s *StockStreamSubscription) {
+	if _, ok := uc.subscribers[s]; !ok {
+		return
+	}
+
+	delete(uc.subscribers, s)
+	close(s.messages)
+}
+
+func (s *StockStreamSubscription) wants(topic StreamTopic) bool {
+	return slices.Contains(s.topics, topic)
+}
+
+func (s *StockStreamSubscription) matches(e event.Event) bool {
+	return len(s.categories) == 0 || slices.Contains(s.categories, entities.ProductCategory(e.Data.Product.Category))
+}
+
+// readPriorities returns the most urgent restock priorities of the
+// categories of the subscriber, filtered and limited by the repository.
+func (s *StockStreamSubscription) readPriorities() ([]restock.Priority, *domain.Error) {
+	return listRestockPriorities(s.uc.repo.ForTenant(s.tenant.ID), s.categories, &domain.Pagination{Page: 1, Limit: s.limit})
+}
+
+func newEventMessage(e event.Event) StreamMessage {
+	return StreamMessage{ID: e.ID, Type: string(e.Type), Event: &e}
+}
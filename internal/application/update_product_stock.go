@@ -1,9 +1,13 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
 )
 
 type UpdateProductStockUseCase struct {
@@ -17,6 +21,7 @@ func NewUpdateProductStockUseCase(repo repository.IProductStockRepository) *Upda
 }
 
 type UpdateProductStockDTO struct {
+	Tenant            tenant.Tenant
 	ID                string
 	CurrentStock      *int
 	MinimumStock      *int
@@ -24,18 +29,57 @@ type UpdateProductStockDTO struct {
 	LeadTimeDays      *int
 	UnitCost          *float64
 	CriticalityLevel  *int
+	SKU               *string
+	Barcodes          *[]string
+	ExternalIDs       *map[string]string
+	Actor             string
 }
 
 func (uc *UpdateProductStockUseCase) Execute(dto UpdateProductStockDTO) *domain.Error {
+	_, err := uc.execute(uc.repo.ForTenant(dto.Tenant.ID), dto)
+	return err
+}
+
+func (uc *UpdateProductStockUseCase) execute(repo repository.IProductStockRepository, dto UpdateProductStockDTO) (*entities.ProductStock, *domain.Error) {
 	if dto.ID == "" {
-		return domain.NewError("id is required", domain.ErrBadRequest)
+		return nil, domain.NewError("id is required", domain.ErrBadRequest)
 	}
 
-	p, err := uc.repo.GetOneByID(dto.ID)
+	var p *entities.ProductStock
+
+	err := withinTransaction(repo, func(repo repository.IProductStockRepository) *domain.Error {
+		before, err := repo.GetOneByID(dto.ID)
+		if err != nil {
+			return err
+		}
+
+		p, err = applyProductStockUpdate(*before, dto)
+		if err != nil {
+			return err
+		}
+
+		if dto.SKU != nil || dto.Barcodes != nil {
+			if err := checkDeletedIdentifiers(repo, p); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.Update(p); err != nil {
+			return err
+		}
+
+		return recordProductStockChange(repo, dto.Actor, audit.Update, before, p, time.Now())
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return p, nil
+}
+
+// applyProductStockUpdate works on a copy, so the caller keeps the product
+// as it was before the update.
+func applyProductStockUpdate(p entities.ProductStock, dto UpdateProductStockDTO) (*entities.ProductStock, *domain.Error) {
 	if dto.CurrentStock != nil {
 		p.CurrentStock = *dto.CurrentStock
 	}
@@ -60,9 +104,22 @@ func (uc *UpdateProductStockUseCase) Execute(dto UpdateProductStockDTO) *domain.
 		p.CriticalityLevel = entities.CriticalityLevel(*dto.CriticalityLevel)
 	}
 
-	p, err = entities.NewProductStock(
+	if dto.SKU != nil {
+		p.Identifiers.SKU = dto.SKU
+	}
+
+	if dto.Barcodes != nil {
+		p.Identifiers.Barcodes = *dto.Barcodes
+	}
+
+	if dto.ExternalIDs != nil {
+		p.Identifiers.ExternalIDs = *dto.ExternalIDs
+	}
+
+	return entities.NewProductStock(
 		&dto.ID,
 		p.Name,
+		p.Identifiers,
 		p.Category,
 		p.CurrentStock,
 		p.MinimumStock,
@@ -71,10 +128,4 @@ func (uc *UpdateProductStockUseCase) Execute(dto UpdateProductStockDTO) *domain.
 		p.UnitCost,
 		p.CriticalityLevel,
 	)
-
-	if err != nil {
-		return err
-	}
-
-	return uc.repo.Update(p)
 }
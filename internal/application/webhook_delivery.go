@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+)
+
+const webhookDeliveryCursorScope = "webhook_deliveries"
+
+type GetWebhookDeliveriesUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetWebhookDeliveriesUseCase(repo repository.IProductStockRepository) *GetWebhookDeliveriesUseCase {
+	return &GetWebhookDeliveriesUseCase{
+		repo: repo,
+	}
+}
+
+type GetWebhookDeliveriesDTO struct {
+	Tenant         tenant.Tenant
+	SubscriptionID string
+	Status         string
+	Pagination     domain.Pagination
+}
+
+// Execute lists the deliveries of a subscription, newest first.
+func (uc *GetWebhookDeliveriesUseCase) Execute(dto GetWebhookDeliveriesDTO) (*domain.Page[webhook.Delivery], *domain.Error) {
+	webhookRepo, err := webhookRepository(uc.repo, dto.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	status := webhook.Status(dto.Status)
+	if status != "" && !webhook.IsValidStatus(status) {
+		return nil, domain.NewError("status must be pending, succeeded or dead", domain.ErrBadRequest)
+	}
+
+	if _, err := webhookRepo.GetWebhookSubscription(dto.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	domain.ApplyPaginationRules(&dto.Pagination, dto.Tenant.Pagination)
+	if err := domain.ApplyCursor(&dto.Pagination, webhookDeliveryCursorScope, 1); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := webhookRepo.GetWebhookDeliveries(dto.SubscriptionID, status, dto.Pagination.Lookahead())
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(deliveries, dto.Pagination, webhookDeliveryCursorScope, func(d webhook.Delivery) []any {
+		return []any{d.ID}
+	})
+
+	if dto.Pagination.IncludeTotal {
+		total, err := webhookRepo.CountWebhookDeliveries(dto.SubscriptionID, status)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return &page, nil
+}
+
+type RedeliverWebhookUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewRedeliverWebhookUseCase(repo repository.IProductStockRepository) *RedeliverWebhookUseCase {
+	return &RedeliverWebhookUseCase{
+		repo: repo,
+	}
+}
+
+type RedeliverWebhookDTO struct {
+	Tenant         tenant.Tenant
+	SubscriptionID string
+	DeliveryID     string
+}
+
+// Execute queues the delivery again, dead or not, with a fresh count of
+// attempts. The same event is posted again, with a new signature.
+func (uc *RedeliverWebhookUseCase) Execute(dto RedeliverWebhookDTO) (*webhook.Delivery, *domain.Error) {
+	webhookRepo, err := webhookRepository(uc.repo, dto.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	id, parseErr := strconv.ParseInt(dto.DeliveryID, 10, 64)
+	if parseErr != nil {
+		return nil, domain.NewError("webhook delivery not found", domain.ErrNotFound)
+	}
+
+	d, err := webhookRepo.GetWebhookDelivery(dto.SubscriptionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Redeliver(time.Now())
+	if err := webhookRepo.UpdateWebhookDelivery(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
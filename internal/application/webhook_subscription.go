@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+)
+
+// webhookRepository binds the repository to the tenant and fails when it
+// cannot keep webhooks.
+func webhookRepository(repo repository.IProductStockRepository, t tenant.Tenant) (repository.IWebhookRepository, *domain.Error) {
+	webhookRepo, ok := repo.ForTenant(t.ID).(repository.IWebhookRepository)
+	if !ok {
+		return nil, domain.NewError("webhooks are not supported by the configured repository", domain.ErrInternal)
+	}
+
+	return webhookRepo, nil
+}
+
+type CreateWebhookSubscriptionUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewCreateWebhookSubscriptionUseCase(repo repository.IProductStockRepository) *CreateWebhookSubscriptionUseCase {
+	return &CreateWebhookSubscriptionUseCase{
+		repo: repo,
+	}
+}
+
+type CreateWebhookSubscriptionDTO struct {
+	Tenant     tenant.Tenant
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+func (uc *CreateWebhookSubscriptionUseCase) Execute(dto CreateWebhookSubscriptionDTO) (*webhook.Subscription, *domain.Error) {
+	webhookRepo, err := webhookRepository(uc.repo, dto.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := make([]event.Type, len(dto.EventTypes))
+	for i, t := range dto.EventTypes {
+		eventTypes[i] = event.Type(strings.TrimSpace(t))
+	}
+
+	s, err := webhook.NewSubscription(strings.TrimSpace(dto.URL), eventTypes, dto.Secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := webhookRepo.CreateWebhookSubscription(s)
+	if err != nil {
+		return nil, err
+	}
+
+	s.ID = &id
+	return s, nil
+}
+
+type GetWebhookSubscriptionsUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewGetWebhookSubscriptionsUseCase(repo repository.IProductStockRepository) *GetWebhookSubscriptionsUseCase {
+	return &GetWebhookSubscriptionsUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetWebhookSubscriptionsUseCase) Execute(t tenant.Tenant) ([]webhook.Subscription, *domain.Error) {
+	webhookRepo, err := webhookRepository(uc.repo, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookRepo.GetWebhookSubscriptions()
+}
+
+type DeleteWebhookSubscriptionUseCase struct {
+	repo repository.IProductStockRepository
+}
+
+func NewDeleteWebhookSubscriptionUseCase(repo repository.IProductStockRepository) *DeleteWebhookSubscriptionUseCase {
+	return &DeleteWebhookSubscriptionUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *DeleteWebhookSubscriptionUseCase) Execute(t tenant.Tenant, id string) *domain.Error {
+	webhookRepo, err := webhookRepository(uc.repo, t)
+	if err != nil {
+		return err
+	}
+
+	return webhookRepo.DeleteWebhookSubscription(id)
+}
@@ -0,0 +1,244 @@
+package alert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+type Condition string
+
+const (
+	// ProjectedBelowMinimum holds when the stock left once the lead time
+	// has passed is below the minimum stock.
+	ProjectedBelowMinimum Condition = "projected_below_minimum"
+	BelowMinimum          Condition = "below_minimum"
+	OutOfStock            Condition = "out_of_stock"
+)
+
+var Conditions = []Condition{ProjectedBelowMinimum, BelowMinimum, OutOfStock}
+
+const (
+	MaxNameLength   = 100
+	DefaultCooldown = time.Hour
+	MinCooldown     = time.Minute
+	MaxCooldown     = 7 * 24 * time.Hour
+)
+
+// Rule notifies its channels when a product it covers meets its condition
+// after a change. It notifies again about the same product only once the
+// cooldown has passed since the last time, however often the product
+// changes meanwhile.
+type Rule struct {
+	ID        *string
+	Name      string
+	Condition Condition
+	// MinCriticality restricts the rule to the products at least this
+	// critical, unless it is zero.
+	MinCriticality entities.CriticalityLevel
+	// Categories restricts the rule to the products of these categories,
+	// unless it is empty.
+	Categories []entities.ProductCategory
+	Channels   []string
+	Cooldown   time.Duration
+	CreatedAt  time.Time
+}
+
+func NewRule(
+	name string,
+	condition Condition,
+	minCriticality entities.CriticalityLevel,
+	categories []entities.ProductCategory,
+	channels []string,
+	cooldown time.Duration,
+	createdAt time.Time,
+) (*Rule, *domain.Error) {
+	if name == "" || len(name) > MaxNameLength {
+		return nil, domain.NewError(fmt.Sprintf("name must have between 1 and %d characters", MaxNameLength), domain.ErrBadRequest)
+	}
+
+	if !slices.Contains(Conditions, condition) {
+		return nil, domain.NewError("condition must be projected_below_minimum, below_minimum or out_of_stock", domain.ErrBadRequest)
+	}
+
+	if minCriticality != 0 && !entities.IsValidCriticalityLevel(minCriticality) {
+		return nil, domain.NewError("invalid min criticality", domain.ErrBadRequest)
+	}
+
+	if len(channels) == 0 {
+		return nil, domain.NewError("at least one channel is required", domain.ErrBadRequest)
+	}
+
+	if cooldown < MinCooldown || cooldown > MaxCooldown {
+		return nil, domain.NewError("cooldown must be between "+MinCooldown.String()+" and "+MaxCooldown.String(), domain.ErrBadRequest)
+	}
+
+	rule := &Rule{
+		Name:           name,
+		Condition:      condition,
+		MinCriticality: minCriticality,
+		Categories:     []entities.ProductCategory{},
+		Channels:       []string{},
+		Cooldown:       cooldown,
+		CreatedAt:      createdAt,
+	}
+
+	for _, c := range categories {
+		if !slices.Contains(rule.Categories, c) {
+			rule.Categories = append(rule.Categories, c)
+		}
+	}
+
+	for _, c := range channels {
+		if !slices.Contains(rule.Channels, c) {
+			rule.Channels = append(rule.Channels, c)
+		}
+	}
+
+	return rule, nil
+}
+
+// Covers tells whether the rule applies to the product, whatever its stock.
+func (r Rule) Covers(p *entities.ProductStock) bool {
+	if p.DeletedAt != nil || p.CriticalityLevel < r.MinCriticality {
+		return false
+	}
+
+	return len(r.Categories) == 0 || slices.Contains(r.Categories, p.Category)
+}
+
+// Holds tells whether the product meets the condition of the rule.
+func (r Rule) Holds(p *entities.ProductStock) bool {
+	if !r.Covers(p) {
+		return false
+	}
+
+	switch r.Condition {
+	case ProjectedBelowMinimum:
+		return restock.Project(p).IsRepositionNeeded
+	case BelowMinimum:
+		return p.CurrentStock < p.MinimumStock
+	case OutOfStock:
+		return p.CurrentStock == 0
+	default:
+		return false
+	}
+}
+
+type Status string
+
+const (
+	// Pending alerts are not sent to every channel of their rule yet.
+	Pending Status = "pending"
+	Sent    Status = "sent"
+)
+
+// Alert is the notification of a rule met by a product after the change
+// of an event. A rule raises at most one alert per event.
+type Alert struct {
+	ID             int64
+	RuleID         string
+	RuleName       string
+	Condition      Condition
+	EventID        int64
+	ProductID      string
+	Product        event.Product
+	ProjectedStock int
+	Status         Status
+	LastError      *string
+	TriggeredAt    time.Time
+	SentAt         *time.Time
+}
+
+func NewAlert(r Rule, e event.Event, triggeredAt time.Time) Alert {
+	return Alert{
+		RuleID:         *r.ID,
+		RuleName:       r.Name,
+		Condition:      r.Condition,
+		EventID:        e.ID,
+		ProductID:      e.ProductID,
+		Product:        e.Data.Product,
+		ProjectedStock: restock.Project(e.ProductStock()).ProjectedStock,
+		Status:         Pending,
+		TriggeredAt:    triggeredAt,
+	}
+}
+
+// CoolsDownAt is when the rule may alert about the product again.
+func (a Alert) CoolsDownAt(r Rule) time.Time {
+	return a.TriggeredAt.Add(r.Cooldown)
+}
+
+func (a *Alert) MarkSent(at time.Time) {
+	a.Status = Sent
+	a.LastError = nil
+	a.SentAt = &at
+}
+
+// Fail records that some channels could not be reached. The alert stays
+// pending, so sending it again reaches them.
+func (a *Alert) Fail(reason string) {
+	a.Status = Pending
+	a.LastError = &reason
+}
+
+// Subject is a one line summary of the alert.
+func (a Alert) Subject() string {
+	var what string
+	switch a.Condition {
+	case ProjectedBelowMinimum:
+		what = "will fall below its minimum stock"
+	case BelowMinimum:
+		what = "is below its minimum stock"
+	case OutOfStock:
+		what = "is out of stock"
+	}
+
+	return fmt.Sprintf("%s %s", a.Product.Name, what)
+}
+
+// Text describes the alert in a few lines of plain text.
+func (a Alert) Text() string {
+	lines := []string{
+		a.Subject(),
+		"",
+		"Rule: " + a.RuleName,
+		"Product: " + a.Product.Name + " (" + a.ProductID + ")",
+	}
+
+	if a.Product.SKU != nil {
+		lines = append(lines, "SKU: "+*a.Product.SKU)
+	}
+
+	lines = append(lines,
+		"Category: "+a.Product.Category,
+		fmt.Sprintf("Criticality: %d", a.Product.CriticalityLevel),
+		fmt.Sprintf("Current stock: %d", a.Product.CurrentStock),
+		fmt.Sprintf("Minimum stock: %d", a.Product.MinimumStock),
+		fmt.Sprintf("Projected stock after %d days of lead time: %d", a.Product.LeadTimeDays, a.ProjectedStock),
+		"Triggered at: "+a.TriggeredAt.UTC().Format(time.RFC3339),
+	)
+
+	return strings.Join(lines, "\n")
+}
+
+// Notification is what channels deliver: alerts, or digests such as the
+// daily one of the restock priorities.
+type Notification interface {
+	// Subject is a one line summary, Text the whole notification in plain
+	// text, starting with the subject.
+	Subject() string
+	Text() string
+}
+
+// IChannel delivers notifications to people, e.g. by email or chat.
+type IChannel interface {
+	Name() string
+	Send(n Notification) *domain.Error
+}
@@ -0,0 +1,136 @@
+package audit
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+type Operation string
+
+const (
+	Create  Operation = "create"
+	Update  Operation = "update"
+	Delete  Operation = "delete"
+	Restore Operation = "restore"
+	Purge   Operation = "purge"
+)
+
+const (
+	ProductStockEntity = "product_stock"
+
+	// AnonymousActor is recorded when the caller did not identify itself,
+	// SystemActor for changes made by the application on its own.
+	AnonymousActor = "anonymous"
+	SystemActor    = "system"
+)
+
+// FieldChange holds the values of a field before and after the change; a
+// nil value means the field did not exist (before a create) or was empty.
+type FieldChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// Entry records a change to an entity. ID is assigned when the entry is
+// stored and grows with every entry.
+type Entry struct {
+	ID         int64
+	Actor      string
+	Operation  Operation
+	EntityType string
+	EntityID   string
+	Changes    []FieldChange
+	OccurredAt time.Time
+}
+
+func NewProductStockEntry(actor string, operation Operation, before, after *entities.ProductStock, occurredAt time.Time) Entry {
+	if actor == "" {
+		actor = AnonymousActor
+	}
+
+	entry := Entry{
+		Actor:      actor,
+		Operation:  operation,
+		EntityType: ProductStockEntity,
+		Changes:    ProductStockChanges(before, after),
+		OccurredAt: occurredAt,
+	}
+
+	for _, p := range []*entities.ProductStock{after, before} {
+		if p != nil && p.ID != nil {
+			entry.EntityID = *p.ID
+			break
+		}
+	}
+
+	return entry
+}
+
+// ProductStockChanges lists the fields whose values differ, in a fixed
+// order. Either product may be nil, for creates and purges.
+func ProductStockChanges(before, after *entities.ProductStock) []FieldChange {
+	beforeFields := productStockFields(before)
+	afterFields := productStockFields(after)
+
+	changes := []FieldChange{}
+	for i, field := range productStockFieldNames {
+		var b, a any
+		if beforeFields != nil {
+			b = beforeFields[i]
+		}
+		if afterFields != nil {
+			a = afterFields[i]
+		}
+
+		if !reflect.DeepEqual(b, a) {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+
+	return changes
+}
+
+var productStockFieldNames = []string{
+	"name", "category", "current_stock", "minimum_stock", "average_daily_sales", "lead_time_days",
+	"unit_cost", "criticality_level", "sku", "barcodes", "external_ids", "deleted_at",
+}
+
+// productStockFields follows productStockFieldNames. Empty identifiers are
+// reported as nil, so a missing and an empty list do not make a change.
+func productStockFields(p *entities.ProductStock) []any {
+	if p == nil {
+		return nil
+	}
+
+	var sku, barcodes, externalIDs, deletedAt any
+	if p.Identifiers.SKU != nil {
+		sku = *p.Identifiers.SKU
+	}
+	if len(p.Identifiers.Barcodes) > 0 {
+		barcodes = p.Identifiers.Barcodes
+	}
+	if len(p.Identifiers.ExternalIDs) > 0 {
+		externalIDs = p.Identifiers.ExternalIDs
+	}
+	if p.DeletedAt != nil {
+		deletedAt = p.DeletedAt.UTC()
+	}
+
+	return []any{
+		p.Name,
+		string(p.Category),
+		p.CurrentStock,
+		p.MinimumStock,
+		p.AverageDailySales,
+		p.LeadTimeDays,
+		p.UnitCost,
+		int(p.CriticalityLevel),
+		sku,
+		barcodes,
+		externalIDs,
+		deletedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import "github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+
+// BearerScheme is the authorization scheme of the tokens.
+const BearerScheme = "Bearer"
+
+// Role grants access to a set of routes. Roles are ordered: each one can do
+// everything the roles below it can.
+type Role string
+
+const (
+	Viewer Role = "viewer"
+	Clerk  Role = "clerk"
+	Buyer  Role = "buyer"
+	Admin  Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	Viewer: 1,
+	Clerk:  2,
+	Buyer:  3,
+	Admin:  4,
+}
+
+func IsValidRole(r Role) bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether the role includes the required one.
+func (r Role) Allows(required Role) bool {
+	return IsValidRole(r) && roleRanks[r] >= roleRanks[required]
+}
+
+// Principal is the authenticated caller of a request. Subject identifies it
+// in the audit log. A principal with a Tenant can only act on that tenant;
+// otherwise an admin chooses it per request and other roles act on the
+// default tenant.
+type Principal struct {
+	Subject string
+	Role    Role
+	Tenant  string
+}
+
+// Authorize fails unless the principal has at least the required role.
+func Authorize(principal *Principal, required Role) *domain.Error {
+	if principal == nil || !principal.Role.Allows(required) {
+		return domain.NewError("this operation requires the "+string(required)+" role", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// AuthorizeAcrossTenants is Authorize for the operations spanning every
+// tenant, which principals bound to one cannot perform.
+func AuthorizeAcrossTenants(principal *Principal, required Role) *domain.Error {
+	if err := Authorize(principal, required); err != nil {
+		return err
+	}
+
+	if principal.Tenant != "" {
+		return domain.NewError("this operation spans every tenant and requires a principal not bound to one", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// ITokenVerifier authenticates bearer tokens.
+type ITokenVerifier interface {
+	VerifyToken(token string) (*Principal, *domain.Error)
+}
+
+// IAPIKeyVerifier authenticates the static API keys of machine clients.
+type IAPIKeyVerifier interface {
+	VerifyAPIKey(key string) (*Principal, *domain.Error)
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// cursor is the payload behind the opaque tokens handed to clients. Scope
+// identifies the ordering the key belongs to, so a cursor cannot be replayed
+// against a list sorted differently.
+type cursor struct {
+	Scope string `json:"s"`
+	Key   []any  `json:"k"`
+}
+
+func EncodeCursor(scope string, key []any) string {
+	payload, _ := json.Marshal(cursor{Scope: scope, Key: key})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor returns the sort key stored in the cursor. JSON numbers come
+// back as int64 when integral and float64 otherwise.
+func DecodeCursor(token, scope string) ([]any, *Error) {
+	invalid := NewError("invalid cursor", ErrBadRequest)
+
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var c cursor
+	if err := decoder.Decode(&c); err != nil || c.Scope != scope || len(c.Key) == 0 {
+		return nil, invalid
+	}
+
+	for i, value := range c.Key {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+
+		if n, err := number.Int64(); err == nil {
+			c.Key[i] = n
+		} else if f, err := number.Float64(); err == nil {
+			c.Key[i] = f
+		} else {
+			return nil, invalid
+		}
+	}
+
+	return c.Key, nil
+}
+
+// ApplyCursor decodes the cursor of the pagination, if any, into After.
+func ApplyCursor(p *Pagination, scope string, keyLength int) *Error {
+	if p.Cursor == "" {
+		return nil
+	}
+
+	key, err := DecodeCursor(p.Cursor, scope)
+	if err != nil {
+		return err
+	}
+
+	if len(key) != keyLength {
+		return NewError("invalid cursor", ErrBadRequest)
+	}
+
+	p.After = key
+
+	return nil
+}
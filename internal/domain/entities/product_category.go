@@ -7,11 +7,6 @@ const (
 	Oil    ProductCategory = "oil"
 )
 
-func IsValidProductCategory(c ProductCategory) bool {
-	switch c {
-	case Engine, Oil:
-		return true
-	default:
-		return false
-	}
-}
+// DefaultProductCategories are the categories of tenants that do not
+// configure their own.
+var DefaultProductCategories = []ProductCategory{Engine, Oil}
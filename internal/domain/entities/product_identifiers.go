@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	maxSKULength              = 64
+	maxExternalIDSystemLength = 50
+	maxExternalIDLength       = 100
+)
+
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ProductIdentifiers are the codes other systems know a product by. SKU is
+// nil for products created before it existed; barcodes are GTINs stored in
+// their EAN-13 form; ExternalIDs maps a system name (e.g. "erp") to the
+// product code in that system.
+type ProductIdentifiers struct {
+	SKU         *string
+	Barcodes    []string
+	ExternalIDs map[string]string
+}
+
+// NormalizeSKU trims the SKU and upper-cases it, so lookups are case
+// insensitive.
+func NormalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
+
+// NormalizeBarcode validates an EAN-13 or UPC-A code and returns it as
+// EAN-13. A UPC-A code is the EAN-13 code with a leading zero, so both forms
+// find the same product.
+func NormalizeBarcode(code string) (string, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) == 12 {
+		code = "0" + code
+	}
+
+	if len(code) != 13 {
+		return "", false
+	}
+
+	sum := 0
+	for i := range code {
+		digit := int(code[i] - '0')
+		if digit < 0 || digit > 9 {
+			return "", false
+		}
+
+		if i == 12 {
+			break
+		}
+
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return code, (10-sum%10)%10 == int(code[12]-'0')
+}
+
+func normalizeIdentifiers(in ProductIdentifiers) (ProductIdentifiers, string) {
+	out := ProductIdentifiers{
+		Barcodes:    []string{},
+		ExternalIDs: map[string]string{},
+	}
+
+	if in.SKU != nil {
+		sku := NormalizeSKU(*in.SKU)
+		if len(sku) > maxSKULength || !skuPattern.MatchString(sku) {
+			return out, "sku must have up to 64 letters, digits, '.', '_' or '-'"
+		}
+		out.SKU = &sku
+	}
+
+	seen := map[string]bool{}
+	for _, code := range in.Barcodes {
+		barcode, ok := NormalizeBarcode(code)
+		if !ok {
+			return out, "invalid barcode: " + code
+		}
+
+		if !seen[barcode] {
+			seen[barcode] = true
+			out.Barcodes = append(out.Barcodes, barcode)
+		}
+	}
+
+	for system, id := range in.ExternalIDs {
+		system = strings.ToLower(strings.TrimSpace(system))
+		id = strings.TrimSpace(id)
+
+		if system == "" || len(system) > maxExternalIDSystemLength {
+			return out, "external id systems must have between 1 and 50 characters"
+		}
+
+		if id == "" || len(id) > maxExternalIDLength {
+			return out, "external ids must have between 1 and 100 characters"
+		}
+
+		out.ExternalIDs[system] = id
+	}
+
+	return out, ""
+}
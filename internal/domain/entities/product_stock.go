@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"time"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 )
 
@@ -14,17 +16,22 @@ type ProductStock struct {
 	LeadTimeDays      int
 	UnitCost          float64
 	CriticalityLevel  CriticalityLevel
+	Identifiers       ProductIdentifiers
+	DeletedAt         *time.Time
 }
 
 func NewProductStock(
 	id *string,
 	name string,
+	identifiers ProductIdentifiers,
 	category ProductCategory,
 	currentStock, minimumStock, averageDailySales, leadTimeDays int,
 	unitCost float64,
 	criticalityLevel CriticalityLevel,
 ) (*ProductStock, *domain.Error) {
 
+	identifiers, errIdentifiers := normalizeIdentifiers(identifiers)
+
 	errValidation := func() string {
 		if name == "" {
 			return "name is required"
@@ -38,15 +45,15 @@ func NewProductStock(
 			return "unit cost must be greater than zero"
 		}
 
-		if !IsValidProductCategory(category) {
-			return "invalid product category"
+		if category == "" {
+			return "category is required"
 		}
 
 		if !IsValidCriticalityLevel(criticalityLevel) {
 			return "criticality level must be between 1 and 5"
 		}
 
-		return ""
+		return errIdentifiers
 	}()
 
 	if errValidation != "" {
@@ -54,6 +61,7 @@ func NewProductStock(
 	}
 
 	return &ProductStock{
+		ID:                id,
 		Name:              name,
 		Category:          category,
 		CurrentStock:      currentStock,
@@ -62,5 +70,6 @@ func NewProductStock(
 		LeadTimeDays:      leadTimeDays,
 		UnitCost:          unitCost,
 		CriticalityLevel:  criticalityLevel,
+		Identifiers:       identifiers,
 	}, nil
 }
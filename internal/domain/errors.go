@@ -7,6 +7,9 @@ const (
 	ErrConflict
 	ErrBadRequest
 	ErrInternal
+	ErrUnprocessable
+	ErrUnauthorized
+	ErrForbidden
 )
 
 type Error struct {
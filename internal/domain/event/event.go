@@ -0,0 +1,181 @@
+package event
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+type Type string
+
+const (
+	ProductCreated  Type = "product.created"
+	StockChanged    Type = "stock.changed"
+	ProductDeleted  Type = "product.deleted"
+	ProductRestored Type = "product.restored"
+	RestockNeeded   Type = "restock.needed"
+)
+
+// Event tells other systems about a change to a product. It is the JSON
+// published to the sinks, so its fields are part of the integration
+// contract. ID is assigned when the event is stored in the outbox and grows
+// with every event; a redelivered event keeps its ID, so consumers can
+// discard duplicates with it.
+type Event struct {
+	ID         int64     `json:"id"`
+	Type       Type      `json:"type"`
+	TenantID   string    `json:"tenant_id"`
+	ProductID  string    `json:"product_id"`
+	Actor      string    `json:"actor"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       Data      `json:"data"`
+}
+
+// Data always holds the product as it is after the change. Stock changes
+// add the stock before it, restock needs the outlook that raised them.
+type Data struct {
+	Product       Product  `json:"product"`
+	PreviousStock *int     `json:"previous_stock,omitempty"`
+	Restock       *Restock `json:"restock,omitempty"`
+}
+
+type Product struct {
+	Name              string            `json:"name"`
+	Category          string            `json:"category"`
+	CurrentStock      int               `json:"current_stock"`
+	MinimumStock      int               `json:"minimum_stock"`
+	AverageDailySales int               `json:"average_daily_sales"`
+	LeadTimeDays      int               `json:"lead_time_days"`
+	UnitCost          float64           `json:"unit_cost"`
+	CriticalityLevel  int               `json:"criticality_level"`
+	SKU               *string           `json:"sku"`
+	Barcodes          []string          `json:"barcodes"`
+	ExternalIDs       map[string]string `json:"external_ids"`
+	DeletedAt         *time.Time        `json:"deleted_at,omitempty"`
+}
+
+type Restock struct {
+	ProjectedStock    int `json:"projected_stock"`
+	UrgencyScore      int `json:"urgency_score"`
+	SuggestedQuantity int `json:"suggested_quantity"`
+}
+
+// ISink delivers events to another system. An error makes the dispatcher
+// try the event again later, so sinks may receive an event more than once.
+type ISink interface {
+	Name() string
+	Publish(e Event) *domain.Error
+}
+
+// NewProductStockEvents lists the events raised by a change to a product,
+// given as it was before and after it. before is nil for creates. A product
+// starts needing restock when its projection crosses the minimum stock, or
+// when it is created or restored already below it.
+func NewProductStockEvents(actor string, before, after *entities.ProductStock, occurredAt time.Time) []Event {
+	if after == nil || after.ID == nil {
+		return nil
+	}
+
+	if actor == "" {
+		actor = audit.AnonymousActor
+	}
+
+	newEvent := func(t Type) Event {
+		return Event{
+			Type:       t,
+			ProductID:  *after.ID,
+			Actor:      actor,
+			OccurredAt: occurredAt,
+			Data:       Data{Product: newProduct(after)},
+		}
+	}
+
+	wasActive := before != nil && before.DeletedAt == nil
+	isActive := after.DeletedAt == nil
+
+	var events []Event
+	switch {
+	case before == nil:
+		events = append(events, newEvent(ProductCreated))
+	case wasActive && !isActive:
+		events = append(events, newEvent(ProductDeleted))
+	case !wasActive && isActive:
+		events = append(events, newEvent(ProductRestored))
+	case isActive && before.CurrentStock != after.CurrentStock:
+		e := newEvent(StockChanged)
+		previousStock := before.CurrentStock
+		e.Data.PreviousStock = &previousStock
+		events = append(events, e)
+	}
+
+	if !isActive {
+		return events
+	}
+
+	projection := restock.Project(after)
+	if projection.IsRepositionNeeded && (!wasActive || !restock.Project(before).IsRepositionNeeded) {
+		e := newEvent(RestockNeeded)
+		e.Data.Restock = &Restock{
+			ProjectedStock:    projection.ProjectedStock,
+			UrgencyScore:      projection.UrgencyScore,
+			SuggestedQuantity: projection.SuggestedQuantity,
+		}
+		events = append(events, e)
+	}
+
+	return events
+}
+
+// ProductStock rebuilds the product of the event as it is after the change.
+func (e Event) ProductStock() *entities.ProductStock {
+	id := e.ProductID
+	p := e.Data.Product
+
+	return &entities.ProductStock{
+		ID:                &id,
+		Name:              p.Name,
+		Category:          entities.ProductCategory(p.Category),
+		CurrentStock:      p.CurrentStock,
+		MinimumStock:      p.MinimumStock,
+		AverageDailySales: p.AverageDailySales,
+		LeadTimeDays:      p.LeadTimeDays,
+		UnitCost:          p.UnitCost,
+		CriticalityLevel:  entities.CriticalityLevel(p.CriticalityLevel),
+		Identifiers: entities.ProductIdentifiers{
+			SKU:         p.SKU,
+			Barcodes:    p.Barcodes,
+			ExternalIDs: p.ExternalIDs,
+		},
+		DeletedAt: p.DeletedAt,
+	}
+}
+
+func newProduct(p *entities.ProductStock) Product {
+	product := Product{
+		Name:              p.Name,
+		Category:          string(p.Category),
+		CurrentStock:      p.CurrentStock,
+		MinimumStock:      p.MinimumStock,
+		AverageDailySales: p.AverageDailySales,
+		LeadTimeDays:      p.LeadTimeDays,
+		UnitCost:          p.UnitCost,
+		CriticalityLevel:  int(p.CriticalityLevel),
+		SKU:               p.Identifiers.SKU,
+		Barcodes:          p.Identifiers.Barcodes,
+		ExternalIDs:       p.Identifiers.ExternalIDs,
+		DeletedAt:         p.DeletedAt,
+	}
+
+	if product.Barcodes == nil {
+		product.Barcodes = []string{}
+	}
+
+	if product.ExternalIDs == nil {
+		product.ExternalIDs = map[string]string{}
+	}
+
+	return product
+}
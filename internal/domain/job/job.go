@@ -0,0 +1,79 @@
+package job
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+)
+
+// ISchedule tells when a job runs next, e.g. after a cron expression.
+type ISchedule interface {
+	Next(after time.Time) time.Time
+}
+
+// IScheduler calls the functions at the times of their schedules, in the
+// background.
+type IScheduler interface {
+	Schedule(schedule ISchedule, fn func())
+}
+
+// Task does the work of a job and sums up what it did, e.g. "purged 3
+// products".
+type Task func() (string, *domain.Error)
+
+// Job is a task run periodically by a single instance of the application.
+type Job struct {
+	Name        string
+	Description string
+	// Spec is the schedule as configured, e.g. "0 8 * * *" or "@hourly".
+	Spec     string
+	Schedule ISchedule
+	Task     Task
+}
+
+// Period is the time between the run at the given time and the next one.
+func (j Job) Period(at time.Time) time.Duration {
+	return j.Schedule.Next(at).Sub(at)
+}
+
+type Status string
+
+const (
+	Running   Status = "running"
+	Succeeded Status = "succeeded"
+	Failed    Status = "failed"
+)
+
+// Run records a run of a job. ID is assigned when it is stored.
+type Run struct {
+	ID         int64
+	Job        string
+	Instance   string
+	Status     Status
+	Result     *string
+	Error      *string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// NewRun starts a run of the job on the instance, e.g. a host name.
+func NewRun(job, instance string, startedAt time.Time) Run {
+	return Run{
+		Job:       job,
+		Instance:  instance,
+		Status:    Running,
+		StartedAt: startedAt,
+	}
+}
+
+func (r *Run) Succeed(result string, at time.Time) {
+	r.Status = Succeeded
+	r.Result = &result
+	r.FinishedAt = &at
+}
+
+func (r *Run) Fail(reason string, at time.Time) {
+	r.Status = Failed
+	r.Error = &reason
+	r.FinishedAt = &at
+}
@@ -1,8 +1,18 @@
 package domain
 
+// Pagination is either offset based (Page) or keyset based (Cursor). When a
+// cursor is given the use case decodes it into After, the sort key of the
+// last item already seen, and Page is ignored.
 type Pagination struct {
-	Page  int
-	Limit int
+	Page         int
+	Limit        int
+	Cursor       string
+	After        []any
+	IncludeTotal bool
+
+	// lookahead counts the extra items Lookahead added to Limit, which must
+	// not move the offset of the page.
+	lookahead int
 }
 
 type PaginationConfig struct {
@@ -10,6 +20,14 @@ type PaginationConfig struct {
 	MaxLimit     int
 }
 
+// Page is a slice of a list. NextCursor is empty on the last page and Total
+// is only filled when requested.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	Total      *int
+}
+
 func ApplyPaginationRules(p *Pagination, config PaginationConfig) {
 	if p.Limit > config.MaxLimit {
 		p.Limit = config.MaxLimit
@@ -24,8 +42,14 @@ func ApplyPaginationRules(p *Pagination, config PaginationConfig) {
 	}
 }
 
+// Offset returns how many items precede the page, counted with the limit
+// the caller asked for.
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * (p.Limit - p.lookahead)
+}
+
 func PaginatedSlice[T any](slice []T, p *Pagination) []T {
-	offset := (p.Page - 1) * p.Limit
+	offset := p.Offset()
 
 	if offset >= len(slice) {
 		return []T{}
@@ -35,3 +59,29 @@ func PaginatedSlice[T any](slice []T, p *Pagination) []T {
 
 	return slice[offset:end]
 }
+
+// Lookahead returns a copy of the pagination asking for one extra item, so
+// the caller can tell whether there is a next page without counting.
+func (p Pagination) Lookahead() *Pagination {
+	p.Limit++
+	p.lookahead++
+	return &p
+}
+
+// NewPage trims the lookahead item fetched with Lookahead and, when there
+// are more items, encodes the sort key of the last returned one as the next
+// cursor.
+func NewPage[T any](items []T, p Pagination, scope string, sortKey func(T) []any) Page[T] {
+	page := Page[T]{Items: items}
+
+	if len(items) > p.Limit {
+		page.Items = items[:p.Limit]
+		page.NextCursor = EncodeCursor(scope, sortKey(page.Items[len(page.Items)-1]))
+	}
+
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	return page
+}
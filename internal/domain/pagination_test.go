@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookaheadKeepsPageOffset(t *testing.T) {
+	p := Pagination{Page: 2, Limit: 20}
+
+	if got := p.Lookahead().Offset(); got != 20 {
+		t.Fatalf("offset of page 2 with lookahead = %d, want 20", got)
+	}
+
+	if got := p.Lookahead().Lookahead().Offset(); got != 20 {
+		t.Fatalf("offset of page 2 with two lookaheads = %d, want 20", got)
+	}
+}
+
+func TestPagesWithLookaheadAreConsecutive(t *testing.T) {
+	items := make([]int, 45)
+	for i := range items {
+		items[i] = i
+	}
+
+	var seen []int
+	for page := 1; page <= 3; page++ {
+		p := Pagination{Page: page, Limit: 20}
+		got := NewPage(PaginatedSlice(items, p.Lookahead()), p, "test", func(i int) []any { return []any{i} })
+
+		if last := page == 3; (got.NextCursor == "") != last {
+			t.Fatalf("page %d next cursor = %q, want one only before the last page", page, got.NextCursor)
+		}
+
+		seen = append(seen, got.Items...)
+	}
+
+	if !slices.Equal(seen, items) {
+		t.Fatalf("pages returned %v, want %v", seen, items)
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+)
+
+// IAlertRepository is an optional capability of a product stock repository
+// able to keep alert rules and the alerts they raised, which belong to the
+// tenant the repository is bound to. Deleting a rule deletes its alerts.
+type IAlertRepository interface {
+	CreateAlertRule(r *alert.Rule) (string, *domain.Error)
+	GetAlertRules() ([]alert.Rule, *domain.Error)
+	DeleteAlertRule(id string) *domain.Error
+	// CreateAlert fails with ErrConflict when the rule already raised an
+	// alert for the event.
+	CreateAlert(a *alert.Alert) (int64, *domain.Error)
+	// UpdateAlert saves the status of the alert.
+	UpdateAlert(a *alert.Alert) *domain.Error
+	// LockAlerts waits for the other transactions holding the alerts of the
+	// rule about the product, and holds them until the transaction ends.
+	LockAlerts(ruleID, productID string) *domain.Error
+	// GetLastAlert returns the latest alert of the rule about the product.
+	GetLastAlert(ruleID, productID string) (*alert.Alert, *domain.Error)
+	// GetAlerts lists the alerts newest first, those of the given rule only
+	// unless it is empty.
+	GetAlerts(ruleID string, pagination *domain.Pagination) ([]alert.Alert, *domain.Error)
+	CountAlerts(ruleID string) (int, *domain.Error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+)
+
+// AuditQuery is a conjunction of optional criteria. The time range includes
+// From and excludes To.
+type AuditQuery struct {
+	EntityID string
+	Actor    string
+	From     *time.Time
+	To       *time.Time
+}
+
+// StockOutflow is the stock taken out of a product, e.g. sold, over a
+// period, when its history in the log starts, and the average daily sales
+// it has now.
+type StockOutflow struct {
+	ProductID         string
+	Quantity          int
+	FirstRecordedAt   time.Time
+	AverageDailySales int
+}
+
+// IAuditLogRepository is an optional capability of a product stock
+// repository able to keep the audit log itself. Entries appended through a
+// repository bound to a transaction are committed or rolled back with the
+// changes they describe. Entries are listed newest first.
+type IAuditLogRepository interface {
+	AppendAuditEntries(entries []audit.Entry) *domain.Error
+	GetAuditEntries(query AuditQuery, pagination *domain.Pagination) ([]audit.Entry, *domain.Error)
+	CountAuditEntries(query AuditQuery) (int, *domain.Error)
+	// GetLatestAuditEntries returns the newest entries of each of the
+	// entities, up to limit per entity, in a single query.
+	GetLatestAuditEntries(entityIDs []string, limit int) (map[string][]audit.Entry, *domain.Error)
+	// GetStockOutflows sums, for every product in the catalog with a
+	// history in the log, the decreases of its current stock recorded by
+	// updates since from. Deleted products are left out.
+	GetStockOutflows(from time.Time) ([]StockOutflow, *domain.Error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+)
+
+// IdempotentResponse is the response stored for an idempotency key, replayed
+// to the retries of the request.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyKeyID scopes a key to the tenant and the actor that sent it,
+// so callers never see or collide with each other's keys.
+type IdempotencyKeyID struct {
+	TenantID string
+	Actor    string
+	Key      string
+}
+
+// IdempotencyKey is the record of a request made with an idempotency key.
+// Response is nil while the request is still being processed.
+type IdempotencyKey struct {
+	IdempotencyKeyID
+	Fingerprint string
+	Response    *IdempotentResponse
+	ExpiresAt   time.Time
+}
+
+// IIdempotencyKeyRepository is an optional capability of a product stock
+// repository able to store idempotency keys next to the products, so the
+// keys survive restarts and are shared between instances.
+type IIdempotencyKeyRepository interface {
+	// ReserveIdempotencyKey stores the key unless an unexpired record of it
+	// already exists, in which case that record is returned instead.
+	ReserveIdempotencyKey(key IdempotencyKey, now time.Time) (*IdempotencyKey, *domain.Error)
+	CompleteIdempotencyKey(id IdempotencyKeyID, response IdempotentResponse) *domain.Error
+	ReleaseIdempotencyKey(id IdempotencyKeyID) *domain.Error
+	DeleteExpiredIdempotencyKeys(now time.Time) (int, *domain.Error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
+)
+
+// IJobRepository is an optional capability of a product stock repository
+// able to coordinate the scheduled jobs of every instance and keep their
+// runs. Jobs are not bound to tenants. Runs are listed newest first.
+type IJobRepository interface {
+	// TryJobLock takes the lock of the job unless another instance holds
+	// it. The lock is held until unlock is called.
+	TryJobLock(name string) (unlock func(), locked bool, err *domain.Error)
+	CreateJobRun(r *job.Run) (int64, *domain.Error)
+	UpdateJobRun(r *job.Run) *domain.Error
+	GetLastJobRun(name string) (*job.Run, *domain.Error)
+	GetJobRuns(name string, pagination *domain.Pagination) ([]job.Run, *domain.Error)
+	CountJobRuns(name string) (int, *domain.Error)
+	PurgeJobRuns(before time.Time) (int, *domain.Error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+)
+
+// OutboxEvent is an event waiting to be dispatched, with the number of
+// failed attempts to dispatch it so far.
+type OutboxEvent struct {
+	Event    event.Event
+	Attempts int
+}
+
+// IOutboxRepository is an optional capability of a product stock repository
+// able to keep the events of the changes in an outbox. Events appended
+// through a repository bound to a transaction are committed or rolled back
+// with the changes that raised them; the other methods work across tenants,
+// except for GetOutboxEvents when the repository is bound to one.
+type IOutboxRepository interface {
+	AppendOutboxEvents(events []event.Event) *domain.Error
+	// ClaimOutboxEvents leases up to limit events due at now, oldest first,
+	// until leaseUntil. Claimed events are not claimed again before the
+	// lease ends, so concurrent dispatchers do not publish them together,
+	// and a dispatcher stopping before it settles them does not lose them.
+	ClaimOutboxEvents(limit int, now, leaseUntil time.Time) ([]OutboxEvent, *domain.Error)
+	MarkOutboxEventDispatched(id int64, dispatchedAt time.Time) *domain.Error
+	// RetryOutboxEvent records a failed attempt. The event is due again at
+	// nextAttemptAt, or never when it is nil.
+	RetryOutboxEvent(id int64, attempts int, nextAttemptAt *time.Time, lastError string) *domain.Error
+	// PurgeDispatchedOutboxEvents deletes the events dispatched before the
+	// given time and returns how many were deleted.
+	PurgeDispatchedOutboxEvents(dispatchedBefore time.Time) (int, *domain.Error)
+	// GetOutboxEvents lists the events, dispatched or not, with an id
+	// greater than afterID or among ids, oldest first, up to limit.
+	GetOutboxEvents(afterID int64, ids []int64, limit int) ([]event.Event, *domain.Error)
+	// GetLastOutboxEventID returns the greatest id of the events, or 0 when
+	// there are none.
+	GetLastOutboxEventID() (int64, *domain.Error)
+}
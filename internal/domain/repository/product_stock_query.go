@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+type ProductStockSortField string
+
+const (
+	SortByName              ProductStockSortField = "name"
+	SortByCategory          ProductStockSortField = "category"
+	SortByCurrentStock      ProductStockSortField = "current_stock"
+	SortByMinimumStock      ProductStockSortField = "minimum_stock"
+	SortByAverageDailySales ProductStockSortField = "average_daily_sales"
+	SortByLeadTimeDays      ProductStockSortField = "lead_time_days"
+	SortByUnitCost          ProductStockSortField = "unit_cost"
+	SortByCriticalityLevel  ProductStockSortField = "criticality_level"
+)
+
+func IsValidProductStockSortField(f ProductStockSortField) bool {
+	switch f {
+	case SortByName, SortByCategory, SortByCurrentStock, SortByMinimumStock,
+		SortByAverageDailySales, SortByLeadTimeDays, SortByUnitCost, SortByCriticalityLevel:
+		return true
+	default:
+		return false
+	}
+}
+
+type ProductStockSort struct {
+	Field      ProductStockSortField
+	Descending bool
+}
+
+// ProductStockFilter is a conjunction of optional criteria; nil and empty
+// fields do not restrict the result.
+type ProductStockFilter struct {
+	NameContains   string
+	Categories     []entities.ProductCategory
+	MinCriticality *entities.CriticalityLevel
+	MaxCriticality *entities.CriticalityLevel
+	MinUnitCost    *float64
+	MaxUnitCost    *float64
+	MinStock       *int
+	MaxStock       *int
+	BelowMinimum   *bool
+	NeedsRestock   *bool
+	IncludeDeleted bool
+}
+
+// ProductStockQuery is validated by the application layer before reaching
+// a repository, so implementations may trust its fields.
+type ProductStockQuery struct {
+	Filter ProductStockFilter
+	Sort   []ProductStockSort
+}
+
+// Matches evaluates the filter in memory, for backends that cannot
+// translate it into their own query language.
+func (f ProductStockFilter) Matches(p *entities.ProductStock) bool {
+	if !f.IncludeDeleted && p.DeletedAt != nil {
+		return false
+	}
+
+	if f.NameContains != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(f.NameContains)) {
+		return false
+	}
+
+	if len(f.Categories) > 0 && !slices.Contains(f.Categories, p.Category) {
+		return false
+	}
+
+	if f.MinCriticality != nil && p.CriticalityLevel < *f.MinCriticality {
+		return false
+	}
+
+	if f.MaxCriticality != nil && p.CriticalityLevel > *f.MaxCriticality {
+		return false
+	}
+
+	if f.MinUnitCost != nil && p.UnitCost < *f.MinUnitCost {
+		return false
+	}
+
+	if f.MaxUnitCost != nil && p.UnitCost > *f.MaxUnitCost {
+		return false
+	}
+
+	if f.MinStock != nil && p.CurrentStock < *f.MinStock {
+		return false
+	}
+
+	if f.MaxStock != nil && p.CurrentStock > *f.MaxStock {
+		return false
+	}
+
+	if f.BelowMinimum != nil && (p.CurrentStock < p.MinimumStock) != *f.BelowMinimum {
+		return false
+	}
+
+	if f.NeedsRestock != nil && restock.Project(p).IsRepositionNeeded != *f.NeedsRestock {
+		return false
+	}
+
+	return true
+}
+
+// CursorScope names the ordering of the query; cursors are only valid for
+// queries with the same scope.
+func (q *ProductStockQuery) CursorScope() string {
+	var fields []string
+	if q != nil {
+		for _, s := range q.Sort {
+			if s.Descending {
+				fields = append(fields, "-"+string(s.Field))
+			} else {
+				fields = append(fields, string(s.Field))
+			}
+		}
+	}
+
+	return "products:" + strings.Join(fields, ",")
+}
+
+// SortKey returns the values of the sort fields of p followed by its id,
+// the keyset the next page resumes after.
+func (q *ProductStockQuery) SortKey(p *entities.ProductStock) []any {
+	var key []any
+	if q != nil {
+		for _, s := range q.Sort {
+			key = append(key, sortValue(p, s.Field))
+		}
+	}
+
+	id := ""
+	if p.ID != nil {
+		id = *p.ID
+	}
+
+	return append(key, id)
+}
+
+func (q *ProductStockQuery) SortKeyLength() int {
+	if q == nil {
+		return 1
+	}
+
+	return len(q.Sort) + 1
+}
+
+func sortValue(p *entities.ProductStock, field ProductStockSortField) any {
+	switch field {
+	case SortByName:
+		return p.Name
+	case SortByCategory:
+		return string(p.Category)
+	case SortByCurrentStock:
+		return p.CurrentStock
+	case SortByMinimumStock:
+		return p.MinimumStock
+	case SortByAverageDailySales:
+		return p.AverageDailySales
+	case SortByLeadTimeDays:
+		return p.LeadTimeDays
+	case SortByUnitCost:
+		return p.UnitCost
+	case SortByCriticalityLevel:
+		return int(p.CriticalityLevel)
+	default:
+		return nil
+	}
+}
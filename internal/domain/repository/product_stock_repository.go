@@ -1,15 +1,33 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 )
 
+// IProductStockRepository works on the products of the tenant it is bound
+// to by ForTenant: every read and write is restricted to that tenant.
 type IProductStockRepository interface {
+	ForTenant(tenantID string) IProductStockRepository
 	Create(in *entities.ProductStock) (string, *domain.Error)
 	Update(in *entities.ProductStock) *domain.Error
-	GetAll(pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error)
+	GetAll(query *ProductStockQuery, pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error)
+	Count(query *ProductStockQuery) (int, *domain.Error)
+	// CountByCategory counts the products of every category holding any.
+	CountByCategory() (map[entities.ProductCategory]int, *domain.Error)
 	GetOneByID(id string) (*entities.ProductStock, *domain.Error)
+	GetOneByIDIncludingDeleted(id string) (*entities.ProductStock, *domain.Error)
+	GetOneBySKU(sku string) (*entities.ProductStock, *domain.Error)
+	GetOneBySKUIncludingDeleted(sku string) (*entities.ProductStock, *domain.Error)
+	GetOneByBarcode(barcode string) (*entities.ProductStock, *domain.Error)
+	GetOneByBarcodeIncludingDeleted(barcode string) (*entities.ProductStock, *domain.Error)
 	GetByCategory(category entities.ProductCategory, pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error)
+	// DeleteProductStock soft deletes the product: it is hidden from every
+	// read but GetOneByIDIncludingDeleted and queries including deleted
+	// products, and keeps its identifiers until it is purged.
 	DeleteProductStock(id string) *domain.Error
+	RestoreProductStock(id string) *domain.Error
+	PurgeDeletedProductStocks(deletedBefore time.Time) ([]string, *domain.Error)
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+type ProductStockSearchResult struct {
+	Score        float64
+	ProductStock *entities.ProductStock
+}
+
+// IProductStockSearchRepository is an optional capability of a product
+// stock repository able to rank products by relevance to a free-text query
+// itself. Results are ordered by descending score.
+type IProductStockSearchRepository interface {
+	Search(query string, pagination *domain.Pagination) ([]ProductStockSearchResult, *domain.Error)
+}
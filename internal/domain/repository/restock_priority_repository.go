@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+// IRestockPriorityRepository is an optional capability of a product stock
+// repository: backends implementing it project, filter and order the
+// products themselves, so only the requested page is materialized.
+type IRestockPriorityRepository interface {
+	// GetRestockPriorities lists the products of the given categories, or
+	// of every category when there are none.
+	GetRestockPriorities(categories []entities.ProductCategory, pagination *domain.Pagination) ([]restock.Priority, *domain.Error)
+	CountRestockPriorities() (int, *domain.Error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+// IRestockPrioritySnapshotRepository is an optional capability of a product
+// stock repository keeping the restock priorities of the tenant precomputed
+// next to the products, so every instance serves the same snapshot and the
+// snapshot changes in the same transaction as the products.
+type IRestockPrioritySnapshotRepository interface {
+	// RebuildRestockPrioritySnapshot recomputes the whole snapshot. Until
+	// the first rebuild the tenant has no snapshot.
+	RebuildRestockPrioritySnapshot(computedAt time.Time) *domain.Error
+	// RefreshRestockPrioritySnapshot recomputes the entry of a product just
+	// written, dropping it when the product was deleted or no longer needs
+	// a reposition. Tenants without a snapshot are left alone.
+	RefreshRestockPrioritySnapshot(p *entities.ProductStock, computedAt time.Time) *domain.Error
+	// GetRestockPrioritySnapshot returns a page of the snapshot, ordered by
+	// urgency, and the time it last changed, which is nil when the tenant
+	// has no snapshot yet.
+	GetRestockPrioritySnapshot(pagination *domain.Pagination) ([]restock.Priority, *time.Time, *domain.Error)
+	CountRestockPrioritySnapshot() (int, *domain.Error)
+}
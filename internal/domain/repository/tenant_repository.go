@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+type ITenantRepository interface {
+	GetTenant(id string) (*tenant.Tenant, *domain.Error)
+	GetTenants() ([]tenant.Tenant, *domain.Error)
+}
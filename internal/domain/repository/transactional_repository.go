@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+
+// ITransactionalRepository is an optional capability of a product stock
+// repository able to run several operations atomically. The repository
+// given to fn is bound to the transaction, which is rolled back when fn
+// returns an error.
+type ITransactionalRepository interface {
+	WithinTransaction(fn func(repo IProductStockRepository) *domain.Error) *domain.Error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+)
+
+// IWebhookRepository is an optional capability of a product stock
+// repository able to keep webhook subscriptions and their deliveries.
+// Subscriptions and deliveries belong to the tenant the repository is bound
+// to, except for ClaimWebhookDeliveries and GetWebhookSubscription, which
+// work across tenants when it is not bound to any. Deleting a subscription
+// deletes its deliveries.
+type IWebhookRepository interface {
+	CreateWebhookSubscription(s *webhook.Subscription) (string, *domain.Error)
+	GetWebhookSubscriptions() ([]webhook.Subscription, *domain.Error)
+	GetWebhookSubscription(id string) (*webhook.Subscription, *domain.Error)
+	DeleteWebhookSubscription(id string) *domain.Error
+	// EnqueueWebhookDeliveries skips the deliveries of an event to a
+	// subscription that already has one, so an event dispatched twice is
+	// delivered once.
+	EnqueueWebhookDeliveries(deliveries []webhook.Delivery) *domain.Error
+	// ClaimWebhookDeliveries leases up to limit pending deliveries due at
+	// now, oldest first, until leaseUntil, like ClaimOutboxEvents.
+	ClaimWebhookDeliveries(limit int, now, leaseUntil time.Time) ([]webhook.Delivery, *domain.Error)
+	// UpdateWebhookDelivery saves the status and the outcome of the last
+	// attempt of the delivery.
+	UpdateWebhookDelivery(d *webhook.Delivery) *domain.Error
+	GetWebhookDelivery(subscriptionID string, id int64) (*webhook.Delivery, *domain.Error)
+	// GetWebhookDeliveries lists the deliveries of a subscription, newest
+	// first, only those with the given status unless it is empty.
+	GetWebhookDeliveries(subscriptionID string, status webhook.Status, pagination *domain.Pagination) ([]webhook.Delivery, *domain.Error)
+	CountWebhookDeliveries(subscriptionID string, status webhook.Status) (int, *domain.Error)
+}
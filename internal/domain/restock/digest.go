@@ -0,0 +1,44 @@
+package restock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Digest summarizes, once in a while, the most urgent restock priorities of
+// a tenant for the people buying.
+type Digest struct {
+	TenantID   string
+	Priorities []Priority
+	// Total is the number of products needing a reposition, of which
+	// Priorities are the most urgent.
+	Total     int
+	CreatedAt time.Time
+}
+
+func (d Digest) Subject() string {
+	return fmt.Sprintf("Restock digest of %s: %d products need a reposition", d.TenantID, d.Total)
+}
+
+func (d Digest) Text() string {
+	lines := []string{d.Subject(), ""}
+
+	for i, p := range d.Priorities {
+		line := fmt.Sprintf("%d. %s", i+1, p.ProductStock.Name)
+		if p.ProductStock.Identifiers.SKU != nil {
+			line += " (" + *p.ProductStock.Identifiers.SKU + ")"
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %d in stock, %d projected after %d days of lead time, order %d (urgency %d)",
+			line, p.ProductStock.CurrentStock, p.ProjectedStock, p.ProductStock.LeadTimeDays, p.SuggestedQuantity, p.UrgencyScore))
+	}
+
+	if more := d.Total - len(d.Priorities); more > 0 {
+		lines = append(lines, fmt.Sprintf("... and %d more", more))
+	}
+
+	lines = append(lines, "", "As of "+d.CreatedAt.UTC().Format(time.RFC3339))
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,194 @@
+package restock
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+type DistributionKind string
+
+const (
+	FixedDistribution      DistributionKind = "fixed"
+	PoissonDistribution    DistributionKind = "poisson"
+	NormalDistribution     DistributionKind = "normal"
+	UniformDistribution    DistributionKind = "uniform"
+	TriangularDistribution DistributionKind = "triangular"
+)
+
+func IsValidDistributionKind(k DistributionKind) bool {
+	switch k {
+	case FixedDistribution, PoissonDistribution, NormalDistribution, UniformDistribution, TriangularDistribution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Distribution is centered on the product's own value (average daily sales
+// or lead time). Spread is the coefficient of variation for the normal
+// distribution and the relative half-width for the uniform and triangular
+// ones; fixed and poisson ignore it.
+type Distribution struct {
+	Kind   DistributionKind
+	Spread float64
+}
+
+func (d Distribution) sample(r *rand.Rand, mean float64) float64 {
+	switch d.Kind {
+	case PoissonDistribution:
+		return samplePoisson(r, mean)
+	case NormalDistribution:
+		return max(0, r.NormFloat64()*d.Spread*mean+mean)
+	case UniformDistribution:
+		low := max(0, mean*(1-d.Spread))
+		high := mean * (1 + d.Spread)
+		return low + r.Float64()*(high-low)
+	case TriangularDistribution:
+		return sampleTriangular(r, max(0, mean*(1-d.Spread)), mean, mean*(1+d.Spread))
+	default:
+		return mean
+	}
+}
+
+// sampleTotal draws the demand accumulated over the given days. Poisson,
+// normal and fixed demand are aggregated in closed form; the others are
+// drawn day by day.
+func (d Distribution) sampleTotal(r *rand.Rand, mean float64, days int) float64 {
+	switch d.Kind {
+	case PoissonDistribution:
+		return samplePoisson(r, mean*float64(days))
+	case NormalDistribution:
+		total := mean * float64(days)
+		return max(0, r.NormFloat64()*d.Spread*mean*math.Sqrt(float64(days))+total)
+	case FixedDistribution:
+		return mean * float64(days)
+	default:
+		total := 0.0
+		for range days {
+			total += d.sample(r, mean)
+		}
+		return total
+	}
+}
+
+type RiskParams struct {
+	Trials     int
+	Seed       uint64
+	Confidence float64
+	Demand     Distribution
+	LeadTime   Distribution
+}
+
+// StockoutRisk summarizes the trials of a product: how often the stock ran
+// out before the replenishment arrived and the confidence interval of the
+// stock left at that moment.
+type StockoutRisk struct {
+	StockoutProbability     float64
+	BelowMinimumProbability float64
+	MeanProjectedStock      float64
+	ProjectedStockLower     float64
+	ProjectedStockUpper     float64
+}
+
+// EstimateStockoutRisk runs a Monte Carlo simulation of the replenishment
+// window of a product. The random stream is derived from the seed and the
+// product id, so the result of a product does not depend on which other
+// products are estimated with it.
+func EstimateStockoutRisk(p *entities.ProductStock, params RiskParams) StockoutRisk {
+	r := rand.New(rand.NewPCG(params.Seed, productStream(p)))
+
+	projected := make([]float64, params.Trials)
+	stockouts := 0
+	belowMinimum := 0
+	sum := 0.0
+
+	for trial := range params.Trials {
+		leadTime := int(math.Round(params.LeadTime.sample(r, float64(p.LeadTimeDays))))
+		demand := math.Round(params.Demand.sampleTotal(r, float64(p.AverageDailySales), leadTime))
+
+		stock := float64(p.CurrentStock) - demand
+		projected[trial] = stock
+		sum += stock
+
+		if stock < 0 {
+			stockouts++
+		}
+
+		if stock < float64(p.MinimumStock) {
+			belowMinimum++
+		}
+	}
+
+	slices.Sort(projected)
+	tail := (1 - params.Confidence) / 2
+
+	return StockoutRisk{
+		StockoutProbability:     float64(stockouts) / float64(params.Trials),
+		BelowMinimumProbability: float64(belowMinimum) / float64(params.Trials),
+		MeanProjectedStock:      sum / float64(params.Trials),
+		ProjectedStockLower:     percentile(projected, tail),
+		ProjectedStockUpper:     percentile(projected, 1-tail),
+	}
+}
+
+func productStream(p *entities.ProductStock) uint64 {
+	h := fnv.New64a()
+	if p.ID != nil {
+		h.Write([]byte(*p.ID))
+	} else {
+		h.Write([]byte(p.Name))
+	}
+
+	return h.Sum64()
+}
+
+// percentile interpolates linearly between the closest ranks of a sorted
+// sample.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+func samplePoisson(r *rand.Rand, mean float64) float64 {
+	if mean <= 0 {
+		return 0
+	}
+
+	// Knuth's multiplication method is exact but linear in the mean, so
+	// large means fall back to the normal approximation.
+	if mean > 30 {
+		return max(0, math.Round(r.NormFloat64()*math.Sqrt(mean)+mean))
+	}
+
+	limit := math.Exp(-mean)
+	k := 0
+	for product := r.Float64(); product > limit; product *= r.Float64() {
+		k++
+	}
+
+	return float64(k)
+}
+
+func sampleTriangular(r *rand.Rand, low, mode, high float64) float64 {
+	if high <= low {
+		return mode
+	}
+
+	u := r.Float64()
+	if u < (mode-low)/(high-low) {
+		return low + math.Sqrt(u*(high-low)*(mode-low))
+	}
+
+	return high - math.Sqrt((1-u)*(high-low)*(high-mode))
+}
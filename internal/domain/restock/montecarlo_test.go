@@ -0,0 +1,66 @@
+package restock
+
+import (
+	"testing"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+func riskTestProduct() *entities.ProductStock {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	return &entities.ProductStock{
+		ID:                &id,
+		Name:              "Oil Filter X",
+		CurrentStock:      40,
+		MinimumStock:      10,
+		AverageDailySales: 6,
+		LeadTimeDays:      7,
+	}
+}
+
+func TestEstimateStockoutRiskIsReproducibleWithSeed(t *testing.T) {
+	params := RiskParams{
+		Trials:     2000,
+		Seed:       42,
+		Confidence: 0.9,
+		Demand:     Distribution{Kind: PoissonDistribution},
+		LeadTime:   Distribution{Kind: TriangularDistribution, Spread: 0.5},
+	}
+
+	first := EstimateStockoutRisk(riskTestProduct(), params)
+	second := EstimateStockoutRisk(riskTestProduct(), params)
+
+	if first != second {
+		t.Fatalf("same seed gave %+v and %+v", first, second)
+	}
+
+	if first.StockoutProbability <= 0 || first.StockoutProbability >= 1 {
+		t.Fatalf("stockout probability = %v, want a risk strictly between 0 and 1", first.StockoutProbability)
+	}
+
+	params.Seed = 43
+	if other := EstimateStockoutRisk(riskTestProduct(), params); other == first {
+		t.Fatalf("seeds 42 and 43 gave the same estimate %+v", first)
+	}
+}
+
+func TestEstimateStockoutRiskWithFixedDistributionsIsExact(t *testing.T) {
+	risk := EstimateStockoutRisk(riskTestProduct(), RiskParams{
+		Trials:     100,
+		Seed:       1,
+		Confidence: 0.95,
+		Demand:     Distribution{Kind: FixedDistribution},
+		LeadTime:   Distribution{Kind: FixedDistribution},
+	})
+
+	want := StockoutRisk{
+		StockoutProbability:     1,
+		BelowMinimumProbability: 1,
+		MeanProjectedStock:      -2,
+		ProjectedStockLower:     -2,
+		ProjectedStockUpper:     -2,
+	}
+	if risk != want {
+		t.Fatalf("risk = %+v, want %+v", risk, want)
+	}
+}
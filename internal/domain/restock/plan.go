@@ -0,0 +1,271 @@
+package restock
+
+import (
+	"math"
+)
+
+// PlanItem is an order line candidate for the budget optimizer. Cost and
+// the budgets are expressed in currency units with cent precision.
+type PlanItem struct {
+	Group string
+	Cost  float64
+	Value float64
+}
+
+type PlanConstraints struct {
+	Budget    float64
+	GroupCaps map[string]float64
+}
+
+const (
+	// maxPlanCapacity bounds the number of budget buckets of the dynamic
+	// program; above it the costs are rounded up to a coarser unit, which
+	// keeps every returned plan within the real budget.
+	maxPlanCapacity = 100_000
+	// maxAllocationSteps bounds groups x capacity², the steps taken to
+	// split the budget between the groups whose cap binds.
+	maxAllocationSteps = 200_000_000
+	// maxPlanCells bounds items x capacity, i.e. the memory used to
+	// reconstruct the chosen lines.
+	maxPlanCells    = 50_000_000
+	minPlanCapacity = 100
+)
+
+// OptimizePlan solves the 0/1 knapsack problem over the items, maximizing
+// the total value while keeping the total cost within the budget and the
+// cost of every group within its cap. It returns the indexes of the
+// selected items in their original order.
+func OptimizePlan(items []PlanItem, constraints PlanConstraints) []int {
+	budget := toCents(constraints.Budget)
+	if budget <= 0 {
+		return []int{}
+	}
+
+	caps := make(map[string]int64, len(constraints.GroupCaps))
+	for group, limit := range constraints.GroupCaps {
+		caps[group] = max(toCents(limit), 0)
+	}
+
+	capOf := func(group string) int64 {
+		if limit, ok := caps[group]; ok {
+			return min(limit, budget)
+		}
+		return budget
+	}
+
+	var eligible []int
+	costs := make([]int64, len(items))
+	for i, item := range items {
+		costs[i] = toCents(item.Cost)
+		if costs[i] <= 0 || item.Value <= 0 || costs[i] > capOf(item.Group) {
+			continue
+		}
+		eligible = append(eligible, i)
+	}
+
+	if len(eligible) == 0 {
+		return []int{}
+	}
+
+	groups := groupItems(items, eligible, func(group string) bool {
+		return capOf(group) < budget
+	})
+
+	maxCapacity := int64(maxPlanCapacity)
+	if len(groups) > 1 {
+		maxCapacity = min(maxCapacity, int64(math.Sqrt(float64(maxAllocationSteps/len(groups)))))
+	}
+	maxCapacity = max(min(maxCapacity, int64(maxPlanCells/len(eligible))), minPlanCapacity)
+
+	unit := budget
+	for _, limit := range caps {
+		unit = gcd(unit, limit)
+	}
+	for _, i := range eligible {
+		unit = gcd(unit, costs[i])
+	}
+	if budget/unit > maxCapacity {
+		unit = ceilDiv(budget, maxCapacity)
+	}
+
+	capacity := int(budget / unit)
+	weights := make([]int, len(items))
+	for _, i := range eligible {
+		weights[i] = int(ceilDiv(costs[i], unit))
+	}
+
+	solutions := make([]*groupSolution, len(groups))
+	for g, group := range groups {
+		groupCapacity := min(int(capOf(items[group[0]].Group)/unit), capacity)
+		solutions[g] = solveGroup(items, weights, group, groupCapacity)
+	}
+
+	allocation := allocateCapacity(solutions, capacity)
+
+	selected := make([]bool, len(items))
+	for g, solution := range solutions {
+		for _, i := range solution.reconstruct(allocation[g]) {
+			selected[i] = true
+		}
+	}
+
+	result := []int{}
+	for i, ok := range selected {
+		if ok {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+type groupSolution struct {
+	items    []int
+	weights  []int
+	capacity int
+	best     []float64
+	take     []bitset
+}
+
+// solveGroup runs the classic 0/1 knapsack over one group, recording for
+// each item and capacity whether taking it improved the optimum.
+func solveGroup(items []PlanItem, weights []int, indexes []int, capacity int) *groupSolution {
+	solution := &groupSolution{
+		items:    indexes,
+		weights:  weights,
+		capacity: capacity,
+		best:     make([]float64, capacity+1),
+		take:     make([]bitset, len(indexes)),
+	}
+
+	for k, i := range indexes {
+		solution.take[k] = newBitset(capacity + 1)
+		w := weights[i]
+		for c := capacity; c >= w; c-- {
+			if candidate := solution.best[c-w] + items[i].Value; candidate > solution.best[c] {
+				solution.best[c] = candidate
+				solution.take[k].set(c)
+			}
+		}
+	}
+
+	return solution
+}
+
+func (s *groupSolution) reconstruct(capacity int) []int {
+	var chosen []int
+	c := min(capacity, s.capacity)
+	for k := len(s.items) - 1; k >= 0; k-- {
+		if s.take[k].has(c) {
+			i := s.items[k]
+			chosen = append(chosen, i)
+			c -= s.weights[i]
+		}
+	}
+
+	return chosen
+}
+
+// allocateCapacity splits the shared capacity between the groups so that
+// the sum of their optimal values is maximal. When the caps of the groups
+// add up to no more than the capacity, each group simply gets its cap.
+func allocateCapacity(solutions []*groupSolution, capacity int) []int {
+	allocation := make([]int, len(solutions))
+
+	capped := 0
+	for g, solution := range solutions {
+		allocation[g] = solution.capacity
+		capped += solution.capacity
+	}
+
+	if capped <= capacity {
+		return allocation
+	}
+
+	total := make([]float64, capacity+1)
+	choices := make([][]int32, len(solutions))
+
+	for g, solution := range solutions {
+		next := make([]float64, capacity+1)
+		choices[g] = make([]int32, capacity+1)
+
+		for c := 0; c <= capacity; c++ {
+			bestValue := math.Inf(-1)
+			for k := 0; k <= min(c, solution.capacity); k++ {
+				if value := total[c-k] + solution.best[k]; value > bestValue {
+					bestValue = value
+					choices[g][c] = int32(k)
+				}
+			}
+			next[c] = bestValue
+		}
+
+		total = next
+	}
+
+	c := capacity
+	for g := len(solutions) - 1; g >= 0; g-- {
+		allocation[g] = int(choices[g][c])
+		c -= allocation[g]
+	}
+
+	return allocation
+}
+
+// groupItems splits the eligible items by group, in order of appearance.
+// The groups whose cap does not bind are only limited by the budget, so
+// they are solved together as a single group, the first one.
+func groupItems(items []PlanItem, eligible []int, binds func(group string) bool) [][]int {
+	groups := [][]int{nil}
+	indexes := map[string]int{}
+	for _, i := range eligible {
+		group := items[i].Group
+		if !binds(group) {
+			groups[0] = append(groups[0], i)
+			continue
+		}
+
+		g, ok := indexes[group]
+		if !ok {
+			g = len(groups)
+			indexes[group] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	if len(groups[0]) == 0 {
+		return groups[1:]
+	}
+
+	return groups
+}
+
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(i%64)) != 0
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
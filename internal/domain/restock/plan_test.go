@@ -0,0 +1,92 @@
+package restock
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestOptimizePlanMatchesExhaustiveSearch compares the optimizer with every
+// subset of small plans, some of whose groups are capped. The costs are
+// whole currency units, so no rounding is involved.
+func TestOptimizePlanMatchesExhaustiveSearch(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 11))
+	groups := []string{"oil", "tire", "brake"}
+
+	for run := range 200 {
+		items := make([]PlanItem, 12)
+		for i := range items {
+			items[i] = PlanItem{
+				Group: groups[rng.IntN(len(groups))],
+				Cost:  float64(rng.IntN(40) + 1),
+				Value: float64(rng.IntN(100) + 1),
+			}
+		}
+
+		constraints := PlanConstraints{
+			Budget:    float64(rng.IntN(150) + 1),
+			GroupCaps: map[string]float64{"oil": float64(rng.IntN(60)), "tire": float64(rng.IntN(120))},
+		}
+
+		value := func(selected []int) float64 {
+			total := 0.0
+			spent := map[string]float64{}
+			for _, i := range selected {
+				total += items[i].Value
+				spent[items[i].Group] += items[i].Cost
+				spent[""] += items[i].Cost
+			}
+
+			if spent[""] > constraints.Budget {
+				return -1
+			}
+			for group, limit := range constraints.GroupCaps {
+				if spent[group] > limit {
+					return -1
+				}
+			}
+
+			return total
+		}
+
+		best := 0.0
+		for mask := range 1 << len(items) {
+			var selected []int
+			for i := range items {
+				if mask&(1<<i) != 0 {
+					selected = append(selected, i)
+				}
+			}
+			best = max(best, value(selected))
+		}
+
+		if got := value(OptimizePlan(items, constraints)); got != best {
+			t.Fatalf("run %d: plan value = %v, want %v", run, got, best)
+		}
+	}
+}
+
+// BenchmarkOptimizePlanWithGroupCaps is the worst case of the optimizer:
+// many capped groups and costs whose cents share no divisor, so the budget
+// is split into as many buckets as allowed.
+func BenchmarkOptimizePlanWithGroupCaps(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	constraints := PlanConstraints{Budget: 1_000_000, GroupCaps: map[string]float64{}}
+	var items []PlanItem
+	for g := range 40 {
+		group := fmt.Sprintf("group-%d", g)
+		constraints.GroupCaps[group] = 999_999.99
+		for range 50 {
+			items = append(items, PlanItem{
+				Group: group,
+				Cost:  float64(rng.IntN(5_000_000)+1) / 100,
+				Value: rng.Float64() * 1000,
+			})
+		}
+	}
+
+	for b.Loop() {
+		OptimizePlan(items, constraints)
+	}
+}
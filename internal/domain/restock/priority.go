@@ -0,0 +1,128 @@
+package restock
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+type Priority struct {
+	Projection
+	ProductStock *entities.ProductStock
+}
+
+// Prioritize keeps the products that need a reposition and sorts them by
+// urgency. It is the in-memory counterpart of the repositories able to
+// compute the priorities themselves.
+func Prioritize(products []*entities.ProductStock) []Priority {
+	priorities := []Priority{}
+	for _, p := range products {
+		if projection := Project(p); projection.IsRepositionNeeded {
+			priorities = append(priorities, Priority{
+				Projection:   projection,
+				ProductStock: p,
+			})
+		}
+	}
+
+	SortPriorities(priorities)
+
+	return priorities
+}
+
+func SortPriorities(priorities []Priority) {
+	sort.SliceStable(priorities, func(i, j int) bool {
+		return Less(priorities[i], priorities[j])
+	})
+}
+
+// Less orders by urgency score, then criticality level, then average daily
+// sales, all descending, and finally by name and id.
+func Less(x, y Priority) bool {
+	if x.UrgencyScore != y.UrgencyScore {
+		return x.UrgencyScore > y.UrgencyScore
+	}
+
+	if x.ProductStock.CriticalityLevel != y.ProductStock.CriticalityLevel {
+		return x.ProductStock.CriticalityLevel > y.ProductStock.CriticalityLevel
+	}
+
+	if x.ProductStock.AverageDailySales != y.ProductStock.AverageDailySales {
+		return x.ProductStock.AverageDailySales > y.ProductStock.AverageDailySales
+	}
+
+	xName := strings.ToLower(x.ProductStock.Name)
+	yName := strings.ToLower(y.ProductStock.Name)
+	if xName != yName {
+		return xName < yName
+	}
+
+	return productID(x.ProductStock) < productID(y.ProductStock)
+}
+
+func productID(p *entities.ProductStock) string {
+	if p.ID == nil {
+		return ""
+	}
+
+	return *p.ID
+}
+
+const (
+	CursorScope   = "restock_priorities"
+	SortKeyLength = 5
+)
+
+// SortKey returns the fields Less compares, in order.
+func (p Priority) SortKey() []any {
+	return []any{
+		p.UrgencyScore,
+		int(p.ProductStock.CriticalityLevel),
+		p.ProductStock.AverageDailySales,
+		p.ProductStock.Name,
+		productID(p.ProductStock),
+	}
+}
+
+// PriorityFromSortKey rebuilds a priority carrying only the fields Less
+// compares, to locate a cursor in an ordered list.
+func PriorityFromSortKey(key []any) (Priority, bool) {
+	if len(key) != SortKeyLength {
+		return Priority{}, false
+	}
+
+	urgency, ok1 := key[0].(int64)
+	criticality, ok2 := key[1].(int64)
+	averageDailySales, ok3 := key[2].(int64)
+	name, ok4 := key[3].(string)
+	id, ok5 := key[4].(string)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return Priority{}, false
+	}
+
+	return Priority{
+		Projection: Projection{UrgencyScore: int(urgency)},
+		ProductStock: &entities.ProductStock{
+			ID:                &id,
+			Name:              name,
+			CriticalityLevel:  entities.CriticalityLevel(criticality),
+			AverageDailySales: int(averageDailySales),
+		},
+	}, true
+}
+
+// After returns the part of a list sorted by Less that comes strictly after
+// the priority identified by the sort key.
+func After(sorted []Priority, key []any) ([]Priority, bool) {
+	last, ok := PriorityFromSortKey(key)
+	if !ok {
+		return nil, false
+	}
+
+	i := sort.Search(len(sorted), func(i int) bool {
+		return Less(last, sorted[i])
+	})
+
+	return sorted[i:], true
+}
@@ -0,0 +1,42 @@
+package restock
+
+import "github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+
+// Projection is the deterministic outlook of a product at the moment a
+// replenishment ordered today would arrive.
+type Projection struct {
+	ExpectedConsumption int
+	ProjectedStock      int
+	IsRepositionNeeded  bool
+	UrgencyScore        int
+	SuggestedQuantity   int
+	StockoutUnits       int
+}
+
+func Project(p *entities.ProductStock) Projection {
+	expectedConsumption := p.AverageDailySales * p.LeadTimeDays
+	projectedStock := p.CurrentStock - expectedConsumption
+
+	projection := Projection{
+		ExpectedConsumption: expectedConsumption,
+		ProjectedStock:      projectedStock,
+		IsRepositionNeeded:  projectedStock < p.MinimumStock,
+	}
+
+	if projection.IsRepositionNeeded {
+		projection.UrgencyScore = (p.MinimumStock - projectedStock) * int(p.CriticalityLevel)
+		projection.SuggestedQuantity = p.MinimumStock - projectedStock
+	}
+
+	if projectedStock < 0 {
+		projection.StockoutUnits = -projectedStock
+	}
+
+	return projection
+}
+
+// StockoutCost weights the units that will be missing before replenishment
+// arrives by their cost and by how critical the product is.
+func (pr Projection) StockoutCost(p *entities.ProductStock) float64 {
+	return float64(pr.StockoutUnits) * p.UnitCost * float64(p.CriticalityLevel)
+}
@@ -0,0 +1,90 @@
+package restock
+
+import "github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+
+// SimulationParams describes a what-if run over a product. When DemandCurve
+// is empty the daily demand is the product's AverageDailySales, otherwise
+// the curve is repeated over the simulated days.
+type SimulationParams struct {
+	Product     *entities.ProductStock
+	Days        int
+	DemandCurve []int
+}
+
+type SimulationDay struct {
+	Day      int
+	Demand   int
+	Sold     int
+	Stockout int
+	Received int
+	Ordered  int
+	OnHand   int
+	OnOrder  int
+}
+
+type pendingOrder struct {
+	arrivalDay int
+	quantity   int
+}
+
+// Simulate replays the reorder policy used by the restock priorities day by
+// day: arrivals are received at the start of the day, demand that cannot be
+// served is lost, and at the end of the day an order of the suggested
+// quantity is placed whenever the projection of the inventory position
+// (on hand plus on order) says a reposition is needed.
+func Simulate(params SimulationParams) []SimulationDay {
+	product := *params.Product
+	onHand := product.CurrentStock
+	onOrder := 0
+	var pending []pendingOrder
+
+	series := make([]SimulationDay, params.Days)
+	for day := 1; day <= params.Days; day++ {
+		current := SimulationDay{Day: day}
+
+		remaining := pending[:0]
+		for _, order := range pending {
+			if order.arrivalDay <= day {
+				current.Received += order.quantity
+				continue
+			}
+			remaining = append(remaining, order)
+		}
+		pending = remaining
+		onHand += current.Received
+		onOrder -= current.Received
+
+		current.Demand = demandOn(params, day)
+		current.Sold = min(current.Demand, onHand)
+		current.Stockout = current.Demand - current.Sold
+		onHand -= current.Sold
+
+		product.CurrentStock = onHand + onOrder
+		if projection := Project(&product); projection.IsRepositionNeeded && projection.SuggestedQuantity > 0 {
+			current.Ordered = projection.SuggestedQuantity
+			if product.LeadTimeDays == 0 {
+				onHand += current.Ordered
+			} else {
+				onOrder += current.Ordered
+				pending = append(pending, pendingOrder{
+					arrivalDay: day + product.LeadTimeDays,
+					quantity:   current.Ordered,
+				})
+			}
+		}
+
+		current.OnHand = onHand
+		current.OnOrder = onOrder
+		series[day-1] = current
+	}
+
+	return series
+}
+
+func demandOn(params SimulationParams, day int) int {
+	if len(params.DemandCurve) == 0 {
+		return params.Product.AverageDailySales
+	}
+
+	return params.DemandCurve[(day-1)%len(params.DemandCurve)]
+}
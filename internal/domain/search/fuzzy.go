@@ -0,0 +1,92 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinScore is the relevance below which Score considers a text unrelated.
+const MinScore = 0.5
+
+// Tokenize lowercases the text and splits it into letter and digit runs.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Score is a simple relevance measure used when the repository cannot
+// search by itself. Every query token is matched against its best text
+// token (exact, prefix or within a small edit distance) and the result is
+// the average of those matches, between 0 and 1.
+func Score(query string, texts ...string) float64 {
+	queryTokens := Tokenize(query)
+	if len(queryTokens) == 0 {
+		return 0
+	}
+
+	var textTokens []string
+	for _, text := range texts {
+		textTokens = append(textTokens, Tokenize(text)...)
+	}
+
+	total := 0.0
+	for _, q := range queryTokens {
+		best := 0.0
+		for _, t := range textTokens {
+			best = max(best, tokenScore(q, t))
+		}
+		total += best
+	}
+
+	return total / float64(len(queryTokens))
+}
+
+func tokenScore(query, token string) float64 {
+	switch {
+	case query == token:
+		return 1
+	case strings.HasPrefix(token, query):
+		return 0.9
+	}
+
+	q := []rune(query)
+	t := []rune(token)
+
+	// Compare typos against the same-length prefix of the token too, so
+	// "filtr" is close to "filters" and not only to "filter".
+	distance := levenshtein(q, t)
+	if len(t) > len(q) {
+		distance = min(distance, levenshtein(q, t[:len(q)]))
+	}
+
+	similarity := 1 - float64(distance)/float64(max(len(q), 1))
+	if similarity < 0.6 {
+		return 0
+	}
+
+	return similarity * 0.8
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
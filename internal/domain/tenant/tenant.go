@@ -0,0 +1,33 @@
+package tenant
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+// DefaultID names the tenant of single tenant deployments, which also owns
+// the products created before tenants existed.
+const DefaultID = "default"
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Tenant is a franchisee. Its products are isolated from the other tenants'
+// and it has its own pagination limits and product categories.
+type Tenant struct {
+	ID         string
+	Pagination domain.PaginationConfig
+	Categories []entities.ProductCategory
+}
+
+// IsValidID accepts up to 64 lowercase letters, digits, dashes and
+// underscores.
+func IsValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+func (t Tenant) AllowsCategory(c entities.ProductCategory) bool {
+	return slices.Contains(t.Categories, c)
+}
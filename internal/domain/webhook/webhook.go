@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+)
+
+const (
+	MinSecretLength = 16
+	MaxSecretLength = 256
+
+	// SignatureHeader holds t=<unix time>,v1=<signature>, see Sign.
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// EventTypes are the events a subscription can receive.
+var EventTypes = []event.Type{
+	event.ProductCreated,
+	event.StockChanged,
+	event.ProductDeleted,
+	event.ProductRestored,
+	event.RestockNeeded,
+}
+
+// Subscription asks for the events of the given types of a tenant to be
+// posted to URL, signed with Secret.
+type Subscription struct {
+	ID         *string
+	URL        string
+	EventTypes []event.Type
+	Secret     string
+	CreatedAt  time.Time
+}
+
+func NewSubscription(rawURL string, eventTypes []event.Type, secret string, createdAt time.Time) (*Subscription, *domain.Error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, domain.NewError("url must be an absolute http or https url", domain.ErrBadRequest)
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, domain.NewError("at least one event type is required", domain.ErrBadRequest)
+	}
+
+	var types []event.Type
+	for _, t := range eventTypes {
+		if !slices.Contains(EventTypes, t) {
+			return nil, domain.NewError("invalid event type "+string(t), domain.ErrBadRequest)
+		}
+
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	if len(secret) < MinSecretLength || len(secret) > MaxSecretLength {
+		return nil, domain.NewError("secret must have between "+strconv.Itoa(MinSecretLength)+" and "+strconv.Itoa(MaxSecretLength)+" characters", domain.ErrBadRequest)
+	}
+
+	return &Subscription{
+		URL:        rawURL,
+		EventTypes: types,
+		Secret:     secret,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+func (s Subscription) Wants(t event.Type) bool {
+	return slices.Contains(s.EventTypes, t)
+}
+
+type Status string
+
+const (
+	Pending   Status = "pending"
+	Succeeded Status = "succeeded"
+	// Dead deliveries failed too many times and are only retried when
+	// redelivered by hand.
+	Dead Status = "dead"
+)
+
+func IsValidStatus(s Status) bool {
+	return s == Pending || s == Succeeded || s == Dead
+}
+
+// Delivery is the posting of an event to a subscription, with the outcome
+// of its last attempt. A pending delivery is due at NextAttemptAt.
+type Delivery struct {
+	ID             int64
+	SubscriptionID string
+	Event          event.Event
+	Status         Status
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func NewDelivery(subscriptionID string, e event.Event, createdAt time.Time) Delivery {
+	return Delivery{
+		SubscriptionID: subscriptionID,
+		Event:          e,
+		Status:         Pending,
+		NextAttemptAt:  &createdAt,
+		CreatedAt:      createdAt,
+	}
+}
+
+// Succeed records a successful attempt.
+func (d *Delivery) Succeed(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = Succeeded
+	d.NextAttemptAt = nil
+	d.LastAttemptAt = &at
+	d.LastStatusCode = &statusCode
+	d.LastError = nil
+	d.DeliveredAt = &at
+}
+
+// Fail records a failed attempt. The delivery is retried at nextAttemptAt,
+// or is dead when it is nil. statusCode is nil when there was no response.
+func (d *Delivery) Fail(statusCode *int, reason string, at time.Time, nextAttemptAt *time.Time) {
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.LastStatusCode = statusCode
+	d.LastError = &reason
+	d.NextAttemptAt = nextAttemptAt
+
+	d.Status = Pending
+	if nextAttemptAt == nil {
+		d.Status = Dead
+	}
+}
+
+// Redeliver makes the delivery due again now, with a fresh count of
+// attempts, whatever its status.
+func (d *Delivery) Redeliver(now time.Time) {
+	d.Status = Pending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+}
+
+// Sign returns the signature of a payload sent at the given time: the
+// hex-encoded HMAC-SHA256, keyed by the secret, of "<unix time>.<payload>".
+// Receivers recompute it and compare it in constant time, and should reject
+// old timestamps to defeat replays.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaderValue is the value of SignatureHeader.
+func SignatureHeaderValue(secret string, timestamp time.Time, payload []byte) string {
+	return "t=" + strconv.FormatInt(timestamp.Unix(), 10) + ",v1=" + Sign(secret, timestamp, payload)
+}
+
+// Request is a signed delivery attempt.
+type Request struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// ISender posts the requests. It returns the status code of the response,
+// or an error when there was none.
+type ISender interface {
+	Send(req Request) (int, *domain.Error)
+}
@@ -0,0 +1,24 @@
+package alerts
+
+import (
+	"log"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+)
+
+// LogChannel writes every notification to the standard logger.
+type LogChannel struct{}
+
+func NewLogChannel() *LogChannel {
+	return &LogChannel{}
+}
+
+func (c *LogChannel) Name() string {
+	return "log"
+}
+
+func (c *LogChannel) Send(n alert.Notification) *domain.Error {
+	log.Printf("notification:\n%s", n.Text())
+	return nil
+}
@@ -0,0 +1,60 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/events"
+)
+
+// SlackChannel posts every notification to an incoming webhook as {"text": ...},
+// which Slack and the chat tools compatible with it understand.
+type SlackChannel struct {
+	url    string
+	sender *events.HTTPSender
+}
+
+func NewSlackChannel(rawURL string) (*SlackChannel, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("slack webhook url must be an absolute http or https url")
+	}
+
+	return &SlackChannel{
+		url:    rawURL,
+		sender: events.NewHTTPSender(),
+	}, nil
+}
+
+func (c *SlackChannel) Name() string {
+	return "slack"
+}
+
+func (c *SlackChannel) Send(n alert.Notification) *domain.Error {
+	body, err := json.Marshal(map[string]string{
+		"text": "*" + n.Subject() + "*\n```\n" + n.Text() + "\n```",
+	})
+	if err != nil {
+		return domain.NewError("failed to encode message: "+err.Error(), domain.ErrInternal)
+	}
+
+	statusCode, domainErr := c.sender.Send(webhook.Request{
+		URL:     c.url,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    body,
+	})
+	if domainErr != nil {
+		return domainErr
+	}
+
+	if statusCode < 200 || statusCode > 299 {
+		return domain.NewError("unexpected status "+strconv.Itoa(statusCode), domain.ErrInternal)
+	}
+
+	return nil
+}
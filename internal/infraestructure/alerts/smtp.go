@@ -0,0 +1,151 @@
+package alerts
+
+import (
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+)
+
+const smtpTimeout = 10 * time.Second
+
+// SMTPChannel emails every notification, as plain text, to a fixed list of
+// recipients. The connection is upgraded with STARTTLS when the server
+// offers it, and authenticated when a username is given.
+type SMTPChannel struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func NewSMTPChannel(addr, username, password, from, to string) (*SMTPChannel, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("smtp address must be host:port")
+	}
+
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid smtp sender %q", from)
+	}
+
+	c := &SMTPChannel{
+		addr:     addr,
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+
+	for raw := range strings.SplitSeq(to, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+
+		if _, err := mail.ParseAddress(raw); err != nil {
+			return nil, fmt.Errorf("invalid smtp recipient %q", raw)
+		}
+		c.to = append(c.to, raw)
+	}
+
+	if len(c.to) == 0 {
+		return nil, fmt.Errorf("at least one smtp recipient is required")
+	}
+
+	return c, nil
+}
+
+func (c *SMTPChannel) Name() string {
+	return "email"
+}
+
+func (c *SMTPChannel) Send(n alert.Notification) *domain.Error {
+	if err := c.send(c.message(n)); err != nil {
+		return domain.NewError("failed to send email: "+err.Error(), domain.ErrInternal)
+	}
+
+	return nil
+}
+
+func (c *SMTPChannel) message(n alert.Notification) []byte {
+	headers := []string{
+		"From: " + c.from,
+		"To: " + strings.Join(c.to, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", "[Stock engine] "+n.Subject()),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+
+	body := strings.ReplaceAll(n.Text(), "\n", "\r\n")
+	return []byte(strings.Join(headers, "\r\n") + "\r\n\r\n" + body + "\r\n")
+}
+
+// send is smtp.SendMail with a deadline, so an unresponsive server cannot
+// hold the dispatcher.
+func (c *SMTPChannel) send(msg []byte) error {
+	conn, err := net.DialTimeout("tcp", c.addr, smtpTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+			return err
+		}
+	}
+
+	if c.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.username, c.password, c.host)); err != nil {
+			return err
+		}
+	}
+
+	from, _ := mail.ParseAddress(c.from)
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+
+	for _, raw := range c.to {
+		to, _ := mail.ParseAddress(raw)
+		if err := client.Rcpt(to.Address); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
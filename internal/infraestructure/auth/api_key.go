@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const (
+	apiKeySubjectPrefix = "api-key:"
+	minAPIKeyLength     = 16
+)
+
+// APIKey is a static credential of a machine client. Name identifies the
+// client in the audit log. A key with a Tenant only gives access to it.
+type APIKey struct {
+	Name   string
+	Tenant string
+	Role   auth.Role
+	Key    string
+}
+
+// ParseAPIKeys reads a comma separated list of name:role:key entries. The
+// name may be followed by @tenant to bind the key to a tenant.
+func ParseAPIKeys(raw string) ([]APIKey, error) {
+	var keys []APIKey
+	for entry := range strings.SplitSeq(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("api key entries must be name:role:key")
+		}
+
+		name, tenantID, _ := strings.Cut(parts[0], "@")
+
+		keys = append(keys, APIKey{
+			Name:   name,
+			Tenant: tenantID,
+			Role:   auth.Role(parts[1]),
+			Key:    parts[2],
+		})
+	}
+
+	return keys, nil
+}
+
+// StaticAPIKeyVerifier authenticates a fixed set of API keys. Only their
+// SHA-256 digests are kept.
+type StaticAPIKeyVerifier struct {
+	principals map[[sha256.Size]byte]auth.Principal
+}
+
+func NewStaticAPIKeyVerifier(keys []APIKey) (*StaticAPIKeyVerifier, error) {
+	principals := make(map[[sha256.Size]byte]auth.Principal, len(keys))
+	names := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("api keys must have a name")
+		}
+
+		if names[key.Name] {
+			return nil, fmt.Errorf("duplicate api key name %q", key.Name)
+		}
+		names[key.Name] = true
+
+		if key.Tenant != "" && !tenant.IsValidID(key.Tenant) {
+			return nil, fmt.Errorf("invalid tenant %q for api key %q", key.Tenant, key.Name)
+		}
+
+		if !auth.IsValidRole(key.Role) {
+			return nil, fmt.Errorf("invalid role %q for api key %q", key.Role, key.Name)
+		}
+
+		if len(key.Key) < minAPIKeyLength {
+			return nil, fmt.Errorf("api key %q must have at least %d characters", key.Name, minAPIKeyLength)
+		}
+
+		digest := sha256.Sum256([]byte(key.Key))
+		if _, ok := principals[digest]; ok {
+			return nil, fmt.Errorf("api key %q is already used by another client", key.Name)
+		}
+
+		principals[digest] = auth.Principal{
+			Subject: apiKeySubjectPrefix + key.Name,
+			Role:    key.Role,
+			Tenant:  key.Tenant,
+		}
+	}
+
+	return &StaticAPIKeyVerifier{principals: principals}, nil
+}
+
+func (v *StaticAPIKeyVerifier) VerifyAPIKey(key string) (*auth.Principal, *domain.Error) {
+	principal, ok := v.principals[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, domain.NewError("invalid api key", domain.ErrUnauthorized)
+	}
+
+	return &principal, nil
+}
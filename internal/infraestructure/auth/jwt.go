@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const tokenLeeway = 30 * time.Second
+
+var errUnknownKey = errors.New("no key to verify the token")
+
+type tokenClaims struct {
+	Role   string `json:"role"`
+	Tenant string `json:"tenant"`
+	jwt.RegisteredClaims
+}
+
+// JWTVerifier verifies bearer tokens signed with a shared secret (HS256,
+// HS384, HS512) or with one of the public keys of a local JWKS. Tokens must
+// have an expiry, a subject and a role claim. A tenant claim binds the
+// caller to that tenant.
+type JWTVerifier struct {
+	secret  []byte
+	keys    map[string]crypto.PublicKey
+	options []jwt.ParserOption
+}
+
+func NewJWTVerifier(secret []byte, keys map[string]crypto.PublicKey, issuer, audience string) (*JWTVerifier, error) {
+	var methods []string
+	if len(secret) > 0 {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+
+	for kid, key := range keys {
+		switch key.(type) {
+		case *rsa.PublicKey:
+			methods = append(methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512")
+		case *ecdsa.PublicKey:
+			methods = append(methods, "ES256", "ES384", "ES512")
+		case ed25519.PublicKey:
+			methods = append(methods, "EdDSA")
+		default:
+			return nil, fmt.Errorf("unsupported key type for kid %q", kid)
+		}
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("a secret or a public key is required to verify tokens")
+	}
+
+	slices.Sort(methods)
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(slices.Compact(methods)),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(tokenLeeway),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &JWTVerifier{
+		secret:  secret,
+		keys:    keys,
+		options: options,
+	}, nil
+}
+
+func (v *JWTVerifier) VerifyToken(token string) (*auth.Principal, *domain.Error) {
+	var claims tokenClaims
+	if _, err := jwt.ParseWithClaims(token, &claims, v.verificationKey, v.options...); err != nil {
+		return nil, domain.NewError("invalid bearer token: "+err.Error(), domain.ErrUnauthorized)
+	}
+
+	if claims.Subject == "" {
+		return nil, domain.NewError("bearer token has no subject", domain.ErrUnauthorized)
+	}
+
+	role := auth.Role(claims.Role)
+	if !auth.IsValidRole(role) {
+		return nil, domain.NewError("bearer token has no valid role", domain.ErrForbidden)
+	}
+
+	if claims.Tenant != "" && !tenant.IsValidID(claims.Tenant) {
+		return nil, domain.NewError("bearer token has an invalid tenant", domain.ErrForbidden)
+	}
+
+	return &auth.Principal{
+		Subject: claims.Subject,
+		Role:    role,
+		Tenant:  claims.Tenant,
+	}, nil
+}
+
+// verificationKey picks the key of the token by its kid header. Tokens
+// without a kid are tried against every public key.
+func (v *JWTVerifier) verificationKey(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(v.secret) == 0 {
+			return nil, errUnknownKey
+		}
+
+		return v.secret, nil
+	}
+
+	if kid, ok := token.Header["kid"].(string); ok {
+		key, ok := v.keys[kid]
+		if !ok {
+			return nil, errUnknownKey
+		}
+
+		return key, nil
+	}
+
+	set := jwt.VerificationKeySet{}
+	for _, key := range v.keys {
+		set.Keys = append(set.Keys, key)
+	}
+
+	return set, nil
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+)
+
+// Message is an event as handed to a broker. Key orders the messages of a
+// product on partitioned logs, ID lets brokers discard redeliveries.
+type Message struct {
+	Topic string
+	Key   string
+	ID    string
+	Body  []byte
+}
+
+// IMessagePublisher is the part of a message broker client the broker sink
+// needs. It fits both subject based brokers, like NATS, and partitioned
+// logs, like Kafka. Publish returns once the broker has accepted the
+// message.
+type IMessagePublisher interface {
+	Publish(m Message) error
+	Close()
+}
+
+// BrokerSink publishes every event to the topic <prefix>.<event type>.
+type BrokerSink struct {
+	publisher IMessagePublisher
+	prefix    string
+}
+
+func NewBrokerSink(publisher IMessagePublisher, prefix string) *BrokerSink {
+	return &BrokerSink{publisher: publisher, prefix: prefix}
+}
+
+func (s *BrokerSink) Name() string {
+	return "broker"
+}
+
+func (s *BrokerSink) Publish(e event.Event) *domain.Error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return domain.NewError("failed to encode event: "+err.Error(), domain.ErrInternal)
+	}
+
+	err = s.publisher.Publish(Message{
+		Topic: s.prefix + "." + string(e.Type),
+		Key:   e.ProductID,
+		ID:    strconv.FormatInt(e.ID, 10),
+		Body:  body,
+	})
+	if err != nil {
+		return domain.NewError("failed to publish event: "+err.Error(), domain.ErrInternal)
+	}
+
+	return nil
+}
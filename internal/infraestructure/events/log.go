@@ -0,0 +1,30 @@
+package events
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+)
+
+// LogSink writes every event to the standard logger as a JSON line.
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Publish(e event.Event) *domain.Error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return domain.NewError("failed to encode event: "+err.Error(), domain.ErrInternal)
+	}
+
+	log.Printf("event %s", body)
+	return nil
+}
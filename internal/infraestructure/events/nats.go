@@ -0,0 +1,41 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const natsFlushTimeout = 5 * time.Second
+
+// NatsPublisher publishes to NATS subjects. Each publish is flushed, so it
+// only succeeds once the server received the message. The event ID goes in
+// the Nats-Msg-Id header, which JetStream streams use to drop duplicates.
+type NatsPublisher struct {
+	conn *nats.Conn
+}
+
+func NewNatsPublisher(url string) (*NatsPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("go_stock_engine"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &NatsPublisher{conn: conn}, nil
+}
+
+func (p *NatsPublisher) Publish(m Message) error {
+	msg := nats.NewMsg(m.Topic)
+	msg.Header.Set(nats.MsgIdHdr, m.ID)
+	msg.Data = m.Body
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	return p.conn.FlushTimeout(natsFlushTimeout)
+}
+
+func (p *NatsPublisher) Close() {
+	p.conn.Close()
+}
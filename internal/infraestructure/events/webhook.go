@@ -0,0 +1,108 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+)
+
+const (
+	webhookTimeout   = 10 * time.Second
+	webhookUserAgent = "go_stock_engine-webhooks"
+)
+
+// HTTPSender posts webhook requests, giving up on responses slower than 10
+// seconds. Redirects are not followed, so they count as failures.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPSender) Send(r webhook.Request) (int, *domain.Error) {
+	req, err := http.NewRequest(http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, domain.NewError("failed to build request: "+err.Error(), domain.ErrInternal)
+	}
+
+	req.Header.Set("User-Agent", webhookUserAgent)
+	for name, value := range r.Headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, domain.NewError("request failed: "+err.Error(), domain.ErrInternal)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	return res.StatusCode, nil
+}
+
+// WebhookSink posts every event as JSON to a single URL. Any response but a
+// 2xx is a failure, and the event is posted again later.
+type WebhookSink struct {
+	url    string
+	sender *HTTPSender
+}
+
+func NewWebhookSink(rawURL string) (*WebhookSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook url must be an absolute http or https url")
+	}
+
+	return &WebhookSink{
+		url:    rawURL,
+		sender: NewHTTPSender(),
+	}, nil
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Publish(e event.Event) *domain.Error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return domain.NewError("failed to encode event: "+err.Error(), domain.ErrInternal)
+	}
+
+	statusCode, domainErr := s.sender.Send(webhook.Request{
+		URL: s.url,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+			"X-Event-ID":   strconv.FormatInt(e.ID, 10),
+			"X-Event-Type": string(e.Type),
+		},
+		Body: body,
+	})
+	if domainErr != nil {
+		return domainErr
+	}
+
+	if statusCode < 200 || statusCode > 299 {
+		return domain.NewError("unexpected status "+strconv.Itoa(statusCode), domain.ErrInternal)
+	}
+
+	return nil
+}
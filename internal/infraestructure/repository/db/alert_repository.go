@@ -0,0 +1,237 @@
+package db
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AlertRuleModel struct {
+	ID              string       `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID        string       `gorm:"type:varchar(64);not null;default:'default';index"`
+	Name            string       `gorm:"type:varchar(100);not null"`
+	Condition       string       `gorm:"type:varchar(32);not null"`
+	MinCriticality  int          `gorm:"not null;default:0"`
+	Categories      []string     `gorm:"type:jsonb;serializer:json;not null"`
+	Channels        []string     `gorm:"type:jsonb;serializer:json;not null"`
+	CooldownSeconds int64        `gorm:"not null"`
+	CreatedAt       time.Time    `gorm:"not null"`
+	Alerts          []AlertModel `gorm:"foreignKey:RuleID;constraint:OnDelete:CASCADE"`
+}
+
+// AlertModel keeps the product as it was when the alert was raised. A rule
+// raises at most one alert per event.
+type AlertModel struct {
+	ID             int64         `gorm:"primaryKey;autoIncrement"`
+	TenantID       string        `gorm:"type:varchar(64);not null;default:'default';index"`
+	RuleID         string        `gorm:"type:uuid;not null;uniqueIndex:idx_alert_models_rule_event,priority:1;index:idx_alert_models_rule_product,priority:1"`
+	EventID        int64         `gorm:"not null;uniqueIndex:idx_alert_models_rule_event,priority:2"`
+	ProductID      string        `gorm:"type:varchar(64);not null;index:idx_alert_models_rule_product,priority:2"`
+	RuleName       string        `gorm:"type:varchar(100);not null"`
+	Condition      string        `gorm:"type:varchar(32);not null"`
+	Product        event.Product `gorm:"type:jsonb;serializer:json;not null"`
+	ProjectedStock int           `gorm:"not null"`
+	Status         string        `gorm:"type:varchar(16);not null"`
+	LastError      *string       `gorm:"type:text"`
+	TriggeredAt    time.Time     `gorm:"not null"`
+	SentAt         *time.Time
+}
+
+// alertColumns orders the alerts newest first.
+var alertColumns = []keysetColumn{{Expr: "id", Descending: true}}
+
+func (r *ProductStockRepository) CreateAlertRule(rule *alert.Rule) (string, *domain.Error) {
+	model := &AlertRuleModel{
+		TenantID:        r.tenantID,
+		Name:            rule.Name,
+		Condition:       string(rule.Condition),
+		MinCriticality:  int(rule.MinCriticality),
+		Categories:      make([]string, len(rule.Categories)),
+		Channels:        rule.Channels,
+		CooldownSeconds: int64(rule.Cooldown / time.Second),
+		CreatedAt:       rule.CreatedAt,
+	}
+
+	for i, c := range rule.Categories {
+		model.Categories[i] = string(c)
+	}
+
+	if err := r.db.Omit(clause.Associations).Create(model).Error; err != nil {
+		return "", r.dbErrMapper.MapErrorToDomain(err, "failed to create alert rule")
+	}
+
+	return model.ID, nil
+}
+
+func (r *ProductStockRepository) GetAlertRules() ([]alert.Rule, *domain.Error) {
+	var models []AlertRuleModel
+
+	if err := r.db.Order("created_at, id").Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list alert rules")
+	}
+
+	result := make([]alert.Rule, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) DeleteAlertRule(id string) *domain.Error {
+	result := r.db.Delete(&AlertRuleModel{}, "id = ?", id)
+	if result.Error != nil {
+		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to delete alert rule")
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.NewError("alert rule not found", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) CreateAlert(a *alert.Alert) (int64, *domain.Error) {
+	model := mapAlertToModel(a)
+	model.TenantID = r.tenantID
+
+	if err := r.db.Create(&model).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to record alert")
+	}
+
+	return model.ID, nil
+}
+
+func (r *ProductStockRepository) UpdateAlert(a *alert.Alert) *domain.Error {
+	result := r.db.Model(&AlertModel{}).Where("id = ?", a.ID).Updates(map[string]any{
+		"status":     string(a.Status),
+		"last_error": a.LastError,
+		"sent_at":    a.SentAt,
+	})
+	if result.Error != nil {
+		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to update alert")
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.NewError("alert not found", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// LockAlerts takes a transaction level advisory lock, keyed by a hash of the
+// rule and the product like the job locks.
+func (r *ProductStockRepository) LockAlerts(ruleID, productID string) *domain.Error {
+	if err := r.db.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey("alert:"+ruleID+":"+productID)).Error; err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to lock alerts")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) GetLastAlert(ruleID, productID string) (*alert.Alert, *domain.Error) {
+	var model AlertModel
+
+	err := r.db.Where("rule_id = ? AND product_id = ?", ruleID, productID).Order("id DESC").Take(&model).Error
+	if err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get last alert")
+	}
+
+	a := model.ToDomain()
+	return &a, nil
+}
+
+func (r *ProductStockRepository) GetAlerts(ruleID string, pagination *domain.Pagination) ([]alert.Alert, *domain.Error) {
+	var models []AlertModel
+
+	query := applyOrderAndPagination(applyAlertQuery(r.db.Model(&AlertModel{}), ruleID), alertColumns, pagination)
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list alerts")
+	}
+
+	result := make([]alert.Alert, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) CountAlerts(ruleID string) (int, *domain.Error) {
+	var count int64
+
+	if err := applyAlertQuery(r.db.Model(&AlertModel{}), ruleID).Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count alerts")
+	}
+
+	return int(count), nil
+}
+
+func applyAlertQuery(query *gorm.DB, ruleID string) *gorm.DB {
+	if ruleID != "" {
+		query = query.Where("rule_id = ?", ruleID)
+	}
+
+	return query
+}
+
+func (m AlertRuleModel) ToDomain() alert.Rule {
+	id := m.ID
+
+	rule := alert.Rule{
+		ID:             &id,
+		Name:           m.Name,
+		Condition:      alert.Condition(m.Condition),
+		MinCriticality: entities.CriticalityLevel(m.MinCriticality),
+		Categories:     make([]entities.ProductCategory, len(m.Categories)),
+		Channels:       m.Channels,
+		Cooldown:       time.Duration(m.CooldownSeconds) * time.Second,
+		CreatedAt:      m.CreatedAt,
+	}
+
+	for i, c := range m.Categories {
+		rule.Categories[i] = entities.ProductCategory(c)
+	}
+
+	return rule
+}
+
+func mapAlertToModel(a *alert.Alert) AlertModel {
+	return AlertModel{
+		ID:             a.ID,
+		RuleID:         a.RuleID,
+		EventID:        a.EventID,
+		ProductID:      a.ProductID,
+		RuleName:       a.RuleName,
+		Condition:      string(a.Condition),
+		Product:        a.Product,
+		ProjectedStock: a.ProjectedStock,
+		Status:         string(a.Status),
+		LastError:      a.LastError,
+		TriggeredAt:    a.TriggeredAt,
+		SentAt:         a.SentAt,
+	}
+}
+
+func (m AlertModel) ToDomain() alert.Alert {
+	return alert.Alert{
+		ID:             m.ID,
+		RuleID:         m.RuleID,
+		RuleName:       m.RuleName,
+		Condition:      alert.Condition(m.Condition),
+		EventID:        m.EventID,
+		ProductID:      m.ProductID,
+		Product:        m.Product,
+		ProjectedStock: m.ProjectedStock,
+		Status:         alert.Status(m.Status),
+		LastError:      m.LastError,
+		TriggeredAt:    m.TriggeredAt,
+		SentAt:         m.SentAt,
+	}
+}
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type AuditEntryModel struct {
+	ID         int64              `gorm:"primaryKey;autoIncrement"`
+	TenantID   string             `gorm:"type:varchar(64);not null;default:'default';index"`
+	Actor      string             `gorm:"type:text;not null;index"`
+	Operation  string             `gorm:"type:varchar(32);not null"`
+	EntityType string             `gorm:"type:varchar(64);not null"`
+	EntityID   string             `gorm:"type:varchar(64);not null;index"`
+	Changes    []auditChangeModel `gorm:"type:jsonb;serializer:json;not null"`
+	OccurredAt time.Time          `gorm:"not null;index"`
+}
+
+type auditChangeModel struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// auditEntryColumns orders the log newest first. The id grows with every
+// entry, so it alone is a total order.
+var auditEntryColumns = []keysetColumn{{Expr: "id", Descending: true}}
+
+func (r *ProductStockRepository) AppendAuditEntries(entries []audit.Entry) *domain.Error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	models := make([]AuditEntryModel, len(entries))
+	for i, entry := range entries {
+		models[i] = AuditEntryModel{
+			TenantID:   r.tenantID,
+			Actor:      entry.Actor,
+			Operation:  string(entry.Operation),
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Changes:    make([]auditChangeModel, len(entry.Changes)),
+			OccurredAt: entry.OccurredAt,
+		}
+
+		for j, change := range entry.Changes {
+			models[i].Changes[j] = auditChangeModel(change)
+		}
+	}
+
+	if err := r.db.Create(&models).Error; err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to write audit log")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) GetAuditEntries(q repository.AuditQuery, pagination *domain.Pagination) ([]audit.Entry, *domain.Error) {
+	var models []AuditEntryModel
+
+	query := applyOrderAndPagination(applyAuditQuery(r.db.Model(&AuditEntryModel{}), q), auditEntryColumns, pagination)
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list audit log")
+	}
+
+	result := make([]audit.Entry, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+// GetStockOutflows unnests the changes of the updates since from to find the
+// decreases of the current stock, so only the entries of the window are
+// read, and looks up the oldest entry of each product on its own through
+// idx_audit_entry_models_entity_occurred_at. The values are cast only for
+// the current stock, as Postgres may evaluate the conditions in any order.
+func (r *ProductStockRepository) GetStockOutflows(from time.Time) ([]repository.StockOutflow, *domain.Error) {
+	var rows []repository.StockOutflow
+
+	outflows := r.db.Model(&AuditEntryModel{}).
+		Select("entity_id, SUM(GREATEST(CASE WHEN c->>'field' = 'current_stock' THEN (c->>'before')::int - (c->>'after')::int END, 0)) AS quantity").
+		Joins("CROSS JOIN LATERAL jsonb_array_elements(changes) AS c").
+		Where("entity_type = ? AND operation = ? AND occurred_at >= ?", audit.ProductStockEntity, string(audit.Update), from).
+		Group("entity_id")
+
+	err := r.db.Model(&ProductStockModel{}).
+		Select("product_stock_models.id AS product_id, product_stock_models.average_daily_sales, first_entry.occurred_at AS first_recorded_at, COALESCE(outflow.quantity, 0) AS quantity").
+		Joins(`JOIN LATERAL (
+			SELECT occurred_at FROM audit_entry_models
+			WHERE entity_type = ? AND entity_id = product_stock_models.id::text
+			ORDER BY occurred_at
+			LIMIT 1
+		) AS first_entry ON true`, audit.ProductStockEntity).
+		Joins("LEFT JOIN (?) AS outflow ON outflow.entity_id = product_stock_models.id::text", outflows).
+		Order("product_stock_models.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to sum stock outflows")
+	}
+
+	return rows, nil
+}
+
+// GetLatestAuditEntries ranks the entries of each entity, newest first, and
+// keeps the first limit of every one.
+func (r *ProductStockRepository) GetLatestAuditEntries(entityIDs []string, limit int) (map[string][]audit.Entry, *domain.Error) {
+	result := make(map[string][]audit.Entry, len(entityIDs))
+	if len(entityIDs) == 0 {
+		return result, nil
+	}
+
+	var models []AuditEntryModel
+
+	ranked := r.db.Model(&AuditEntryModel{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY entity_id ORDER BY id DESC) AS entry_rank").
+		Where("entity_id IN ?", entityIDs)
+
+	query := r.db.Table("(?) AS ranked", ranked).
+		Where("entry_rank <= ?", limit).
+		Order("entity_id, id DESC")
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list audit log")
+	}
+
+	for _, model := range models {
+		result[model.EntityID] = append(result[model.EntityID], model.ToDomain())
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) CountAuditEntries(q repository.AuditQuery) (int, *domain.Error) {
+	var count int64
+
+	if err := applyAuditQuery(r.db.Model(&AuditEntryModel{}), q).Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count audit log")
+	}
+
+	return int(count), nil
+}
+
+func (m AuditEntryModel) ToDomain() audit.Entry {
+	entry := audit.Entry{
+		ID:         m.ID,
+		Actor:      m.Actor,
+		Operation:  audit.Operation(m.Operation),
+		EntityType: m.EntityType,
+		EntityID:   m.EntityID,
+		Changes:    make([]audit.FieldChange, len(m.Changes)),
+		OccurredAt: m.OccurredAt,
+	}
+
+	for i, change := range m.Changes {
+		entry.Changes[i] = audit.FieldChange(change)
+	}
+
+	return entry
+}
+
+func applyAuditQuery(query *gorm.DB, q repository.AuditQuery) *gorm.DB {
+	if q.EntityID != "" {
+		query = query.Where("entity_id = ?", q.EntityID)
+	}
+
+	if q.Actor != "" {
+		query = query.Where("actor = ?", q.Actor)
+	}
+
+	if q.From != nil {
+		query = query.Where("occurred_at >= ?", *q.From)
+	}
+
+	if q.To != nil {
+		query = query.Where("occurred_at < ?", *q.To)
+	}
+
+	return query
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyModel has no response while its request is being processed.
+// Keys are unique per tenant and actor.
+type IdempotencyKeyModel struct {
+	TenantID    string `gorm:"type:varchar(64);primaryKey;not null;default:'default'"`
+	Actor       string `gorm:"type:text;primaryKey;not null;default:''"`
+	Key         string `gorm:"type:varchar(255);primaryKey"`
+	Fingerprint string `gorm:"type:char(64);not null"`
+	StatusCode  *int
+	ContentType *string `gorm:"type:varchar(255)"`
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (m *IdempotencyKeyModel) ToDomain() *repository.IdempotencyKey {
+	key := &repository.IdempotencyKey{
+		IdempotencyKeyID: repository.IdempotencyKeyID{
+			TenantID: m.TenantID,
+			Actor:    m.Actor,
+			Key:      m.Key,
+		},
+		Fingerprint: m.Fingerprint,
+		ExpiresAt:   m.ExpiresAt,
+	}
+
+	if m.StatusCode != nil {
+		key.Response = &repository.IdempotentResponse{
+			StatusCode: *m.StatusCode,
+			Body:       m.Body,
+		}
+
+		if m.ContentType != nil {
+			key.Response.ContentType = *m.ContentType
+		}
+	}
+
+	return key
+}
+
+func idempotencyKeyCondition(id repository.IdempotencyKeyID) map[string]any {
+	return map[string]any{"tenant_id": id.TenantID, "actor": id.Actor, "key": id.Key}
+}
+
+// ReserveIdempotencyKey inserts the key, taking over an expired record of it
+// in the same statement. When nothing was written the key is taken and its
+// record is returned.
+func (r *ProductStockRepository) ReserveIdempotencyKey(key repository.IdempotencyKey, now time.Time) (*repository.IdempotencyKey, *domain.Error) {
+	model := &IdempotencyKeyModel{
+		TenantID:    key.TenantID,
+		Actor:       key.Actor,
+		Key:         key.Key,
+		Fingerprint: key.Fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   key.ExpiresAt,
+	}
+
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "actor"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "status_code", "content_type", "body", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lte{Column: clause.Column{Table: "idempotency_key_models", Name: "expires_at"}, Value: now},
+		}},
+	}).Create(model)
+	if result.Error != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(result.Error, "failed to reserve idempotency key")
+	}
+
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing IdempotencyKeyModel
+	if err := r.db.Where(idempotencyKeyCondition(key.IdempotencyKeyID)).First(&existing).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get idempotency key")
+	}
+
+	return existing.ToDomain(), nil
+}
+
+func (r *ProductStockRepository) CompleteIdempotencyKey(id repository.IdempotencyKeyID, response repository.IdempotentResponse) *domain.Error {
+	err := r.db.Model(&IdempotencyKeyModel{}).Where(idempotencyKeyCondition(id)).Updates(map[string]any{
+		"status_code":  response.StatusCode,
+		"content_type": response.ContentType,
+		"body":         response.Body,
+	}).Error
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to store idempotent response")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) ReleaseIdempotencyKey(id repository.IdempotencyKeyID) *domain.Error {
+	if err := r.db.Where(idempotencyKeyCondition(id)).Delete(&IdempotencyKeyModel{}).Error; err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to release idempotency key")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) DeleteExpiredIdempotencyKeys(now time.Time) (int, *domain.Error) {
+	result := r.db.Delete(&IdempotencyKeyModel{}, "expires_at <= ?", now)
+	if result.Error != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(result.Error, "failed to delete expired idempotency keys")
+	}
+
+	return int(result.RowsAffected), nil
+}
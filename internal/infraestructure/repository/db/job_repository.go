@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"hash/fnv"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
+	"gorm.io/gorm"
+)
+
+type JobRunModel struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement;index:idx_job_run_models_job_id,priority:2"`
+	Job        string    `gorm:"type:varchar(64);not null;index:idx_job_run_models_job_id,priority:1"`
+	Instance   string    `gorm:"type:varchar(255);not null"`
+	Status     string    `gorm:"type:varchar(16);not null"`
+	Result     *string   `gorm:"type:text"`
+	Error      *string   `gorm:"type:text"`
+	StartedAt  time.Time `gorm:"not null"`
+	FinishedAt *time.Time
+}
+
+// jobRunColumns orders the runs newest first.
+var jobRunColumns = []keysetColumn{{Expr: "id", Descending: true}}
+
+// TryJobLock takes a session level advisory lock, keyed by a hash of the
+// name, on a connection set aside until unlock. Should the connection drop,
+// Postgres releases the lock along with it.
+func (r *ProductStockRepository) TryJobLock(name string) (func(), bool, *domain.Error) {
+	ctx := context.Background()
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return nil, false, r.dbErrMapper.MapErrorToDomain(err, "failed to lock job")
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, r.dbErrMapper.MapErrorToDomain(err, "failed to lock job")
+	}
+
+	key := advisoryLockKey("job:" + name)
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, false, r.dbErrMapper.MapErrorToDomain(err, "failed to lock job")
+	}
+
+	if !locked {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", key); err != nil {
+			// The lock may still be held: drop the connection rather than
+			// hand it back to the pool.
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}, true, nil
+}
+
+// advisoryLockKey hashes the name of an advisory lock, prefixed by what it
+// guards, into the key Postgres takes.
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+func (r *ProductStockRepository) CreateJobRun(run *job.Run) (int64, *domain.Error) {
+	model := mapJobRunToModel(run)
+
+	if err := r.db.Create(&model).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to record job run")
+	}
+
+	return model.ID, nil
+}
+
+func (r *ProductStockRepository) UpdateJobRun(run *job.Run) *domain.Error {
+	result := r.db.Model(&JobRunModel{}).Where("id = ?", run.ID).Updates(map[string]any{
+		"status":      string(run.Status),
+		"result":      run.Result,
+		"error":       run.Error,
+		"finished_at": run.FinishedAt,
+	})
+	if result.Error != nil {
+		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to update job run")
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.NewError("job run not found", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) GetLastJobRun(name string) (*job.Run, *domain.Error) {
+	var model JobRunModel
+
+	if err := r.db.Where("job = ?", name).Order("id DESC").Take(&model).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get last job run")
+	}
+
+	run := model.ToDomain()
+	return &run, nil
+}
+
+func (r *ProductStockRepository) GetJobRuns(name string, pagination *domain.Pagination) ([]job.Run, *domain.Error) {
+	var models []JobRunModel
+
+	query := applyOrderAndPagination(applyJobRunQuery(r.db.Model(&JobRunModel{}), name), jobRunColumns, pagination)
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list job runs")
+	}
+
+	result := make([]job.Run, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) CountJobRuns(name string) (int, *domain.Error) {
+	var count int64
+
+	if err := applyJobRunQuery(r.db.Model(&JobRunModel{}), name).Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count job runs")
+	}
+
+	return int(count), nil
+}
+
+func (r *ProductStockRepository) PurgeJobRuns(before time.Time) (int, *domain.Error) {
+	result := r.db.Where("finished_at < ?", before).Delete(&JobRunModel{})
+	if result.Error != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(result.Error, "failed to purge job runs")
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func applyJobRunQuery(query *gorm.DB, name string) *gorm.DB {
+	if name != "" {
+		query = query.Where("job = ?", name)
+	}
+
+	return query
+}
+
+func mapJobRunToModel(run *job.Run) JobRunModel {
+	return JobRunModel{
+		ID:         run.ID,
+		Job:        run.Job,
+		Instance:   run.Instance,
+		Status:     string(run.Status),
+		Result:     run.Result,
+		Error:      run.Error,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+	}
+}
+
+func (m JobRunModel) ToDomain() job.Run {
+	return job.Run{
+		ID:         m.ID,
+		Job:        m.Job,
+		Instance:   m.Instance,
+		Status:     job.Status(m.Status),
+		Result:     m.Result,
+		Error:      m.Error,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+	}
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// keysetColumn is an ordering expression. Param wraps the placeholder the
+// same way the column is wrapped, e.g. LOWER(name) > LOWER(?).
+type keysetColumn struct {
+	Expr       string
+	Param      string
+	Descending bool
+}
+
+// applyKeyset restricts the query to the rows strictly after the given key
+// in the order described by columns, expanding the row comparison into
+// (c1 > v1) OR (c1 = v1 AND c2 > v2) OR ... so each column can have its
+// own direction.
+func applyKeyset(query *gorm.DB, columns []keysetColumn, key []any) *gorm.DB {
+	var disjuncts []string
+	var args []any
+
+	for i, column := range columns {
+		var conjuncts []string
+		for j, previous := range columns[:i] {
+			conjuncts = append(conjuncts, previous.Expr+" = "+previous.param())
+			args = append(args, key[j])
+		}
+
+		operator := " > "
+		if column.Descending {
+			operator = " < "
+		}
+		conjuncts = append(conjuncts, column.Expr+operator+column.param())
+		args = append(args, key[i])
+
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+	}
+
+	return query.Where("("+strings.Join(disjuncts, " OR ")+")", args...)
+}
+
+func (c keysetColumn) param() string {
+	if c.Param == "" {
+		return "?"
+	}
+
+	return c.Param
+}
@@ -2,10 +2,14 @@ package db
 
 import (
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"gorm.io/gorm"
 )
 
+// ProductStockModel belongs to a tenant. The SKU is unique within the
+// tenant only.
 type ProductStockModel struct {
 	ID                string  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID          string  `gorm:"type:varchar(64);not null;default:'default';uniqueIndex:idx_product_stock_models_tenant_sku,priority:1"`
 	Name              string  `gorm:"type:varchar(255);not null"`
 	Category          string  `gorm:"type:varchar(100);not null"`
 	CurrentStock      int     `gorm:"not null"`
@@ -14,11 +18,36 @@ type ProductStockModel struct {
 	LeadTimeDays      int     `gorm:"not null"`
 	UnitCost          float64 `gorm:"type:numeric(10,2);not null"`
 	CriticalityLevel  int     `gorm:"not null"`
+
+	SKU         *string               `gorm:"type:varchar(64);uniqueIndex:idx_product_stock_models_tenant_sku,priority:2"`
+	ExternalIDs map[string]string     `gorm:"type:jsonb;serializer:json;not null;default:'{}'"`
+	Barcodes    []ProductBarcodeModel `gorm:"foreignKey:ProductStockID;constraint:OnDelete:CASCADE"`
+
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// ProductBarcodeModel has the tenant and the barcode as primary key, so a
+// barcode belongs to at most one product of a tenant.
+type ProductBarcodeModel struct {
+	TenantID       string `gorm:"type:varchar(64);primaryKey;not null;default:'default'"`
+	Barcode        string `gorm:"type:char(13);primaryKey"`
+	ProductStockID string `gorm:"type:uuid;not null;index"`
 }
 
 func (m *ProductStockModel) ToDomain() *entities.ProductStock {
 	id := m.ID
-	return &entities.ProductStock{
+
+	barcodes := make([]string, len(m.Barcodes))
+	for i, barcode := range m.Barcodes {
+		barcodes[i] = barcode.Barcode
+	}
+
+	externalIDs := m.ExternalIDs
+	if externalIDs == nil {
+		externalIDs = map[string]string{}
+	}
+
+	product := &entities.ProductStock{
 		ID:                &id,
 		Name:              m.Name,
 		Category:          entities.ProductCategory(m.Category),
@@ -28,7 +57,19 @@ func (m *ProductStockModel) ToDomain() *entities.ProductStock {
 		LeadTimeDays:      m.LeadTimeDays,
 		UnitCost:          m.UnitCost,
 		CriticalityLevel:  entities.CriticalityLevel(m.CriticalityLevel),
+		Identifiers: entities.ProductIdentifiers{
+			SKU:         m.SKU,
+			Barcodes:    barcodes,
+			ExternalIDs: externalIDs,
+		},
 	}
+
+	if m.DeletedAt.Valid {
+		deletedAt := m.DeletedAt.Time
+		product.DeletedAt = &deletedAt
+	}
+
+	return product
 }
 
 func MapProductStockToModel(e *entities.ProductStock) *ProductStockModel {
@@ -41,11 +82,28 @@ func MapProductStockToModel(e *entities.ProductStock) *ProductStockModel {
 		LeadTimeDays:      e.LeadTimeDays,
 		UnitCost:          e.UnitCost,
 		CriticalityLevel:  int(e.CriticalityLevel),
+		SKU:               e.Identifiers.SKU,
+		ExternalIDs:       e.Identifiers.ExternalIDs,
 	}
 
 	if e.ID != nil {
 		model.ID = *e.ID
 	}
 
+	if e.DeletedAt != nil {
+		model.DeletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	if model.ExternalIDs == nil {
+		model.ExternalIDs = map[string]string{}
+	}
+
+	for _, barcode := range e.Identifiers.Barcodes {
+		model.Barcodes = append(model.Barcodes, ProductBarcodeModel{
+			Barcode:        barcode,
+			ProductStockID: model.ID,
+		})
+	}
+
 	return model
 }
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxEventModel is due while it is not dispatched and its next attempt
+// is not in the future. Claiming it moves the next attempt to the end of
+// the lease; an event given up on has no next attempt.
+type OutboxEventModel struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement"`
+	TenantID      string     `gorm:"type:varchar(64);not null;default:'default'"`
+	Type          string     `gorm:"type:varchar(64);not null"`
+	ProductID     string     `gorm:"type:varchar(64);not null"`
+	Actor         string     `gorm:"type:text;not null"`
+	Data          event.Data `gorm:"type:jsonb;serializer:json;not null"`
+	OccurredAt    time.Time  `gorm:"not null"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time
+	LastError     *string    `gorm:"type:text"`
+	DispatchedAt  *time.Time `gorm:"index"`
+}
+
+func (r *ProductStockRepository) AppendOutboxEvents(events []event.Event) *domain.Error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	models := make([]OutboxEventModel, len(events))
+	for i, e := range events {
+		occurredAt := e.OccurredAt
+		models[i] = OutboxEventModel{
+			TenantID:      r.tenantID,
+			Type:          string(e.Type),
+			ProductID:     e.ProductID,
+			Actor:         e.Actor,
+			Data:          e.Data,
+			OccurredAt:    e.OccurredAt,
+			NextAttemptAt: &occurredAt,
+		}
+	}
+
+	if err := r.db.Create(&models).Error; err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to write outbox")
+	}
+
+	return nil
+}
+
+// ClaimOutboxEvents locks the due rows with SKIP LOCKED, so a dispatcher
+// never waits for the rows another one is claiming.
+func (r *ProductStockRepository) ClaimOutboxEvents(limit int, now, leaseUntil time.Time) ([]repository.OutboxEvent, *domain.Error) {
+	var models []OutboxEventModel
+
+	due := r.db.Model(&OutboxEventModel{}).
+		Select("id").
+		Where("dispatched_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	err := r.db.Model(&models).
+		Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Update("next_attempt_at", leaseUntil).Error
+	if err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to claim outbox events")
+	}
+
+	slices.SortFunc(models, func(a, b OutboxEventModel) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	result := make([]repository.OutboxEvent, len(models))
+	for i, model := range models {
+		result[i] = repository.OutboxEvent{Event: model.ToDomain(), Attempts: model.Attempts}
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) MarkOutboxEventDispatched(id int64, dispatchedAt time.Time) *domain.Error {
+	err := r.db.Model(&OutboxEventModel{}).Where("id = ?", id).Updates(map[string]any{
+		"dispatched_at": dispatchedAt,
+		"last_error":    nil,
+	}).Error
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to mark outbox event as dispatched")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) RetryOutboxEvent(id int64, attempts int, nextAttemptAt *time.Time, lastError string) *domain.Error {
+	err := r.db.Model(&OutboxEventModel{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	}).Error
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to reschedule outbox event")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) PurgeDispatchedOutboxEvents(dispatchedBefore time.Time) (int, *domain.Error) {
+	result := r.db.Delete(&OutboxEventModel{}, "dispatched_at <= ?", dispatchedBefore)
+	if result.Error != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(result.Error, "failed to purge outbox")
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *ProductStockRepository) GetOutboxEvents(afterID int64, ids []int64, limit int) ([]event.Event, *domain.Error) {
+	var models []OutboxEventModel
+
+	// The conditions are grouped, apart from the tenant one.
+	cond := r.db.Session(&gorm.Session{NewDB: true}).Where("id > ?", afterID)
+	if len(ids) > 0 {
+		cond = cond.Or("id IN ?", ids)
+	}
+
+	if err := r.db.Where(cond).Order("id").Limit(limit).Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to read outbox")
+	}
+
+	result := make([]event.Event, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) GetLastOutboxEventID() (int64, *domain.Error) {
+	var id int64
+
+	if err := r.db.Model(&OutboxEventModel{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to read outbox")
+	}
+
+	return id, nil
+}
+
+func (m OutboxEventModel) ToDomain() event.Event {
+	return event.Event{
+		ID:         m.ID,
+		Type:       event.Type(m.Type),
+		TenantID:   m.TenantID,
+		ProductID:  m.ProductID,
+		Actor:      m.Actor,
+		OccurredAt: m.OccurredAt,
+		Data:       m.Data,
+	}
+}
@@ -33,7 +33,11 @@ func NewPostgresConnection() *gorm.DB {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 
-	if err := conn.AutoMigrate(&db.ProductStockModel{}); err != nil {
+	if err := conn.AutoMigrate(&db.ProductStockModel{}, &db.ProductBarcodeModel{}, &db.IdempotencyKeyModel{}, &db.AuditEntryModel{}, &db.OutboxEventModel{}, &db.WebhookSubscriptionModel{}, &db.WebhookDeliveryModel{}, &db.AlertRuleModel{}, &db.AlertModel{}, &db.JobRunModel{}, &db.RestockPrioritySnapshotModel{}, &db.RestockPrioritySnapshotStateModel{}); err != nil {
+		log.Fatalf("failed to run migrations: %v", err)
+	}
+
+	if err := runMigrations(conn); err != nil {
 		log.Fatalf("failed to run migrations: %v", err)
 	}
 
@@ -1,10 +1,13 @@
 package postgres
 
 import (
+	"errors"
+	"regexp"
 	"strings"
 
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
 )
 
 type PostgresErrMapper struct {
@@ -25,11 +28,32 @@ const (
 	pgInvalidTextRepresentation = "22P02"
 )
 
+// uniqueViolationDetail matches the detail of a unique violation, e.g.
+// `Key (sku)=(ABC-1) already exists.` Keys unique per tenant start with the
+// tenant, which tenantUniqueViolationDetail leaves out.
+var (
+	uniqueViolationDetail       = regexp.MustCompile(`^Key \((.+)\)=\((.*)\) already exists`)
+	tenantUniqueViolationDetail = regexp.MustCompile(`^Key \(tenant_id, (.+)\)=\([^,]*, (.*)\) already exists`)
+)
+
 func (errMapper *PostgresErrMapper) MapErrorToDomain(err error, context string) *domain.Error {
-	pgErr, _ := err.(*pgconn.PgError)
-	if pgErr != nil {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.NewError(context+": not found", domain.ErrNotFound)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case pgUniqueViolation:
+			match := tenantUniqueViolationDetail.FindStringSubmatch(pgErr.Detail)
+			if match == nil {
+				match = uniqueViolationDetail.FindStringSubmatch(pgErr.Detail)
+			}
+
+			if match != nil {
+				return domain.NewError(context+": "+match[1]+" '"+match[2]+"' already in use", domain.ErrConflict)
+			}
+
 			field := "field"
 			parts := strings.Split(pgErr.ConstraintName, "_")
 			if len(parts) >= 2 {
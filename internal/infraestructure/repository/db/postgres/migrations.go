@@ -0,0 +1,93 @@
+package postgres
+
+import "gorm.io/gorm"
+
+// migrations holds the schema objects AutoMigrate cannot express. Every
+// statement must be idempotent, since they run on every start.
+var migrations = []string{
+	// Partial indexes matching the ORDER BY of the restock priorities of a
+	// tenant and of their snapshot, so a page is read straight from them.
+	`DROP INDEX IF EXISTS idx_product_stock_models_restock_urgency`,
+	`CREATE INDEX IF NOT EXISTS idx_product_stock_models_tenant_restock_urgency
+		ON product_stock_models (
+			tenant_id,
+			((minimum_stock - (current_stock - average_daily_sales * lead_time_days)) * criticality_level) DESC,
+			criticality_level DESC,
+			average_daily_sales DESC,
+			LOWER(name) ASC,
+			id ASC
+		)
+		WHERE (current_stock - average_daily_sales * lead_time_days) < minimum_stock`,
+	`CREATE INDEX IF NOT EXISTS idx_restock_priority_snapshot_models_tenant_urgency
+		ON restock_priority_snapshot_models (
+			tenant_id,
+			urgency_score DESC,
+			criticality_level DESC,
+			average_daily_sales DESC,
+			LOWER(name) ASC,
+			product_stock_id ASC
+		)
+		WHERE needs_restock`,
+
+	// SKUs and barcodes used to be unique across the whole table; they are
+	// unique per tenant now.
+	`DROP INDEX IF EXISTS idx_product_stock_models_sku`,
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT 1 FROM pg_constraint c
+			JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY (c.conkey)
+			WHERE c.conrelid = 'product_barcode_models'::regclass AND c.contype = 'p' AND a.attname = 'tenant_id'
+		) THEN
+			ALTER TABLE product_barcode_models DROP CONSTRAINT IF EXISTS product_barcode_models_pkey;
+			ALTER TABLE product_barcode_models ADD PRIMARY KEY (tenant_id, barcode);
+		END IF;
+	END $$`,
+
+	// Idempotency keys used to be global; they are unique per tenant and
+	// actor now.
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT 1 FROM pg_constraint c
+			JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY (c.conkey)
+			WHERE c.conrelid = 'idempotency_key_models'::regclass AND c.contype = 'p' AND a.attname = 'tenant_id'
+		) THEN
+			ALTER TABLE idempotency_key_models DROP CONSTRAINT IF EXISTS idempotency_key_models_pkey;
+			ALTER TABLE idempotency_key_models ADD PRIMARY KEY (tenant_id, actor, key);
+		END IF;
+	END $$`,
+
+	// Product search: full-text on the name plus trigram similarity for
+	// partial words and typos.
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS idx_product_stock_models_name_trgm
+		ON product_stock_models USING gin (name gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_product_stock_models_name_fts
+		ON product_stock_models USING gin (to_tsvector('simple', name))`,
+
+	// The audit entries of a window, for the sales averages, and the oldest
+	// entry of a product.
+	`CREATE INDEX IF NOT EXISTS idx_audit_entry_models_tenant_type_occurred_at
+		ON audit_entry_models (tenant_id, entity_type, occurred_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_entry_models_entity_occurred_at
+		ON audit_entry_models (entity_id, occurred_at)`,
+
+	// Events waiting in the outbox, in the order the dispatcher claims them.
+	`CREATE INDEX IF NOT EXISTS idx_outbox_event_models_due
+		ON outbox_event_models (next_attempt_at, id)
+		WHERE dispatched_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_webhook_delivery_models_due
+		ON webhook_delivery_models (next_attempt_at, id)
+		WHERE status = 'pending'`,
+}
+
+func runMigrations(conn *gorm.DB) error {
+	for _, statement := range migrations {
+		if err := conn.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var productStockSortColumns = map[repository.ProductStockSortField]keysetColumn{
+	repository.SortByName:              {Expr: "LOWER(name)", Param: "LOWER(?)"},
+	repository.SortByCategory:          {Expr: "category"},
+	repository.SortByCurrentStock:      {Expr: "current_stock"},
+	repository.SortByMinimumStock:      {Expr: "minimum_stock"},
+	repository.SortByAverageDailySales: {Expr: "average_daily_sales"},
+	repository.SortByLeadTimeDays:      {Expr: "lead_time_days"},
+	repository.SortByUnitCost:          {Expr: "unit_cost"},
+	repository.SortByCriticalityLevel:  {Expr: "criticality_level"},
+}
+
+var idColumn = keysetColumn{Expr: "id"}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func applyProductStockFilter(query *gorm.DB, f repository.ProductStockFilter) *gorm.DB {
+	if f.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
+	if f.NameContains != "" {
+		query = query.Where("name ILIKE ?", "%"+likeEscaper.Replace(f.NameContains)+"%")
+	}
+
+	if len(f.Categories) > 0 {
+		categories := make([]string, len(f.Categories))
+		for i, c := range f.Categories {
+			categories[i] = string(c)
+		}
+		query = query.Where("category IN ?", categories)
+	}
+
+	if f.MinCriticality != nil {
+		query = query.Where("criticality_level >= ?", int(*f.MinCriticality))
+	}
+
+	if f.MaxCriticality != nil {
+		query = query.Where("criticality_level <= ?", int(*f.MaxCriticality))
+	}
+
+	if f.MinUnitCost != nil {
+		query = query.Where("unit_cost >= ?", *f.MinUnitCost)
+	}
+
+	if f.MaxUnitCost != nil {
+		query = query.Where("unit_cost <= ?", *f.MaxUnitCost)
+	}
+
+	if f.MinStock != nil {
+		query = query.Where("current_stock >= ?", *f.MinStock)
+	}
+
+	if f.MaxStock != nil {
+		query = query.Where("current_stock <= ?", *f.MaxStock)
+	}
+
+	if f.BelowMinimum != nil {
+		if *f.BelowMinimum {
+			query = query.Where("current_stock < minimum_stock")
+		} else {
+			query = query.Where("current_stock >= minimum_stock")
+		}
+	}
+
+	if f.NeedsRestock != nil {
+		if *f.NeedsRestock {
+			query = query.Where(needsRestockSQL)
+		} else {
+			query = query.Where("NOT (" + needsRestockSQL + ")")
+		}
+	}
+
+	return query
+}
+
+// applyProductStockQuery filters, orders and paginates the products. The
+// ordering always ends with the primary key, so it is total and pages never
+// overlap.
+func applyProductStockQuery(query *gorm.DB, q *repository.ProductStockQuery, pagination *domain.Pagination) *gorm.DB {
+	var columns []keysetColumn
+
+	if q != nil {
+		query = applyProductStockFilter(query, q.Filter)
+
+		for _, s := range q.Sort {
+			column := productStockSortColumns[s.Field]
+			column.Descending = s.Descending
+			columns = append(columns, column)
+		}
+	}
+
+	columns = append(columns, idColumn)
+
+	return applyOrderAndPagination(query, columns, pagination)
+}
+
+func applyOrderAndPagination(query *gorm.DB, columns []keysetColumn, pagination *domain.Pagination) *gorm.DB {
+	for _, column := range columns {
+		query = query.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: column.Expr, Raw: true},
+			Desc:   column.Descending,
+		})
+	}
+
+	if pagination == nil {
+		return query
+	}
+
+	if pagination.After != nil {
+		return applyKeyset(query, columns, pagination.After).Limit(pagination.Limit)
+	}
+
+	return query.Offset(pagination.Offset()).Limit(pagination.Limit)
+}
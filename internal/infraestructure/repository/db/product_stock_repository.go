@@ -1,58 +1,118 @@
 package db
 
 import (
+	"time"
+
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ErrorMapper interface {
 	MapErrorToDomain(err error, context string) *domain.Error
 }
 
+// ProductStockRepository is not bound to a tenant until ForTenant is
+// called; unbound, it only serves the tenant independent capabilities, like
+// the idempotency keys.
 type ProductStockRepository struct {
 	db          *gorm.DB
 	dbErrMapper ErrorMapper
+	tenantID    string
 }
 
 func NewProductStockRepository(gorm *gorm.DB, errMapper ErrorMapper) *ProductStockRepository {
 	return &ProductStockRepository{db: gorm, dbErrMapper: errMapper}
 }
 
+// ForTenant adds the tenant condition to every statement run through the
+// returned repository, inside transactions too, so no query can reach the
+// rows of another tenant. Rows it creates are given the tenant.
+func (r *ProductStockRepository) ForTenant(tenantID string) repository.IProductStockRepository {
+	return &ProductStockRepository{
+		db:          r.db.Session(&gorm.Session{NewDB: true}).Where("tenant_id = ?", tenantID).Session(&gorm.Session{}),
+		dbErrMapper: r.dbErrMapper,
+		tenantID:    tenantID,
+	}
+}
+
 func (r *ProductStockRepository) Create(in *entities.ProductStock) (string, *domain.Error) {
 	model := MapProductStockToModel(in)
+	model.TenantID = r.tenantID
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(model).Error; err != nil {
+			return err
+		}
 
-	if err := r.db.Create(model).Error; err != nil {
+		return createBarcodes(tx, model)
+	})
+	if err != nil {
 		return "", r.dbErrMapper.MapErrorToDomain(err, "failed to create product")
 	}
 
 	return model.ID, nil
 }
 
+// createBarcodes inserts the barcodes of a product without GORM's
+// association upsert, which would silently move a barcode already taken by
+// another product instead of failing with a unique violation.
+func createBarcodes(tx *gorm.DB, model *ProductStockModel) error {
+	if len(model.Barcodes) == 0 {
+		return nil
+	}
+
+	for i := range model.Barcodes {
+		model.Barcodes[i].TenantID = model.TenantID
+		model.Barcodes[i].ProductStockID = model.ID
+	}
+
+	return tx.Create(&model.Barcodes).Error
+}
+
 func (r *ProductStockRepository) Update(in *entities.ProductStock) *domain.Error {
 	model := MapProductStockToModel(in)
+	model.TenantID = r.tenantID
 
-	result := r.db.Save(model)
-	if result.Error != nil {
-		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to update product")
+	var rowsAffected int64
+
+	// The barcodes are replaced as a whole, in the same transaction as the
+	// product. Unlike Save, Updates never falls back to an insert, which
+	// would bring a deleted product back.
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Select("*").Omit(clause.Associations).Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected = result.RowsAffected
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Where("product_stock_id = ?", model.ID).Delete(&ProductBarcodeModel{}).Error; err != nil {
+			return err
+		}
+
+		return createBarcodes(tx, model)
+	})
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to update product")
 	}
 
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return domain.NewError("product not found", domain.ErrNotFound)
 	}
 
 	return nil
 }
 
-func (r *ProductStockRepository) GetAll(pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error) {
+func (r *ProductStockRepository) GetAll(q *repository.ProductStockQuery, pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error) {
 	var models []ProductStockModel
 
-	query := r.db.Model(&ProductStockModel{})
-
-	if pagination != nil {
-		offset := (pagination.Page - 1) * pagination.Limit
-		query = query.Offset(offset).Limit(pagination.Limit)
-	}
+	query := applyProductStockQuery(r.db.Model(&ProductStockModel{}).Preload("Barcodes"), q, pagination)
 
 	if err := query.Find(&models).Error; err != nil {
 		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list products")
@@ -66,26 +126,109 @@ func (r *ProductStockRepository) GetAll(pagination *domain.Pagination) ([]*entit
 	return result, nil
 }
 
+func (r *ProductStockRepository) Count(q *repository.ProductStockQuery) (int, *domain.Error) {
+	var count int64
+
+	query := r.db.Model(&ProductStockModel{})
+	if q != nil {
+		query = applyProductStockFilter(query, q.Filter)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count products")
+	}
+
+	return int(count), nil
+}
+
+func (r *ProductStockRepository) CountByCategory() (map[entities.ProductCategory]int, *domain.Error) {
+	var rows []struct {
+		Category string
+		Count    int
+	}
+
+	err := r.db.Model(&ProductStockModel{}).
+		Select("category, COUNT(*) AS count").
+		Group("category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to count products by category")
+	}
+
+	counts := make(map[entities.ProductCategory]int, len(rows))
+	for _, row := range rows {
+		counts[entities.ProductCategory(row.Category)] = row.Count
+	}
+
+	return counts, nil
+}
+
 func (r *ProductStockRepository) GetOneByID(id string) (*entities.ProductStock, *domain.Error) {
 	var model ProductStockModel
 
-	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Barcodes").First(&model, "id = ?", id).Error; err != nil {
 		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get product")
 	}
 
 	return model.ToDomain(), nil
 }
 
-func (r *ProductStockRepository) GetByCategory(category entities.ProductCategory, pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error) {
-	var models []ProductStockModel
+func (r *ProductStockRepository) GetOneByIDIncludingDeleted(id string) (*entities.ProductStock, *domain.Error) {
+	var model ProductStockModel
+
+	if err := r.db.Unscoped().Preload("Barcodes").First(&model, "id = ?", id).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get product")
+	}
+
+	return model.ToDomain(), nil
+}
 
-	query := r.db.Where("category = ?", string(category))
+func (r *ProductStockRepository) GetOneBySKU(sku string) (*entities.ProductStock, *domain.Error) {
+	var model ProductStockModel
+
+	if err := r.db.Preload("Barcodes").First(&model, "sku = ?", sku).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get product by sku")
+	}
+
+	return model.ToDomain(), nil
+}
 
-	if pagination != nil {
-		offset := (pagination.Page - 1) * pagination.Limit
-		query = query.Offset(offset).Limit(pagination.Limit)
+func (r *ProductStockRepository) GetOneBySKUIncludingDeleted(sku string) (*entities.ProductStock, *domain.Error) {
+	var model ProductStockModel
+
+	if err := r.db.Unscoped().Preload("Barcodes").First(&model, "sku = ?", sku).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get product by sku")
 	}
 
+	return model.ToDomain(), nil
+}
+
+func (r *ProductStockRepository) GetOneByBarcode(barcode string) (*entities.ProductStock, *domain.Error) {
+	return r.getOneByBarcode(r.db, barcode)
+}
+
+func (r *ProductStockRepository) GetOneByBarcodeIncludingDeleted(barcode string) (*entities.ProductStock, *domain.Error) {
+	return r.getOneByBarcode(r.db.Unscoped(), barcode)
+}
+
+func (r *ProductStockRepository) getOneByBarcode(db *gorm.DB, barcode string) (*entities.ProductStock, *domain.Error) {
+	var model ProductStockModel
+
+	query := db.Preload("Barcodes").
+		Where("id = (?)", r.db.Model(&ProductBarcodeModel{}).Select("product_stock_id").Where("barcode = ?", barcode))
+
+	if err := query.First(&model).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get product by barcode")
+	}
+
+	return model.ToDomain(), nil
+}
+
+func (r *ProductStockRepository) GetByCategory(category entities.ProductCategory, pagination *domain.Pagination) ([]*entities.ProductStock, *domain.Error) {
+	var models []ProductStockModel
+
+	query := applyProductStockQuery(r.db.Preload("Barcodes").Where("category = ?", string(category)), nil, pagination)
+
 	if err := query.Find(&models).Error; err != nil {
 		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get products by category")
 	}
@@ -99,7 +242,6 @@ func (r *ProductStockRepository) GetByCategory(category entities.ProductCategory
 }
 
 func (r *ProductStockRepository) DeleteProductStock(id string) *domain.Error {
-
 	result := r.db.Delete(&ProductStockModel{}, "id = ?", id)
 	if result.Error != nil {
 		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to delete product")
@@ -111,3 +253,39 @@ func (r *ProductStockRepository) DeleteProductStock(id string) *domain.Error {
 
 	return nil
 }
+
+func (r *ProductStockRepository) RestoreProductStock(id string) *domain.Error {
+	result := r.db.Unscoped().Model(&ProductStockModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to restore product")
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.NewError("deleted product not found", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// PurgeDeletedProductStocks removes the products for good, their barcodes
+// going with them through the foreign key, and returns their ids.
+func (r *ProductStockRepository) PurgeDeletedProductStocks(deletedBefore time.Time) ([]string, *domain.Error) {
+	var models []ProductStockModel
+
+	err := r.db.Unscoped().
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("deleted_at <= ?", deletedBefore).
+		Delete(&models).Error
+	if err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to purge deleted products")
+	}
+
+	ids := make([]string, len(models))
+	for i, model := range models {
+		ids[i] = model.ID
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/search"
+)
+
+// A product matches when the full-text search on its name finds every
+// query word as a prefix, when the query is similar enough to a part of
+// the name (pg_trgm word similarity), which tolerates typos, or when the
+// query is its SKU. The rank adds those measures, so an exact SKU comes
+// first.
+const (
+	searchDocumentSQL = "to_tsvector('simple', name)"
+	searchMatchSQL    = searchDocumentSQL + " @@ to_tsquery('simple', @tsquery) OR @term <% name OR sku = @sku"
+	searchRankSQL     = "ts_rank(" + searchDocumentSQL + ", to_tsquery('simple', @tsquery)) + word_similarity(@term, name)" +
+		" + CASE WHEN sku = @sku THEN 1 ELSE 0 END"
+)
+
+type productStockSearchRow struct {
+	ID    string
+	Score float64
+}
+
+func (r *ProductStockRepository) Search(query string, pagination *domain.Pagination) ([]repository.ProductStockSearchResult, *domain.Error) {
+	var rows []productStockSearchRow
+
+	args := []any{
+		sql.Named("tsquery", prefixTSQuery(query)),
+		sql.Named("term", query),
+		sql.Named("sku", entities.NormalizeSKU(query)),
+	}
+
+	q := r.db.Model(&ProductStockModel{}).
+		Select("id, "+searchRankSQL+" AS score", args...).
+		Where(searchMatchSQL, args...).
+		Order("score DESC").
+		Order("id ASC")
+
+	if pagination != nil {
+		q = q.Offset(pagination.Offset()).Limit(pagination.Limit)
+	}
+
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to search products")
+	}
+
+	if len(rows) == 0 {
+		return []repository.ProductStockSearchResult{}, nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	var models []ProductStockModel
+	if err := r.db.Preload("Barcodes").Find(&models, "id IN ?", ids).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to search products")
+	}
+
+	byID := make(map[string]*entities.ProductStock, len(models))
+	for i := range models {
+		byID[models[i].ID] = models[i].ToDomain()
+	}
+
+	result := make([]repository.ProductStockSearchResult, 0, len(rows))
+	for _, row := range rows {
+		if p, ok := byID[row.ID]; ok {
+			result = append(result, repository.ProductStockSearchResult{
+				Score:        row.Score,
+				ProductStock: p,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// prefixTSQuery turns "oil filt" into "oil:* & filt:*". Tokenize only keeps
+// letters and digits, so the result is always valid tsquery syntax.
+func prefixTSQuery(query string) string {
+	tokens := search.Tokenize(query)
+	for i, token := range tokens {
+		tokens[i] = token + ":*"
+	}
+
+	return strings.Join(tokens, " & ")
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+// The expressions mirror restock.Project, so the database can filter and
+// order by urgency without loading the catalog into memory.
+const (
+	projectedStockSQL = "(current_stock - average_daily_sales * lead_time_days)"
+	urgencyScoreSQL   = "((minimum_stock - " + projectedStockSQL + ") * criticality_level)"
+	needsRestockSQL   = projectedStockSQL + " < minimum_stock"
+)
+
+// restockPriorityColumns follow restock.Less and restock.Priority.SortKey.
+var restockPriorityColumns = []keysetColumn{
+	{Expr: urgencyScoreSQL, Descending: true},
+	{Expr: "criticality_level", Descending: true},
+	{Expr: "average_daily_sales", Descending: true},
+	{Expr: "LOWER(name)", Param: "LOWER(?)"},
+	idColumn,
+}
+
+func (r *ProductStockRepository) CountRestockPriorities() (int, *domain.Error) {
+	var count int64
+
+	if err := r.db.Model(&ProductStockModel{}).Where(needsRestockSQL).Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count restock priorities")
+	}
+
+	return int(count), nil
+}
+
+func (r *ProductStockRepository) GetRestockPriorities(categories []entities.ProductCategory, pagination *domain.Pagination) ([]restock.Priority, *domain.Error) {
+	var models []ProductStockModel
+
+	query := r.db.Model(&ProductStockModel{}).Preload("Barcodes").Where(needsRestockSQL)
+	if len(categories) > 0 {
+		names := make([]string, len(categories))
+		for i, c := range categories {
+			names[i] = string(c)
+		}
+		query = query.Where("category IN ?", names)
+	}
+
+	query = applyOrderAndPagination(query, restockPriorityColumns, pagination)
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list restock priorities")
+	}
+
+	result := make([]restock.Priority, len(models))
+	for i := range models {
+		p := models[i].ToDomain()
+		result[i] = restock.Priority{
+			Projection:   restock.Project(p),
+			ProductStock: p,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RestockPrioritySnapshotModel is the snapshot entry of a product, with the
+// columns restock.Less orders by. The entry of a product leaving the
+// snapshot is kept with needs_restock unset, so its computed_at still tells
+// when the snapshot last changed.
+type RestockPrioritySnapshotModel struct {
+	ProductStockID    string            `gorm:"type:uuid;primaryKey"`
+	TenantID          string            `gorm:"type:varchar(64);not null;default:'default';index:idx_restock_priority_snapshot_models_tenant_computed_at,priority:1"`
+	NeedsRestock      bool              `gorm:"not null"`
+	UrgencyScore      int               `gorm:"not null"`
+	CriticalityLevel  int               `gorm:"not null"`
+	AverageDailySales int               `gorm:"not null"`
+	Name              string            `gorm:"type:varchar(255);not null"`
+	ComputedAt        time.Time         `gorm:"not null;index:idx_restock_priority_snapshot_models_tenant_computed_at,priority:2"`
+	ProductStock      ProductStockModel `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// RestockPrioritySnapshotStateModel marks the tenants having a snapshot.
+type RestockPrioritySnapshotStateModel struct {
+	TenantID  string    `gorm:"type:varchar(64);primaryKey"`
+	RebuiltAt time.Time `gorm:"not null"`
+}
+
+// restockPrioritySnapshotColumns follow restock.Less and
+// restock.Priority.SortKey, like restockPriorityColumns.
+var restockPrioritySnapshotColumns = []keysetColumn{
+	{Expr: "urgency_score", Descending: true},
+	{Expr: "criticality_level", Descending: true},
+	{Expr: "average_daily_sales", Descending: true},
+	{Expr: "LOWER(name)", Param: "LOWER(?)"},
+	{Expr: "product_stock_id"},
+}
+
+// RebuildRestockPrioritySnapshot replaces the entries of the tenant with
+// those of the products needing a reposition now, computed by the database.
+func (r *ProductStockRepository) RebuildRestockPrioritySnapshot(computedAt time.Time) *domain.Error {
+	entries := r.db.Model(&ProductStockModel{}).
+		Select("id, tenant_id, true, "+urgencyScoreSQL+", criticality_level, average_daily_sales, name, ?", computedAt).
+		Where(needsRestockSQL)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRestockPrioritySnapshot(tx, r.tenantID, true); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&RestockPrioritySnapshotModel{}).Error; err != nil {
+			return err
+		}
+
+		err := tx.Exec(`INSERT INTO restock_priority_snapshot_models
+			(product_stock_id, tenant_id, needs_restock, urgency_score, criticality_level, average_daily_sales, name, computed_at) ?`, entries).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rebuilt_at"}),
+		}).Create(&RestockPrioritySnapshotStateModel{TenantID: r.tenantID, RebuiltAt: computedAt}).Error
+	})
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to rebuild restock priority snapshot")
+	}
+
+	return nil
+}
+
+// RefreshRestockPrioritySnapshot only writes the entry of the product, so
+// writers never wait on each other for the snapshot, only on a rebuild.
+func (r *ProductStockRepository) RefreshRestockPrioritySnapshot(p *entities.ProductStock, computedAt time.Time) *domain.Error {
+	projection := restock.Project(p)
+	entry := &RestockPrioritySnapshotModel{
+		ProductStockID:    *p.ID,
+		TenantID:          r.tenantID,
+		NeedsRestock:      projection.IsRepositionNeeded && p.DeletedAt == nil,
+		UrgencyScore:      projection.UrgencyScore,
+		CriticalityLevel:  int(p.CriticalityLevel),
+		AverageDailySales: p.AverageDailySales,
+		Name:              p.Name,
+		ComputedAt:        computedAt,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRestockPrioritySnapshot(tx, r.tenantID, false); err != nil {
+			return err
+		}
+
+		var built int64
+		if err := tx.Model(&RestockPrioritySnapshotStateModel{}).Count(&built).Error; err != nil || built == 0 {
+			return err
+		}
+
+		if entry.NeedsRestock {
+			return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "product_stock_id"}},
+				UpdateAll: true,
+			}).Create(entry).Error
+		}
+
+		return tx.Model(&RestockPrioritySnapshotModel{}).
+			Where("product_stock_id = ? AND needs_restock", entry.ProductStockID).
+			Updates(map[string]any{"needs_restock": false, "computed_at": computedAt}).Error
+	})
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to refresh restock priority snapshot")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) GetRestockPrioritySnapshot(pagination *domain.Pagination) ([]restock.Priority, *time.Time, *domain.Error) {
+	var state struct {
+		ComputedAt time.Time
+	}
+
+	result := r.db.Model(&RestockPrioritySnapshotStateModel{}).
+		Select("GREATEST(rebuilt_at, (?)) AS computed_at", r.db.Model(&RestockPrioritySnapshotModel{}).Select("MAX(computed_at)")).
+		Scan(&state)
+	if result.Error != nil {
+		return nil, nil, r.dbErrMapper.MapErrorToDomain(result.Error, "failed to get restock priority snapshot")
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil, nil
+	}
+
+	var models []RestockPrioritySnapshotModel
+
+	query := applyOrderAndPagination(r.db.Preload("ProductStock.Barcodes").Where("needs_restock"), restockPrioritySnapshotColumns, pagination)
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get restock priority snapshot")
+	}
+
+	priorities := make([]restock.Priority, len(models))
+	for i := range models {
+		p := models[i].ProductStock.ToDomain()
+		priorities[i] = restock.Priority{
+			Projection:   restock.Project(p),
+			ProductStock: p,
+		}
+	}
+
+	return priorities, &state.ComputedAt, nil
+}
+
+func (r *ProductStockRepository) CountRestockPrioritySnapshot() (int, *domain.Error) {
+	var count int64
+
+	if err := r.db.Model(&RestockPrioritySnapshotModel{}).Where("needs_restock").Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count restock priority snapshot")
+	}
+
+	return int(count), nil
+}
+
+// lockRestockPrioritySnapshot takes a transaction level advisory lock on the
+// snapshot of the tenant: shared to refresh entries and exclusive to
+// rebuild, so a rebuild sees every change committed before it and the
+// changes waiting on it are applied over it.
+func lockRestockPrioritySnapshot(tx *gorm.DB, tenantID string, exclusive bool) error {
+	key := advisoryLockKey("restock_priority_snapshot:" + tenantID)
+
+	if exclusive {
+		return tx.Exec("SELECT pg_advisory_xact_lock(?)", key).Error
+	}
+
+	return tx.Exec("SELECT pg_advisory_xact_lock_shared(?)", key).Error
+}
@@ -0,0 +1,117 @@
+package db_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/db"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/db/postgres"
+)
+
+// TestRestockPrioritySnapshotFollowsProductChanges runs against the
+// database configured by the POSTGRES_* variables, in a tenant of its own.
+// Each read goes through a new use case, as another replica would.
+func TestRestockPrioritySnapshotFollowsProductChanges(t *testing.T) {
+	if os.Getenv("POSTGRES_HOST") == "" {
+		t.Skip("POSTGRES_HOST is not set")
+	}
+
+	conn := postgres.NewPostgresConnection()
+	repo := db.NewProductStockRepository(conn, postgres.NewPostgresErrMapper())
+	tn := tenant.Tenant{
+		ID:         "snapshot-test-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		Pagination: domain.PaginationConfig{DefaultLimit: 20, MaxLimit: 100},
+		Categories: []entities.ProductCategory{"oil"},
+	}
+
+	t.Cleanup(func() {
+		for _, model := range []any{&db.ProductStockModel{}, &db.RestockPrioritySnapshotStateModel{}, &db.AuditEntryModel{}, &db.OutboxEventModel{}} {
+			conn.Unscoped().Where("tenant_id = ?", tn.ID).Delete(model)
+		}
+	})
+
+	priorities := func(want ...string) time.Time {
+		t.Helper()
+
+		page, err := usecases.NewGetProductPriorityUseCase(repo).Execute(tn, domain.Pagination{IncludeTotal: true})
+		if err != nil {
+			t.Fatalf("get priorities: %s", err.Message)
+		}
+
+		var got []string
+		for _, p := range page.Items {
+			got = append(got, *p.ProductStock.ID)
+		}
+
+		if len(got) != len(want) || *page.Total != len(want) {
+			t.Fatalf("priorities = %v with total %d, want %v", got, *page.Total, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("priorities = %v, want %v", got, want)
+			}
+		}
+
+		return page.ComputedAt
+	}
+
+	create := func(name string, currentStock, criticality int) string {
+		t.Helper()
+
+		id, err := usecases.NewCreateProductStockUseCase(repo).Execute(usecases.CreateProductStockDTO{
+			Tenant:            tn,
+			Name:              name,
+			Category:          "oil",
+			CurrentStock:      currentStock,
+			MinimumStock:      10,
+			AverageDailySales: 1,
+			LeadTimeDays:      2,
+			UnitCost:          5,
+			CriticalityLevel:  criticality,
+			Actor:             "test",
+		})
+		if err != nil {
+			t.Fatalf("create %s: %s", name, err.Message)
+		}
+
+		return id
+	}
+
+	filter := create("Oil Filter", 5, 2)
+	builtAt := priorities(filter)
+
+	pump := create("Oil Pump", 0, 5)
+	if changedAt := priorities(pump, filter); changedAt.Before(builtAt) {
+		t.Fatalf("computed_at went back from %s to %s", builtAt, changedAt)
+	}
+
+	stock := 100
+	if err := usecases.NewUpdateProductStockUseCase(repo).Execute(usecases.UpdateProductStockDTO{Tenant: tn, ID: filter, CurrentStock: &stock, Actor: "test"}); err != nil {
+		t.Fatalf("update: %s", err.Message)
+	}
+	priorities(pump)
+
+	if err := usecases.NewDeleteProductStockUseCase(repo).Execute(usecases.DeleteProductStockDTO{Tenant: tn, ID: pump, Actor: "test"}); err != nil {
+		t.Fatalf("delete: %s", err.Message)
+	}
+	priorities()
+
+	if _, err := usecases.NewRestoreProductStockUseCase(repo).Execute(usecases.RestoreProductStockDTO{Tenant: tn, ID: pump, Actor: "test"}); err != nil {
+		t.Fatalf("restore: %s", err.Message)
+	}
+	restoredAt := priorities(pump)
+
+	refreshedAt, err := usecases.NewRefreshRestockPrioritySnapshotUseCase(repo).Execute(tn.ID)
+	if err != nil {
+		t.Fatalf("refresh: %s", err.Message)
+	}
+	if servedAt := priorities(pump); servedAt.Before(restoredAt) || servedAt.Sub(refreshedAt).Abs() > time.Millisecond {
+		t.Fatalf("computed_at = %s after a refresh at %s", servedAt, refreshedAt)
+	}
+}
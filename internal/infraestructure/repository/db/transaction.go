@@ -0,0 +1,30 @@
+package db
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+func (r *ProductStockRepository) WithinTransaction(fn func(repo repository.IProductStockRepository) *domain.Error) *domain.Error {
+	var fnErr *domain.Error
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		fnErr = fn(&ProductStockRepository{db: tx, dbErrMapper: r.dbErrMapper, tenantID: r.tenantID})
+		if fnErr != nil {
+			return fnErr
+		}
+
+		return nil
+	})
+
+	if fnErr != nil {
+		return fnErr
+	}
+
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to commit transaction")
+	}
+
+	return nil
+}
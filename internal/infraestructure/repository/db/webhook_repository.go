@@ -0,0 +1,276 @@
+package db
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookSubscriptionModel struct {
+	ID         string                 `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TenantID   string                 `gorm:"type:varchar(64);not null;default:'default';index"`
+	URL        string                 `gorm:"type:text;not null"`
+	EventTypes []string               `gorm:"type:jsonb;serializer:json;not null"`
+	Secret     string                 `gorm:"type:text;not null"`
+	CreatedAt  time.Time              `gorm:"not null"`
+	Deliveries []WebhookDeliveryModel `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookDeliveryModel keeps the event it delivers, so it can still be
+// redelivered once the event is purged from the outbox. An event has at
+// most one delivery per subscription.
+type WebhookDeliveryModel struct {
+	ID             int64       `gorm:"primaryKey;autoIncrement"`
+	TenantID       string      `gorm:"type:varchar(64);not null;default:'default'"`
+	SubscriptionID string      `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_models_subscription_event,priority:1"`
+	EventID        int64       `gorm:"not null;uniqueIndex:idx_webhook_delivery_models_subscription_event,priority:2"`
+	Event          event.Event `gorm:"type:jsonb;serializer:json;not null"`
+	Status         string      `gorm:"type:varchar(16);not null"`
+	Attempts       int         `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string   `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"not null"`
+	DeliveredAt    *time.Time
+}
+
+// webhookDeliveryColumns orders the deliveries newest first.
+var webhookDeliveryColumns = []keysetColumn{{Expr: "id", Descending: true}}
+
+func (r *ProductStockRepository) CreateWebhookSubscription(s *webhook.Subscription) (string, *domain.Error) {
+	model := &WebhookSubscriptionModel{
+		TenantID:   r.tenantID,
+		URL:        s.URL,
+		EventTypes: make([]string, len(s.EventTypes)),
+		Secret:     s.Secret,
+		CreatedAt:  s.CreatedAt,
+	}
+
+	for i, t := range s.EventTypes {
+		model.EventTypes[i] = string(t)
+	}
+
+	if err := r.db.Omit(clause.Associations).Create(model).Error; err != nil {
+		return "", r.dbErrMapper.MapErrorToDomain(err, "failed to create webhook subscription")
+	}
+
+	return model.ID, nil
+}
+
+func (r *ProductStockRepository) GetWebhookSubscriptions() ([]webhook.Subscription, *domain.Error) {
+	var models []WebhookSubscriptionModel
+
+	if err := r.db.Order("created_at, id").Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list webhook subscriptions")
+	}
+
+	result := make([]webhook.Subscription, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) GetWebhookSubscription(id string) (*webhook.Subscription, *domain.Error) {
+	var model WebhookSubscriptionModel
+
+	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get webhook subscription")
+	}
+
+	s := model.ToDomain()
+	return &s, nil
+}
+
+func (r *ProductStockRepository) DeleteWebhookSubscription(id string) *domain.Error {
+	result := r.db.Delete(&WebhookSubscriptionModel{}, "id = ?", id)
+	if result.Error != nil {
+		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to delete webhook subscription")
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.NewError("webhook subscription not found", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) EnqueueWebhookDeliveries(deliveries []webhook.Delivery) *domain.Error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	models := make([]WebhookDeliveryModel, len(deliveries))
+	for i, d := range deliveries {
+		models[i] = mapWebhookDeliveryToModel(&d)
+		models[i].TenantID = r.tenantID
+	}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(&models).Error
+	if err != nil {
+		return r.dbErrMapper.MapErrorToDomain(err, "failed to enqueue webhook deliveries")
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) ClaimWebhookDeliveries(limit int, now, leaseUntil time.Time) ([]webhook.Delivery, *domain.Error) {
+	var models []WebhookDeliveryModel
+
+	due := r.db.Model(&WebhookDeliveryModel{}).
+		Select("id").
+		Where("status = ? AND next_attempt_at <= ?", string(webhook.Pending), now).
+		Order("id").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	err := r.db.Model(&models).
+		Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Update("next_attempt_at", leaseUntil).Error
+	if err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to claim webhook deliveries")
+	}
+
+	slices.SortFunc(models, func(a, b WebhookDeliveryModel) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	result := make([]webhook.Delivery, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) UpdateWebhookDelivery(d *webhook.Delivery) *domain.Error {
+	result := r.db.Model(&WebhookDeliveryModel{}).Where("id = ?", d.ID).Updates(map[string]any{
+		"status":           string(d.Status),
+		"attempts":         d.Attempts,
+		"next_attempt_at":  d.NextAttemptAt,
+		"last_attempt_at":  d.LastAttemptAt,
+		"last_status_code": d.LastStatusCode,
+		"last_error":       d.LastError,
+		"delivered_at":     d.DeliveredAt,
+	})
+	if result.Error != nil {
+		return r.dbErrMapper.MapErrorToDomain(result.Error, "failed to update webhook delivery")
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.NewError("webhook delivery not found", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *ProductStockRepository) GetWebhookDelivery(subscriptionID string, id int64) (*webhook.Delivery, *domain.Error) {
+	var model WebhookDeliveryModel
+
+	if err := r.db.First(&model, "subscription_id = ? AND id = ?", subscriptionID, id).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to get webhook delivery")
+	}
+
+	d := model.ToDomain()
+	return &d, nil
+}
+
+func (r *ProductStockRepository) GetWebhookDeliveries(subscriptionID string, status webhook.Status, pagination *domain.Pagination) ([]webhook.Delivery, *domain.Error) {
+	var models []WebhookDeliveryModel
+
+	query := applyOrderAndPagination(applyWebhookDeliveryQuery(r.db.Model(&WebhookDeliveryModel{}), subscriptionID, status), webhookDeliveryColumns, pagination)
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, r.dbErrMapper.MapErrorToDomain(err, "failed to list webhook deliveries")
+	}
+
+	result := make([]webhook.Delivery, len(models))
+	for i, model := range models {
+		result[i] = model.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) CountWebhookDeliveries(subscriptionID string, status webhook.Status) (int, *domain.Error) {
+	var count int64
+
+	if err := applyWebhookDeliveryQuery(r.db.Model(&WebhookDeliveryModel{}), subscriptionID, status).Count(&count).Error; err != nil {
+		return 0, r.dbErrMapper.MapErrorToDomain(err, "failed to count webhook deliveries")
+	}
+
+	return int(count), nil
+}
+
+func applyWebhookDeliveryQuery(query *gorm.DB, subscriptionID string, status webhook.Status) *gorm.DB {
+	query = query.Where("subscription_id = ?", subscriptionID)
+
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	return query
+}
+
+func (m WebhookSubscriptionModel) ToDomain() webhook.Subscription {
+	id := m.ID
+
+	s := webhook.Subscription{
+		ID:         &id,
+		URL:        m.URL,
+		EventTypes: make([]event.Type, len(m.EventTypes)),
+		Secret:     m.Secret,
+		CreatedAt:  m.CreatedAt,
+	}
+
+	for i, t := range m.EventTypes {
+		s.EventTypes[i] = event.Type(t)
+	}
+
+	return s
+}
+
+func mapWebhookDeliveryToModel(d *webhook.Delivery) WebhookDeliveryModel {
+	return WebhookDeliveryModel{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.Event.ID,
+		Event:          d.Event,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func (m WebhookDeliveryModel) ToDomain() webhook.Delivery {
+	return webhook.Delivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		Event:          m.Event,
+		Status:         webhook.Status(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastAttemptAt:  m.LastAttemptAt,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		CreatedAt:      m.CreatedAt,
+		DeliveredAt:    m.DeliveredAt,
+	}
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+)
+
+// IdempotencyKeyRepository keeps the keys in the process memory, for
+// product stock repositories unable to store them.
+type IdempotencyKeyRepository struct {
+	mu   sync.Mutex
+	keys map[repository.IdempotencyKeyID]repository.IdempotencyKey
+}
+
+func NewIdempotencyKeyRepository() *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{keys: map[repository.IdempotencyKeyID]repository.IdempotencyKey{}}
+}
+
+func (r *IdempotencyKeyRepository) ReserveIdempotencyKey(key repository.IdempotencyKey, now time.Time) (*repository.IdempotencyKey, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.keys[key.IdempotencyKeyID]; ok && existing.ExpiresAt.After(now) {
+		return &existing, nil
+	}
+
+	key.Response = nil
+	r.keys[key.IdempotencyKeyID] = key
+
+	return nil, nil
+}
+
+func (r *IdempotencyKeyRepository) CompleteIdempotencyKey(id repository.IdempotencyKeyID, response repository.IdempotentResponse) *domain.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.keys[id]; ok {
+		existing.Response = &response
+		r.keys[id] = existing
+	}
+
+	return nil
+}
+
+func (r *IdempotencyKeyRepository) ReleaseIdempotencyKey(id repository.IdempotencyKeyID) *domain.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, id)
+
+	return nil
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpiredIdempotencyKeys(now time.Time) (int, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for k, key := range r.keys {
+		if !key.ExpiresAt.After(now) {
+			delete(r.keys, k)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
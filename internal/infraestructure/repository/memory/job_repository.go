@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
+)
+
+// JobRepository keeps the runs and the locks of the jobs in the process
+// memory, for product stock repositories unable to store them. Every
+// instance then runs the jobs on its own.
+type JobRepository struct {
+	mu     sync.Mutex
+	locked map[string]bool
+	runs   []job.Run
+	nextID int64
+}
+
+func NewJobRepository() *JobRepository {
+	return &JobRepository{locked: map[string]bool{}}
+}
+
+func (r *JobRepository) TryJobLock(name string) (func(), bool, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.locked[name] {
+		return nil, false, nil
+	}
+	r.locked[name] = true
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		delete(r.locked, name)
+	}, true, nil
+}
+
+func (r *JobRepository) CreateJobRun(run *job.Run) (int64, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	stored := *run
+	stored.ID = r.nextID
+	r.runs = append(r.runs, stored)
+
+	return stored.ID, nil
+}
+
+func (r *JobRepository) UpdateJobRun(run *job.Run) *domain.Error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.runs, func(existing job.Run) bool { return existing.ID == run.ID })
+	if i < 0 {
+		return domain.NewError("job run not found", domain.ErrNotFound)
+	}
+	r.runs[i] = *run
+
+	return nil
+}
+
+func (r *JobRepository) GetLastJobRun(name string) (*job.Run, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].Job == name {
+			run := r.runs[i]
+			return &run, nil
+		}
+	}
+
+	return nil, domain.NewError("job run not found", domain.ErrNotFound)
+}
+
+func (r *JobRepository) GetJobRuns(name string, pagination *domain.Pagination) ([]job.Run, *domain.Error) {
+	runs := r.newestFirst(name)
+
+	if pagination.After == nil {
+		return domain.PaginatedSlice(runs, pagination), nil
+	}
+
+	after, _ := pagination.After[0].(int64)
+	i := slices.IndexFunc(runs, func(run job.Run) bool { return run.ID < after })
+	if i < 0 {
+		return []job.Run{}, nil
+	}
+
+	return runs[i:min(i+pagination.Limit, len(runs))], nil
+}
+
+func (r *JobRepository) CountJobRuns(name string) (int, *domain.Error) {
+	return len(r.newestFirst(name)), nil
+}
+
+func (r *JobRepository) PurgeJobRuns(before time.Time) (int, *domain.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.runs)
+	r.runs = slices.DeleteFunc(r.runs, func(run job.Run) bool {
+		return run.FinishedAt != nil && run.FinishedAt.Before(before)
+	})
+
+	return n - len(r.runs), nil
+}
+
+func (r *JobRepository) newestFirst(name string) []job.Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var runs []job.Run
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if name == "" || r.runs[i].Job == name {
+			runs = append(runs, r.runs[i])
+		}
+	}
+
+	return runs
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+// TenantRepository holds the tenants read from the configuration at start.
+type TenantRepository struct {
+	tenants []tenant.Tenant
+	byID    map[string]tenant.Tenant
+}
+
+func NewTenantRepository(tenants []tenant.Tenant) *TenantRepository {
+	byID := make(map[string]tenant.Tenant, len(tenants))
+	for _, t := range tenants {
+		byID[t.ID] = t
+	}
+
+	return &TenantRepository{
+		tenants: tenants,
+		byID:    byID,
+	}
+}
+
+func (r *TenantRepository) GetTenant(id string) (*tenant.Tenant, *domain.Error) {
+	t, ok := r.byID[id]
+	if !ok {
+		return nil, domain.NewError("tenant not found", domain.ErrNotFound)
+	}
+
+	return &t, nil
+}
+
+func (r *TenantRepository) GetTenants() ([]tenant.Tenant, *domain.Error) {
+	return r.tenants, nil
+}
+
+const maxTenantCategoryLength = 100
+
+// tenantFileEntry is a tenant as listed in the tenants file. Zero limits and
+// a missing category list fall back to the defaults.
+type tenantFileEntry struct {
+	ID         string `json:"id"`
+	Pagination struct {
+		DefaultLimit int `json:"default_limit"`
+		MaxLimit     int `json:"max_limit"`
+	} `json:"pagination"`
+	Categories []string `json:"categories"`
+}
+
+// LoadTenants reads the JSON array of tenants of the given file, filling
+// what a tenant leaves out from the defaults.
+func LoadTenants(path string, defaults domain.PaginationConfig) ([]tenant.Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var entries []tenantFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid tenants file: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the tenants file lists no tenant")
+	}
+
+	tenants := make([]tenant.Tenant, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !tenant.IsValidID(entry.ID) {
+			return nil, fmt.Errorf("invalid tenant id %q", entry.ID)
+		}
+
+		if seen[entry.ID] {
+			return nil, fmt.Errorf("duplicate tenant %q", entry.ID)
+		}
+		seen[entry.ID] = true
+
+		t := tenant.Tenant{
+			ID:         entry.ID,
+			Pagination: defaults,
+			Categories: entities.DefaultProductCategories,
+		}
+
+		if entry.Pagination.DefaultLimit != 0 {
+			t.Pagination.DefaultLimit = entry.Pagination.DefaultLimit
+		}
+
+		if entry.Pagination.MaxLimit != 0 {
+			t.Pagination.MaxLimit = entry.Pagination.MaxLimit
+		}
+
+		if t.Pagination.DefaultLimit <= 0 || t.Pagination.MaxLimit < t.Pagination.DefaultLimit {
+			return nil, fmt.Errorf("tenant %q: the default limit must be positive and not above the max limit", entry.ID)
+		}
+
+		if entry.Categories != nil {
+			if t.Categories, err = parseTenantCategories(entry.Categories); err != nil {
+				return nil, fmt.Errorf("tenant %q: %w", entry.ID, err)
+			}
+		}
+
+		tenants = append(tenants, t)
+	}
+
+	return tenants, nil
+}
+
+func parseTenantCategories(names []string) ([]entities.ProductCategory, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("categories must not be empty")
+	}
+
+	categories := make([]entities.ProductCategory, 0, len(names))
+	for _, name := range names {
+		category := entities.ProductCategory(strings.TrimSpace(name))
+		if category == "" || len(category) > maxTenantCategoryLength {
+			return nil, fmt.Errorf("categories must have between 1 and %d characters", maxTenantCategoryLength)
+		}
+
+		if slices.Contains(categories, category) {
+			return nil, fmt.Errorf("duplicate category %q", category)
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
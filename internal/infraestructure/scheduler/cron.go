@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
+	"github.com/robfig/cron/v3"
+)
+
+// parser reads standard cron expressions, with an optional leading seconds
+// field, and descriptors such as @daily or @every 1h.
+var parser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ParseSchedule reads a cron expression, in the local time zone unless it
+// starts with CRON_TZ=<zone>.
+func ParseSchedule(spec string) (job.ISchedule, error) {
+	return parser.Parse(spec)
+}
+
+// CronScheduler calls every function on its own goroutine, so a slow one
+// does not delay the others.
+type CronScheduler struct {
+	cron *cron.Cron
+}
+
+func NewCronScheduler() *CronScheduler {
+	return &CronScheduler{
+		cron: cron.New(cron.WithParser(parser)),
+	}
+}
+
+func (s *CronScheduler) Schedule(schedule job.ISchedule, fn func()) {
+	s.cron.Schedule(schedule, cron.FuncJob(fn))
+}
+
+func (s *CronScheduler) Start() {
+	s.cron.Start()
+}
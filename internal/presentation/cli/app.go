@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+)
+
+const (
+	tableOutput  = "table"
+	jsonOutput   = "json"
+	defaultActor = "cli"
+)
+
+// options are the flags every command accepts, before or after its name.
+type options struct {
+	tenant string
+	actor  string
+	output string
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.tenant, "tenant", o.tenant, "tenant to act on (default \"default\")")
+	fs.StringVar(&o.actor, "actor", o.actor, "name recorded in the audit log")
+	fs.StringVar(&o.output, "output", o.output, "output format: table or json")
+}
+
+// command defines its flags on the flag set and returns the function
+// running it once they are parsed. args is the number of positional
+// arguments it takes, named in usage.
+type command struct {
+	name   string
+	usage  string
+	args   int
+	define func(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error
+}
+
+// commandContext is what a command runs with: its positional arguments,
+// the tenant and the writers.
+type commandContext struct {
+	args   []string
+	tenant tenant.Tenant
+	actor  string
+	out    printer
+	stdin  io.Reader
+	stderr io.Writer
+}
+
+// CLIApp runs a single command against the use cases, without going
+// through authentication: whoever runs it already has access to the
+// configured repository.
+type CLIApp struct {
+	args     []string
+	commands []command
+	tenantUC *usecases.ResolveTenantUseCase
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+// Run exits the process with the code of the command.
+func (a CLIApp) Run() {
+	os.Exit(a.run())
+}
+
+// NewCLIApp runs the command named by args, the command line without the
+// program name.
+func NewCLIApp(args []string, commands *ProductStockCommands, tenantUC *usecases.ResolveTenantUseCase) CLIApp {
+	return CLIApp{
+		args:     args,
+		commands: commands.list(),
+		tenantUC: tenantUC,
+		stdin:    os.Stdin,
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+	}
+}
+
+func (a CLIApp) run() int {
+	opts := &options{actor: defaultActor, output: tableOutput}
+
+	global := flag.NewFlagSet("stock", flag.ContinueOnError)
+	global.SetOutput(a.stderr)
+	global.Usage = a.usage
+	opts.register(global)
+
+	if err := global.Parse(a.args); err != nil {
+		return flagErrorExitCode(err)
+	}
+
+	rest := global.Args()
+	if len(rest) < 2 {
+		a.usage()
+		return exitUsage
+	}
+
+	name := rest[0] + " " + rest[1]
+	for _, cmd := range a.commands {
+		if cmd.name != name {
+			continue
+		}
+
+		fs := flag.NewFlagSet(name, flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		fs.Usage = func() {
+			fmt.Fprintf(a.stderr, "usage: %s [flags]\n", cmd.usage)
+			fs.PrintDefaults()
+		}
+		opts.register(fs)
+		runCommand := cmd.define(fs)
+
+		args, err := parseInterspersed(fs, rest[2:])
+		if err != nil {
+			return flagErrorExitCode(err)
+		}
+
+		if len(args) != cmd.args {
+			fs.Usage()
+			return exitUsage
+		}
+
+		if opts.output != tableOutput && opts.output != jsonOutput {
+			fmt.Fprintln(a.stderr, "error: output must be table or json")
+			return exitUsage
+		}
+
+		t, domainErr := a.tenantUC.Execute(usecases.ResolveTenantDTO{Requested: opts.tenant})
+		if domainErr == nil {
+			domainErr = runCommand(&commandContext{
+				args:   args,
+				tenant: *t,
+				actor:  opts.actor,
+				out:    printer{w: a.stdout, format: opts.output},
+				stdin:  a.stdin,
+				stderr: a.stderr,
+			})
+		}
+
+		if domainErr != nil {
+			fmt.Fprintln(a.stderr, "error: "+domainErr.Message)
+			return mapErrorToExitCode(domainErr.ErrCode)
+		}
+
+		return exitOK
+	}
+
+	fmt.Fprintf(a.stderr, "unknown command %q\n", name)
+	a.usage()
+	return exitUsage
+}
+
+func (a CLIApp) usage() {
+	fmt.Fprintln(a.stderr, "usage: HANDLER_TYPE=CLI <program> [--tenant id] [--actor name] [--output table|json] <command> [flags] [args]")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	for _, cmd := range a.commands {
+		fmt.Fprintln(a.stderr, "  "+cmd.usage)
+	}
+	fmt.Fprintln(a.stderr, "\nrun a command with -h to list its flags")
+}
+
+// parseInterspersed lets flags follow positional arguments, which the flag
+// package alone stops parsing at.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func flagErrorExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	return exitUsage
+}
@@ -0,0 +1,449 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+type ProductStockCommands struct {
+	createUC        *usecases.CreateProductStockUseCase
+	getAllUC        *usecases.GetAllProductStockUseCase
+	getOneUC        *usecases.GetOneProductStockUseCase
+	updateUC        *usecases.UpdateProductStockUseCase
+	deleteUC        *usecases.DeleteProductStockUseCase
+	getPriorityUC   *usecases.GetProductPriorityUseCase
+	importUC        *usecases.ImportProductStockUseCase
+	exportUC        *usecases.ExportProductStockUseCase
+	exportRestockUC *usecases.ExportRestockPrioritiesUseCase
+}
+
+func NewProductStockCommands(
+	createUC *usecases.CreateProductStockUseCase,
+	getAllUC *usecases.GetAllProductStockUseCase,
+	getOneUC *usecases.GetOneProductStockUseCase,
+	updateUC *usecases.UpdateProductStockUseCase,
+	deleteUC *usecases.DeleteProductStockUseCase,
+	getPriorityUC *usecases.GetProductPriorityUseCase,
+	importUC *usecases.ImportProductStockUseCase,
+	exportUC *usecases.ExportProductStockUseCase,
+	exportRestockUC *usecases.ExportRestockPrioritiesUseCase,
+) *ProductStockCommands {
+	return &ProductStockCommands{
+		createUC:        createUC,
+		getAllUC:        getAllUC,
+		getOneUC:        getOneUC,
+		updateUC:        updateUC,
+		deleteUC:        deleteUC,
+		getPriorityUC:   getPriorityUC,
+		importUC:        importUC,
+		exportUC:        exportUC,
+		exportRestockUC: exportRestockUC,
+	}
+}
+
+func (h *ProductStockCommands) list() []command {
+	return []command{
+		{name: "products list", usage: "products list", define: h.List},
+		{name: "products get", usage: "products get <id>", args: 1, define: h.Get},
+		{name: "products create", usage: "products create --name n --category c --unit-cost x --criticality-level n", define: h.Create},
+		{name: "products update", usage: "products update <id>", args: 1, define: h.Update},
+		{name: "products delete", usage: "products delete <id>", args: 1, define: h.Delete},
+		{name: "products import", usage: "products import <file.csv|->", args: 1, define: h.Import},
+		{name: "products export", usage: "products export", define: h.Export},
+		{name: "priorities list", usage: "priorities list", define: h.ListPriorities},
+		{name: "priorities export", usage: "priorities export", define: h.ExportPriorities},
+	}
+}
+
+// productStockFilterFlags are the filters of GET /stock.
+type productStockFilterFlags struct {
+	nameContains   *string
+	categories     repeatedFlag
+	minCriticality *optional[int]
+	maxCriticality *optional[int]
+	minUnitCost    *optional[float64]
+	maxUnitCost    *optional[float64]
+	minStock       *optional[int]
+	maxStock       *optional[int]
+	belowMinimum   *optional[bool]
+	needsRestock   *optional[bool]
+	includeDeleted *bool
+	sort           *string
+}
+
+func defineProductStockFilterFlags(fs *flag.FlagSet) *productStockFilterFlags {
+	f := &productStockFilterFlags{
+		nameContains:   fs.String("name-contains", "", "only products whose name contains the text"),
+		minCriticality: optionalIntFlag(fs, "criticality-min", "minimum criticality level"),
+		maxCriticality: optionalIntFlag(fs, "criticality-max", "maximum criticality level"),
+		minUnitCost:    optionalFloatFlag(fs, "unit-cost-min", "minimum unit cost"),
+		maxUnitCost:    optionalFloatFlag(fs, "unit-cost-max", "maximum unit cost"),
+		minStock:       optionalIntFlag(fs, "stock-min", "minimum current stock"),
+		maxStock:       optionalIntFlag(fs, "stock-max", "maximum current stock"),
+		belowMinimum:   optionalBoolFlag(fs, "below-minimum", "only products below (or, when false, not below) their minimum stock"),
+		needsRestock:   optionalBoolFlag(fs, "needs-restock", "only products that need (or, when false, do not need) restocking"),
+		includeDeleted: fs.Bool("include-deleted", false, "include deleted products"),
+		sort:           fs.String("sort", "", "fields to sort by, separated by commas, descending when prefixed by \"-\""),
+	}
+	fs.Var(&f.categories, "category", "only products of the category; repeat for several")
+
+	return f
+}
+
+func (f *productStockFilterFlags) toDTO() usecases.ProductStockFilterDTO {
+	return usecases.ProductStockFilterDTO{
+		NameContains:   *f.nameContains,
+		Categories:     f.categories,
+		MinCriticality: f.minCriticality.value,
+		MaxCriticality: f.maxCriticality.value,
+		MinUnitCost:    f.minUnitCost.value,
+		MaxUnitCost:    f.maxUnitCost.value,
+		MinStock:       f.minStock.value,
+		MaxStock:       f.maxStock.value,
+		BelowMinimum:   f.belowMinimum.value,
+		NeedsRestock:   f.needsRestock.value,
+		IncludeDeleted: *f.includeDeleted,
+		Sort:           *f.sort,
+	}
+}
+
+type paginationFlags struct {
+	limit  *int
+	page   *int
+	cursor *string
+	total  *bool
+}
+
+func definePaginationFlags(fs *flag.FlagSet) *paginationFlags {
+	return &paginationFlags{
+		limit:  fs.Int("limit", 0, "items per page (default and maximum from the tenant pagination config)"),
+		page:   fs.Int("page", 1, "page number"),
+		cursor: fs.String("cursor", "", "cursor of the next page, printed after the previous one"),
+		total:  fs.Bool("total", false, "count the items of every page"),
+	}
+}
+
+func (p *paginationFlags) toPagination() domain.Pagination {
+	return domain.Pagination{
+		Page:         *p.page,
+		Limit:        *p.limit,
+		Cursor:       *p.cursor,
+		IncludeTotal: *p.total,
+	}
+}
+
+// printNextPage tells on stderr how to get the rest of a table, so stdout
+// only holds the table.
+func printNextPage(cmd *commandContext, nextCursor string, total *int) {
+	if total != nil {
+		fmt.Fprintf(cmd.stderr, "total: %d\n", *total)
+	}
+
+	if nextCursor != "" {
+		fmt.Fprintf(cmd.stderr, "next page: --cursor %s\n", nextCursor)
+	}
+}
+
+func productStockTable(tw *tabwriter.Writer, products []*entities.ProductStock) {
+	row(tw, "ID", "SKU", "NAME", "CATEGORY", "STOCK", "MINIMUM", "DAILY SALES", "LEAD TIME", "UNIT COST", "CRITICALITY")
+	for _, p := range products {
+		row(tw, *p.ID, optionalText(p.Identifiers.SKU), p.Name, p.Category, p.CurrentStock, p.MinimumStock,
+			p.AverageDailySales, p.LeadTimeDays, formatCost(p.UnitCost), int(p.CriticalityLevel))
+	}
+}
+
+func productStockDetails(tw *tabwriter.Writer, p *entities.ProductStock) {
+	view := toProductStockView(p)
+
+	externalIDs := []string{}
+	for system, id := range view.ExternalIDs {
+		externalIDs = append(externalIDs, system+"="+id)
+	}
+	slices.Sort(externalIDs)
+
+	row(tw, "ID", view.ID)
+	row(tw, "SKU", optionalText(view.SKU))
+	row(tw, "Name", view.Name)
+	row(tw, "Category", view.Category)
+	row(tw, "Current stock", view.CurrentStock)
+	row(tw, "Minimum stock", view.MinimumStock)
+	row(tw, "Average daily sales", view.AverageDailySales)
+	row(tw, "Lead time days", view.LeadTimeDays)
+	row(tw, "Unit cost", formatCost(view.UnitCost))
+	row(tw, "Criticality level", view.CriticalityLevel)
+	row(tw, "Barcodes", strings.Join(view.Barcodes, ", "))
+	row(tw, "External ids", strings.Join(externalIDs, ", "))
+	if view.DeletedAt != nil {
+		row(tw, "Deleted at", view.DeletedAt.UTC().Format("2006-01-02 15:04:05Z"))
+	}
+}
+
+func (h *ProductStockCommands) List(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	filter := defineProductStockFilterFlags(fs)
+	pagination := definePaginationFlags(fs)
+
+	return func(cmd *commandContext) *domain.Error {
+		page, err := h.getAllUC.Execute(usecases.GetAllProductStockDTO{
+			Tenant:     cmd.tenant,
+			Filter:     filter.toDTO(),
+			Pagination: pagination.toPagination(),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = cmd.out.print(toProductStockPageView(page), func(tw *tabwriter.Writer) {
+			productStockTable(tw, page.Items)
+		})
+		if err == nil && cmd.out.format == tableOutput {
+			printNextPage(cmd, page.NextCursor, page.Total)
+		}
+
+		return err
+	}
+}
+
+func (h *ProductStockCommands) Get(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	includeDeleted := fs.Bool("include-deleted", false, "find deleted products too")
+
+	return func(cmd *commandContext) *domain.Error {
+		p, err := h.getOneUC.Execute(usecases.GetOneProductStockDTO{
+			Tenant:         cmd.tenant,
+			ID:             cmd.args[0],
+			IncludeDeleted: *includeDeleted,
+		})
+		if err != nil {
+			return err
+		}
+
+		return cmd.out.print(toProductStockView(p), func(tw *tabwriter.Writer) {
+			productStockDetails(tw, p)
+		})
+	}
+}
+
+func (h *ProductStockCommands) Create(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	name := fs.String("name", "", "name (required)")
+	category := fs.String("category", "", "category (required)")
+	currentStock := fs.Int("current-stock", 0, "current stock")
+	minimumStock := fs.Int("minimum-stock", 0, "minimum stock")
+	averageDailySales := fs.Int("average-daily-sales", 0, "average daily sales")
+	leadTimeDays := fs.Int("lead-time-days", 0, "lead time in days")
+	unitCost := fs.Float64("unit-cost", 0, "unit cost (required)")
+	criticalityLevel := fs.Int("criticality-level", 0, "criticality level, from 1 to 5 (required)")
+	sku := optionalStringFlag(fs, "sku", "SKU")
+	barcodes := optionalListFlag(fs, "barcodes", "EAN-13 or UPC-A barcodes, separated by commas")
+	externalIDs := optionalMapFlag(fs, "external-ids", "ids in other systems, as system=id pairs separated by commas")
+
+	return func(cmd *commandContext) *domain.Error {
+		dto := usecases.CreateProductStockDTO{
+			Tenant:            cmd.tenant,
+			Name:              *name,
+			Category:          *category,
+			CurrentStock:      *currentStock,
+			MinimumStock:      *minimumStock,
+			AverageDailySales: *averageDailySales,
+			LeadTimeDays:      *leadTimeDays,
+			UnitCost:          *unitCost,
+			CriticalityLevel:  *criticalityLevel,
+			SKU:               sku.value,
+			Actor:             cmd.actor,
+		}
+		if barcodes.value != nil {
+			dto.Barcodes = *barcodes.value
+		}
+		if externalIDs.value != nil {
+			dto.ExternalIDs = *externalIDs.value
+		}
+
+		id, err := h.createUC.Execute(dto)
+		if err != nil {
+			return err
+		}
+
+		p, err := h.getOneUC.Execute(usecases.GetOneProductStockDTO{Tenant: cmd.tenant, ID: id})
+		if err != nil {
+			return err
+		}
+
+		return cmd.out.print(toProductStockView(p), func(tw *tabwriter.Writer) {
+			productStockDetails(tw, p)
+		})
+	}
+}
+
+// Update only changes the fields whose flags are given. --barcodes and
+// --external-ids replace the current ones; an empty value removes them.
+func (h *ProductStockCommands) Update(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	currentStock := optionalIntFlag(fs, "current-stock", "current stock")
+	minimumStock := optionalIntFlag(fs, "minimum-stock", "minimum stock")
+	averageDailySales := optionalIntFlag(fs, "average-daily-sales", "average daily sales")
+	leadTimeDays := optionalIntFlag(fs, "lead-time-days", "lead time in days")
+	unitCost := optionalFloatFlag(fs, "unit-cost", "unit cost")
+	criticalityLevel := optionalIntFlag(fs, "criticality-level", "criticality level, from 1 to 5")
+	sku := optionalStringFlag(fs, "sku", "SKU")
+	barcodes := optionalListFlag(fs, "barcodes", "EAN-13 or UPC-A barcodes, separated by commas")
+	externalIDs := optionalMapFlag(fs, "external-ids", "ids in other systems, as system=id pairs separated by commas")
+
+	return func(cmd *commandContext) *domain.Error {
+		err := h.updateUC.Execute(usecases.UpdateProductStockDTO{
+			Tenant:            cmd.tenant,
+			ID:                cmd.args[0],
+			CurrentStock:      currentStock.value,
+			MinimumStock:      minimumStock.value,
+			AverageDailySales: averageDailySales.value,
+			LeadTimeDays:      leadTimeDays.value,
+			UnitCost:          unitCost.value,
+			CriticalityLevel:  criticalityLevel.value,
+			SKU:               sku.value,
+			Barcodes:          barcodes.value,
+			ExternalIDs:       externalIDs.value,
+			Actor:             cmd.actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		p, err := h.getOneUC.Execute(usecases.GetOneProductStockDTO{Tenant: cmd.tenant, ID: cmd.args[0]})
+		if err != nil {
+			return err
+		}
+
+		return cmd.out.print(toProductStockView(p), func(tw *tabwriter.Writer) {
+			productStockDetails(tw, p)
+		})
+	}
+}
+
+func (h *ProductStockCommands) Delete(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	return func(cmd *commandContext) *domain.Error {
+		return h.deleteUC.Execute(usecases.DeleteProductStockDTO{
+			Tenant: cmd.tenant,
+			ID:     cmd.args[0],
+			Actor:  cmd.actor,
+		})
+	}
+}
+
+// Import reads a CSV file, "-" for stdin, with the columns of POST
+// /stock/import. Like the route, nothing is written when any row fails,
+// which exits with the code of unprocessable requests.
+func (h *ProductStockCommands) Import(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	dryRun := fs.Bool("dry-run", false, "only validate the file")
+
+	return func(cmd *commandContext) *domain.Error {
+		var r io.Reader = cmd.stdin
+		if cmd.args[0] != "-" {
+			file, err := os.Open(cmd.args[0])
+			if err != nil {
+				return domain.NewError("failed to open the file: "+err.Error(), domain.ErrBadRequest)
+			}
+			defer file.Close()
+			r = file
+		}
+
+		header, rows, err := readCSV(r)
+		if err != nil {
+			return err
+		}
+
+		report, err := h.importUC.Execute(usecases.ImportProductStockDTO{
+			Tenant: cmd.tenant,
+			Header: header,
+			Rows:   rows,
+			DryRun: *dryRun,
+			Actor:  cmd.actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = cmd.out.print(toImportReportView(report), func(tw *tabwriter.Writer) {
+			row(tw, "ROW", "ACTION", "ID", "SKU", "ERRORS")
+			for _, r := range report.Rows {
+				row(tw, r.Row, r.Action, optionalText(r.ID), optionalText(r.SKU), strings.Join(r.Errors, "; "))
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		if cmd.out.format == tableOutput {
+			fmt.Fprintf(cmd.stderr, "created: %d, updated: %d, failed: %d, committed: %t\n", report.Created, report.Updated, report.Failed, report.Committed)
+		}
+
+		if !report.DryRun && !report.Committed {
+			return domain.NewError("import has invalid rows, nothing was written", domain.ErrUnprocessable)
+		}
+
+		return nil
+	}
+}
+
+// Export writes every product matching the filters to stdout as CSV,
+// whatever the output format.
+func (h *ProductStockCommands) Export(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	filter := defineProductStockFilterFlags(fs)
+
+	return func(cmd *commandContext) *domain.Error {
+		export := newCSVExport(cmd.out.w, productStockCSVHeader, productStockCSVRow)
+		if *filter.includeDeleted {
+			export = newCSVExport(cmd.out.w, deletedProductStockCSVHeader, deletedProductStockCSVRow)
+		}
+
+		if err := h.exportUC.Execute(cmd.tenant, filter.toDTO(), export.write); err != nil {
+			return err
+		}
+
+		return export.write(nil)
+	}
+}
+
+func (h *ProductStockCommands) ListPriorities(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	pagination := definePaginationFlags(fs)
+
+	return func(cmd *commandContext) *domain.Error {
+		priorities, err := h.getPriorityUC.Execute(cmd.tenant, pagination.toPagination())
+		if err != nil {
+			return err
+		}
+
+		err = cmd.out.print(toRestockPrioritiesView(priorities), func(tw *tabwriter.Writer) {
+			row(tw, "URGENCY", "ID", "SKU", "NAME", "CATEGORY", "STOCK", "MINIMUM", "PROJECTED", "SUGGESTED")
+			for _, priority := range priorities.Items {
+				p := priority.ProductStock
+				row(tw, priority.UrgencyScore, *p.ID, optionalText(p.Identifiers.SKU), p.Name, p.Category,
+					p.CurrentStock, p.MinimumStock, priority.ProjectedStock, priority.SuggestedQuantity)
+			}
+		})
+		if err == nil && cmd.out.format == tableOutput {
+			printNextPage(cmd, priorities.NextCursor, priorities.Total)
+		}
+
+		return err
+	}
+}
+
+// ExportPriorities writes every restock priority to stdout as CSV, most
+// urgent first.
+func (h *ProductStockCommands) ExportPriorities(fs *flag.FlagSet) func(cmd *commandContext) *domain.Error {
+	return func(cmd *commandContext) *domain.Error {
+		export := newCSVExport(cmd.out.w, restockPriorityCSVHeader, restockPriorityCSVRow)
+
+		if err := h.exportRestockUC.Execute(cmd.tenant, export.write); err != nil {
+			return err
+		}
+
+		return export.write([]restock.Priority(nil))
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import "github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+
+// Exit codes. Usage errors exit with 2, like the flag package; failed
+// commands exit with a code telling the kind of domain error.
+const (
+	exitOK            = 0
+	exitInternal      = 1
+	exitUsage         = 2
+	exitNotFound      = 3
+	exitConflict      = 4
+	exitBadRequest    = 5
+	exitUnprocessable = 6
+	exitUnauthorized  = 7
+	exitForbidden     = 8
+)
+
+func mapErrorToExitCode(errCode domain.ErrorCode) int {
+	switch errCode {
+	case domain.ErrNotFound:
+		return exitNotFound
+	case domain.ErrConflict:
+		return exitConflict
+	case domain.ErrBadRequest:
+		return exitBadRequest
+	case domain.ErrUnprocessable:
+		return exitUnprocessable
+	case domain.ErrUnauthorized:
+		return exitUnauthorized
+	case domain.ErrForbidden:
+		return exitForbidden
+	default:
+		return exitInternal
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// optional is a flag telling whether it was given, for the fields that are
+// only filtered on or changed when they are.
+type optional[T any] struct {
+	value *T
+	parse func(string) (T, error)
+}
+
+func (o *optional[T]) String() string {
+	if o == nil || o.value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(*o.value)
+}
+
+func (o *optional[T]) Set(s string) error {
+	v, err := o.parse(s)
+	if err != nil {
+		return err
+	}
+
+	o.value = &v
+	return nil
+}
+
+type optionalBool struct {
+	optional[bool]
+}
+
+func (o *optionalBool) IsBoolFlag() bool {
+	return true
+}
+
+func optionalIntFlag(fs *flag.FlagSet, name, usage string) *optional[int] {
+	o := &optional[int]{parse: strconv.Atoi}
+	fs.Var(o, name, usage)
+	return o
+}
+
+func optionalFloatFlag(fs *flag.FlagSet, name, usage string) *optional[float64] {
+	o := &optional[float64]{parse: func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}}
+	fs.Var(o, name, usage)
+	return o
+}
+
+func optionalBoolFlag(fs *flag.FlagSet, name, usage string) *optional[bool] {
+	o := &optionalBool{optional[bool]{parse: strconv.ParseBool}}
+	fs.Var(o, name, usage)
+	return &o.optional
+}
+
+func optionalStringFlag(fs *flag.FlagSet, name, usage string) *optional[string] {
+	o := &optional[string]{parse: func(s string) (string, error) {
+		return s, nil
+	}}
+	fs.Var(o, name, usage)
+	return o
+}
+
+// optionalListFlag takes a comma separated list; an empty value sets an
+// empty list.
+func optionalListFlag(fs *flag.FlagSet, name, usage string) *optional[[]string] {
+	o := &optional[[]string]{parse: func(s string) ([]string, error) {
+		list := []string{}
+		for item := range strings.SplitSeq(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list, nil
+	}}
+	fs.Var(o, name, usage)
+	return o
+}
+
+// optionalMapFlag takes a comma separated list of key=value pairs; an empty
+// value sets an empty map.
+func optionalMapFlag(fs *flag.FlagSet, name, usage string) *optional[map[string]string] {
+	o := &optional[map[string]string]{parse: func(s string) (map[string]string, error) {
+		m := map[string]string{}
+		for pair := range strings.SplitSeq(s, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("%q is not a key=value pair", pair)
+			}
+			m[key] = value
+		}
+		return m, nil
+	}}
+	fs.Var(o, name, usage)
+	return o
+}
+
+// repeatedFlag collects every value of a flag given several times.
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string {
+	return strings.Join(*r, ",")
+}
+
+func (r *repeatedFlag) Set(s string) error {
+	*r = append(*r, s)
+	return nil
+}
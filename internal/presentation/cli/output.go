@@ -0,0 +1,331 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+)
+
+// printer writes a result either as indented JSON, shaped like the
+// responses of the HTTP API, or as a table.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p printer) print(view any, table func(tw *tabwriter.Writer)) *domain.Error {
+	if p.format == jsonOutput {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(view); err != nil {
+			return domain.NewError("failed to write output: "+err.Error(), domain.ErrInternal)
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	table(tw)
+	if err := tw.Flush(); err != nil {
+		return domain.NewError("failed to write output: "+err.Error(), domain.ErrInternal)
+	}
+
+	return nil
+}
+
+func row(tw *tabwriter.Writer, cells ...any) {
+	for i, cell := range cells {
+		if i > 0 {
+			fmt.Fprint(tw, "\t")
+		}
+		fmt.Fprint(tw, cell)
+	}
+	fmt.Fprintln(tw)
+}
+
+type productStockView struct {
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	Category          string            `json:"category"`
+	CurrentStock      int               `json:"current_stock"`
+	MinimumStock      int               `json:"minimum_stock"`
+	AverageDailySales int               `json:"average_daily_sales"`
+	LeadTimeDays      int               `json:"lead_time_days"`
+	UnitCost          float64           `json:"unit_cost"`
+	CriticalityLevel  int               `json:"criticality_level"`
+	SKU               *string           `json:"sku"`
+	Barcodes          []string          `json:"barcodes"`
+	ExternalIDs       map[string]string `json:"external_ids"`
+	DeletedAt         *time.Time        `json:"deleted_at,omitempty"`
+}
+
+func toProductStockView(p *entities.ProductStock) productStockView {
+	view := productStockView{
+		Name:              p.Name,
+		Category:          string(p.Category),
+		CurrentStock:      p.CurrentStock,
+		MinimumStock:      p.MinimumStock,
+		AverageDailySales: p.AverageDailySales,
+		LeadTimeDays:      p.LeadTimeDays,
+		UnitCost:          p.UnitCost,
+		CriticalityLevel:  int(p.CriticalityLevel),
+		SKU:               p.Identifiers.SKU,
+		Barcodes:          p.Identifiers.Barcodes,
+		ExternalIDs:       p.Identifiers.ExternalIDs,
+		DeletedAt:         p.DeletedAt,
+	}
+
+	if p.ID != nil {
+		view.ID = *p.ID
+	}
+
+	if view.Barcodes == nil {
+		view.Barcodes = []string{}
+	}
+
+	if view.ExternalIDs == nil {
+		view.ExternalIDs = map[string]string{}
+	}
+
+	return view
+}
+
+type productStockPageView struct {
+	Items      []productStockView `json:"items"`
+	NextCursor *string            `json:"next_cursor"`
+	Total      *int               `json:"total,omitempty"`
+}
+
+func toProductStockPageView(page *domain.Page[*entities.ProductStock]) productStockPageView {
+	view := productStockPageView{
+		Items:      make([]productStockView, len(page.Items)),
+		NextCursor: optionalCursor(page.NextCursor),
+		Total:      page.Total,
+	}
+
+	for i, p := range page.Items {
+		view.Items[i] = toProductStockView(p)
+	}
+
+	return view
+}
+
+type restockPriorityView struct {
+	ExpectedConsumption int              `json:"expected_consumption"`
+	ProjectedStock      int              `json:"projected_stock"`
+	IsRepositionNeeded  bool             `json:"is_reposition_needed"`
+	UrgencyScore        int              `json:"urgency_score"`
+	SuggestedQuantity   int              `json:"suggested_quantity"`
+	ProductStock        productStockView `json:"product_stock"`
+}
+
+type restockPrioritiesView struct {
+	ComputedAt time.Time             `json:"computed_at"`
+	Items      []restockPriorityView `json:"items"`
+	NextCursor *string               `json:"next_cursor"`
+	Total      *int                  `json:"total,omitempty"`
+}
+
+func toRestockPrioritiesView(priorities *usecases.RestockPriorities) restockPrioritiesView {
+	view := restockPrioritiesView{
+		ComputedAt: priorities.ComputedAt,
+		Items:      make([]restockPriorityView, len(priorities.Items)),
+		NextCursor: optionalCursor(priorities.NextCursor),
+		Total:      priorities.Total,
+	}
+
+	for i, priority := range priorities.Items {
+		view.Items[i] = restockPriorityView{
+			ExpectedConsumption: priority.ExpectedConsumption,
+			ProjectedStock:      priority.ProjectedStock,
+			IsRepositionNeeded:  priority.IsRepositionNeeded,
+			UrgencyScore:        priority.UrgencyScore,
+			SuggestedQuantity:   priority.SuggestedQuantity,
+			ProductStock:        toProductStockView(priority.ProductStock),
+		}
+	}
+
+	return view
+}
+
+type importRowView struct {
+	Row    int      `json:"row"`
+	Action string   `json:"action"`
+	ID     *string  `json:"id"`
+	SKU    *string  `json:"sku"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type importReportView struct {
+	DryRun    bool            `json:"dry_run"`
+	Committed bool            `json:"committed"`
+	Created   int             `json:"created"`
+	Updated   int             `json:"updated"`
+	Failed    int             `json:"failed"`
+	Rows      []importRowView `json:"rows"`
+}
+
+func toImportReportView(report *usecases.ImportReport) importReportView {
+	view := importReportView{
+		DryRun:    report.DryRun,
+		Committed: report.Committed,
+		Created:   report.Created,
+		Updated:   report.Updated,
+		Failed:    report.Failed,
+		Rows:      make([]importRowView, len(report.Rows)),
+	}
+
+	for i, r := range report.Rows {
+		view.Rows[i] = importRowView{
+			Row:    r.Row,
+			Action: string(r.Action),
+			ID:     r.ID,
+			SKU:    r.SKU,
+			Errors: r.Errors,
+		}
+	}
+
+	return view
+}
+
+func optionalCursor(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+
+	return &cursor
+}
+
+func optionalText(s *string) string {
+	if s == nil {
+		return "-"
+	}
+
+	return *s
+}
+
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 2, 64)
+}
+
+// The CSV exports have the columns of the HTTP exports.
+
+var productStockCSVHeader = []string{
+	"id", "sku", "name", "category", "current_stock", "minimum_stock",
+	"average_daily_sales", "lead_time_days", "unit_cost", "criticality_level", "barcodes",
+}
+
+func productStockCSVRow(p *entities.ProductStock) []string {
+	view := toProductStockView(p)
+
+	sku := ""
+	if view.SKU != nil {
+		sku = *view.SKU
+	}
+
+	return []string{
+		view.ID,
+		sku,
+		csvText(view.Name),
+		view.Category,
+		strconv.Itoa(view.CurrentStock),
+		strconv.Itoa(view.MinimumStock),
+		strconv.Itoa(view.AverageDailySales),
+		strconv.Itoa(view.LeadTimeDays),
+		strconv.FormatFloat(view.UnitCost, 'f', -1, 64),
+		strconv.Itoa(view.CriticalityLevel),
+		strings.Join(view.Barcodes, "|"),
+	}
+}
+
+var deletedProductStockCSVHeader = append(slices.Clone(productStockCSVHeader), "deleted_at")
+
+func deletedProductStockCSVRow(p *entities.ProductStock) []string {
+	deletedAt := ""
+	if p.DeletedAt != nil {
+		deletedAt = p.DeletedAt.UTC().Format(time.RFC3339)
+	}
+
+	return append(productStockCSVRow(p), deletedAt)
+}
+
+var restockPriorityCSVHeader = slices.Concat(productStockCSVHeader, []string{
+	"expected_consumption", "projected_stock", "urgency_score", "suggested_quantity",
+})
+
+func restockPriorityCSVRow(priority restock.Priority) []string {
+	return append(productStockCSVRow(priority.ProductStock),
+		strconv.Itoa(priority.ExpectedConsumption),
+		strconv.Itoa(priority.ProjectedStock),
+		strconv.Itoa(priority.UrgencyScore),
+		strconv.Itoa(priority.SuggestedQuantity),
+	)
+}
+
+// csvText keeps spreadsheets from evaluating free text as a formula.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// csvExport is given to the export use cases as their yield function. The
+// header is written with the first page, so an export failing before it
+// writes nothing; writing no items writes the header of an empty export.
+type csvExport[T any] struct {
+	w       *csv.Writer
+	header  []string
+	row     func(T) []string
+	started bool
+}
+
+func newCSVExport[T any](w io.Writer, header []string, row func(T) []string) *csvExport[T] {
+	return &csvExport[T]{w: csv.NewWriter(w), header: header, row: row}
+}
+
+func (e *csvExport[T]) write(items []T) *domain.Error {
+	if !e.started {
+		e.started = true
+		e.w.Write(e.header)
+	}
+
+	for _, item := range items {
+		e.w.Write(e.row(item))
+	}
+
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return domain.NewError("failed to write export: "+err.Error(), domain.ErrInternal)
+	}
+
+	return nil
+}
+
+func readCSV(r io.Reader) ([]string, [][]string, *domain.Error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, domain.NewError("invalid CSV: "+err.Error(), domain.ErrBadRequest)
+	}
+
+	if len(records) == 0 {
+		return nil, nil, domain.NewError("the file is empty", domain.ErrBadRequest)
+	}
+
+	return records[0], records[1:], nil
+}
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	_ "embed"
+	"log"
+	"net/http"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/gin-gonic/gin"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds the nesting of queries, which could otherwise make a
+// single request arbitrarily expensive.
+const maxDepth = 10
+
+type GraphQLApp struct {
+	gin *gin.Engine
+}
+
+func (g GraphQLApp) Run() {
+	if err := g.gin.Run(":8081"); err != nil {
+		log.Fatalf("failed to start graphql server: %v", err)
+	}
+}
+
+// NewGraphQLApp serves the schema at POST /graphql. It panics when the
+// resolvers do not match the schema.
+func NewGraphQLApp(resolver *Resolver, authUC *usecases.AuthenticateUseCase, tenantUC *usecases.ResolveTenantUseCase) GraphQLApp {
+	schema := gql.MustParseSchema(schemaSDL, resolver, gql.UseStringDescriptions(), gql.MaxDepth(maxDepth))
+
+	authorizer := requestAuthorizer{
+		authUC:   authUC,
+		tenantUC: tenantUC,
+	}
+
+	r := gin.Default()
+	r.POST("/graphql", authorizer.middleware, execute(schema))
+
+	return GraphQLApp{
+		gin: r,
+	}
+}
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// execute answers 200 even when the query fails, with the errors in the
+// response body, as GraphQL clients expect.
+func execute(schema *gql.Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req graphQLRequest
+		if err := c.ShouldBindJSON(&req); err != nil || req.Query == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": "body must be a JSON object with a query"}}})
+			return
+		}
+
+		ctx := withLoaders(c.Request.Context())
+
+		c.JSON(http.StatusOK, schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+	}
+}
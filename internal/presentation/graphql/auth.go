@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	apiKeyHeader        = "X-API-Key"
+	actorHeader         = "X-Actor"
+	tenantHeader        = "X-Tenant-ID"
+)
+
+// caller is who makes a request and the tenant it acts on.
+type caller struct {
+	principal *auth.Principal
+	tenant    tenant.Tenant
+}
+
+type callerKey struct{}
+
+// requestAuthorizer authenticates the caller of every request from the same
+// headers as the HTTP API and resolves its tenant. Any role may query; the
+// resolvers check the roles of mutations and restricted fields.
+type requestAuthorizer struct {
+	authUC   *usecases.AuthenticateUseCase
+	tenantUC *usecases.ResolveTenantUseCase
+}
+
+func (a requestAuthorizer) authorize(r *http.Request) (*caller, *domain.Error) {
+	principal, domainErr := a.authUC.Execute(usecases.AuthenticateDTO{
+		Authorization: r.Header.Get(authorizationHeader),
+		APIKey:        r.Header.Get(apiKeyHeader),
+		Actor:         r.Header.Get(actorHeader),
+	})
+	if domainErr != nil {
+		return nil, domainErr
+	}
+
+	if domainErr := auth.Authorize(principal, auth.Viewer); domainErr != nil {
+		return nil, domainErr
+	}
+
+	t, domainErr := a.tenantUC.Execute(usecases.ResolveTenantDTO{
+		Principal: principal,
+		Requested: r.Header.Get(tenantHeader),
+	})
+	if domainErr != nil {
+		return nil, domainErr
+	}
+
+	return &caller{principal: principal, tenant: *t}, nil
+}
+
+func (a requestAuthorizer) middleware(c *gin.Context) {
+	cl, domainErr := a.authorize(c.Request)
+	if domainErr != nil {
+		if domainErr.ErrCode == domain.ErrUnauthorized {
+			c.Header("WWW-Authenticate", auth.BearerScheme)
+		}
+
+		c.AbortWithStatusJSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"errors": []gin.H{{
+			"message":    domainErr.Message,
+			"extensions": resolverError{domainErr}.Extensions(),
+		}}})
+		return
+	}
+
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), callerKey{}, cl))
+	c.Next()
+}
+
+func requestCaller(ctx context.Context) *caller {
+	cl, _ := ctx.Value(callerKey{}).(*caller)
+	if cl == nil {
+		return &caller{}
+	}
+
+	return cl
+}
+
+func requestTenant(ctx context.Context) tenant.Tenant {
+	return requestCaller(ctx).tenant
+}
+
+// requestActor names who makes the request, as recorded in the audit log.
+func requestActor(ctx context.Context) string {
+	if principal := requestCaller(ctx).principal; principal != nil {
+		return principal.Subject
+	}
+
+	return ""
+}
+
+func requireRole(ctx context.Context, role auth.Role) error {
+	if domainErr := auth.Authorize(requestCaller(ctx).principal, role); domainErr != nil {
+		return toResolverError(domainErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+)
+
+// resolverError carries the code of a domain error in the extensions of the
+// GraphQL error, so clients can tell errors apart as they would by the HTTP
+// status.
+type resolverError struct {
+	err *domain.Error
+}
+
+func (e resolverError) Error() string {
+	return e.err.Message
+}
+
+func (e resolverError) Extensions() map[string]any {
+	return map[string]any{"code": mapErrorToCode(e.err.ErrCode)}
+}
+
+func toResolverError(domainErr *domain.Error) error {
+	return resolverError{domainErr}
+}
+
+func mapErrorToCode(errCode domain.ErrorCode) string {
+	switch errCode {
+	case domain.ErrNotFound:
+		return "NOT_FOUND"
+	case domain.ErrConflict:
+		return "CONFLICT"
+	case domain.ErrBadRequest:
+		return "BAD_REQUEST"
+	case domain.ErrUnprocessable:
+		return "UNPROCESSABLE"
+	case domain.ErrUnauthorized:
+		return "UNAUTHENTICATED"
+	case domain.ErrForbidden:
+		return "FORBIDDEN"
+	default:
+		return "INTERNAL"
+	}
+}
+
+func mapErrorToHTTPStatus(errCode domain.ErrorCode) int {
+	switch errCode {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrBadRequest:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
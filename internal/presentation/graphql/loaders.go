@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+)
+
+// Resolvers run concurrently, and a nested field is resolved once for every
+// item of the list holding it. The loaders below make the first resolver
+// asking for such a field read it for all the items in one query, which the
+// others then share, instead of one query per item.
+
+type loadersKey struct{}
+
+// loaders hold what is read once per request.
+type loaders struct {
+	countsOnce sync.Once
+	counts     map[entities.ProductCategory]int
+	countsErr  *domain.Error
+}
+
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{})
+}
+
+func requestLoaders(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	if l == nil {
+		return &loaders{}
+	}
+
+	return l
+}
+
+// categoryCounts counts the products of every category at the first call.
+func (l *loaders) categoryCounts(load func() (map[entities.ProductCategory]int, *domain.Error)) (map[entities.ProductCategory]int, *domain.Error) {
+	l.countsOnce.Do(func() {
+		l.counts, l.countsErr = load()
+	})
+
+	return l.counts, l.countsErr
+}
+
+// productBatch holds the ids of the products resolved by the same list.
+type productBatch struct {
+	ids []string
+
+	mu        sync.Mutex
+	histories map[int]*historyLoad
+}
+
+// historyLoad is the history of the batch for one limit, as the same field
+// may be selected with different arguments under aliases.
+type historyLoad struct {
+	once    sync.Once
+	entries map[string][]audit.Entry
+	err     *domain.Error
+}
+
+func newProductBatch(products []*entities.ProductStock) *productBatch {
+	batch := &productBatch{
+		ids:       make([]string, len(products)),
+		histories: map[int]*historyLoad{},
+	}
+
+	for i, p := range products {
+		batch.ids[i] = *p.ID
+	}
+
+	return batch
+}
+
+func (b *productBatch) history(limit int, load func(ids []string, limit int) (map[string][]audit.Entry, *domain.Error)) (map[string][]audit.Entry, *domain.Error) {
+	b.mu.Lock()
+	h, ok := b.histories[limit]
+	if !ok {
+		h = &historyLoad{}
+		b.histories[limit] = h
+	}
+	b.mu.Unlock()
+
+	h.once.Do(func() {
+		h.entries, h.err = load(b.ids, limit)
+	})
+
+	return h.entries, h.err
+}
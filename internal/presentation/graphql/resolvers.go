@@ -0,0 +1,390 @@
+package graphql
+
+import (
+	"context"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver resolves the queries and mutations of schema.graphql.
+type Resolver struct {
+	createUC          *usecases.CreateProductStockUseCase
+	getAllUC          *usecases.GetAllProductStockUseCase
+	getOneUC          *usecases.GetOneProductStockUseCase
+	updateUC          *usecases.UpdateProductStockUseCase
+	deleteUC          *usecases.DeleteProductStockUseCase
+	restoreUC         *usecases.RestoreProductStockUseCase
+	getByCategoryUC   *usecases.GetByCategoryProductStockUseCase
+	countByCategoryUC *usecases.CountByCategoryProductStockUseCase
+	getPriorityUC     *usecases.GetProductPriorityUseCase
+	historyUC         *usecases.GetProductStockHistoryUseCase
+}
+
+func NewResolver(
+	createUC *usecases.CreateProductStockUseCase,
+	getAllUC *usecases.GetAllProductStockUseCase,
+	getOneUC *usecases.GetOneProductStockUseCase,
+	updateUC *usecases.UpdateProductStockUseCase,
+	deleteUC *usecases.DeleteProductStockUseCase,
+	restoreUC *usecases.RestoreProductStockUseCase,
+	getByCategoryUC *usecases.GetByCategoryProductStockUseCase,
+	countByCategoryUC *usecases.CountByCategoryProductStockUseCase,
+	getPriorityUC *usecases.GetProductPriorityUseCase,
+	historyUC *usecases.GetProductStockHistoryUseCase,
+) *Resolver {
+	return &Resolver{
+		createUC:          createUC,
+		getAllUC:          getAllUC,
+		getOneUC:          getOneUC,
+		updateUC:          updateUC,
+		deleteUC:          deleteUC,
+		restoreUC:         restoreUC,
+		getByCategoryUC:   getByCategoryUC,
+		countByCategoryUC: countByCategoryUC,
+		getPriorityUC:     getPriorityUC,
+		historyUC:         historyUC,
+	}
+}
+
+type productFilterInput struct {
+	NameContains   *string
+	Categories     *[]string
+	CriticalityMin *int32
+	CriticalityMax *int32
+	UnitCostMin    *float64
+	UnitCostMax    *float64
+	StockMin       *int32
+	StockMax       *int32
+	BelowMinimum   *bool
+	NeedsRestock   *bool
+	IncludeDeleted bool
+	Sort           *string
+}
+
+type externalIDInput struct {
+	System string
+	ID     string
+}
+
+type createProductInput struct {
+	Name              string
+	Category          string
+	CurrentStock      int32
+	MinimumStock      int32
+	AverageDailySales int32
+	LeadTimeDays      int32
+	UnitCost          float64
+	CriticalityLevel  int32
+	SKU               *string
+	Barcodes          *[]string
+	ExternalIDs       *[]externalIDInput
+}
+
+type updateProductInput struct {
+	CurrentStock      *int32
+	MinimumStock      *int32
+	AverageDailySales *int32
+	LeadTimeDays      *int32
+	UnitCost          *float64
+	CriticalityLevel  *int32
+	SKU               *string
+	Barcodes          *[]string
+	ExternalIDs       *[]externalIDInput
+}
+
+func (r *Resolver) Product(ctx context.Context, args struct {
+	ID             gql.ID
+	IncludeDeleted bool
+}) (*productResolver, error) {
+	if args.IncludeDeleted {
+		if err := requireRole(ctx, auth.Admin); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.getProduct(ctx, string(args.ID), args.IncludeDeleted)
+}
+
+func (r *Resolver) getProduct(ctx context.Context, id string, includeDeleted bool) (*productResolver, error) {
+	p, domainErr := r.getOneUC.Execute(usecases.GetOneProductStockDTO{
+		Tenant:         requestTenant(ctx),
+		ID:             id,
+		IncludeDeleted: includeDeleted,
+	})
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	return r.newProductResolvers([]*entities.ProductStock{p})[0], nil
+}
+
+func (r *Resolver) Products(ctx context.Context, args struct {
+	Filter *productFilterInput
+	First  *int32
+	After  *string
+	Page   *int32
+}) (*productConnectionResolver, error) {
+	filter := toProductStockFilterDTO(args.Filter)
+	if filter.IncludeDeleted {
+		if err := requireRole(ctx, auth.Admin); err != nil {
+			return nil, err
+		}
+	}
+
+	page, domainErr := r.getAllUC.Execute(usecases.GetAllProductStockDTO{
+		Tenant:     requestTenant(ctx),
+		Filter:     filter,
+		Pagination: toPagination(ctx, args.First, args.After, args.Page),
+	})
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	return r.newProductConnection(page), nil
+}
+
+func (r *Resolver) ProductsByCategory(ctx context.Context, args struct {
+	Category string
+	First    *int32
+	After    *string
+	Page     *int32
+}) (*productConnectionResolver, error) {
+	page, domainErr := r.getByCategoryUC.Execute(usecases.GetByCategoryDTO{
+		Tenant:     requestTenant(ctx),
+		Category:   args.Category,
+		Pagination: toPagination(ctx, args.First, args.After, args.Page),
+	})
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	return r.newProductConnection(page), nil
+}
+
+func (r *Resolver) Categories(ctx context.Context) []*categoryResolver {
+	categories := requestTenant(ctx).Categories
+
+	result := make([]*categoryResolver, len(categories))
+	for i, category := range categories {
+		result[i] = &categoryResolver{root: r, name: category}
+	}
+
+	return result
+}
+
+func (r *Resolver) RestockPriorities(ctx context.Context, args struct {
+	First *int32
+	After *string
+	Page  *int32
+}) (*restockPriorityConnectionResolver, error) {
+	priorities, domainErr := r.getPriorityUC.Execute(requestTenant(ctx), toPagination(ctx, args.First, args.After, args.Page))
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	products := make([]*entities.ProductStock, len(priorities.Items))
+	for i, priority := range priorities.Items {
+		products[i] = priority.ProductStock
+	}
+
+	productResolvers := r.newProductResolvers(products)
+
+	connection := &restockPriorityConnectionResolver{
+		nodes:      make([]*restockPriorityResolver, len(priorities.Items)),
+		pageInfo:   &pageInfoResolver{endCursor: priorities.NextCursor},
+		total:      priorities.Total,
+		computedAt: priorities.ComputedAt,
+	}
+	for i, priority := range priorities.Items {
+		connection.nodes[i] = &restockPriorityResolver{
+			restockProjectionResolver: restockProjectionResolver{priority.Projection},
+			product:                   productResolvers[i],
+		}
+	}
+
+	return connection, nil
+}
+
+func (r *Resolver) CreateProduct(ctx context.Context, args struct {
+	Input createProductInput
+}) (*productResolver, error) {
+	if err := requireRole(ctx, auth.Clerk); err != nil {
+		return nil, err
+	}
+
+	in := args.Input
+	dto := usecases.CreateProductStockDTO{
+		Tenant:            requestTenant(ctx),
+		Name:              in.Name,
+		Category:          in.Category,
+		CurrentStock:      int(in.CurrentStock),
+		MinimumStock:      int(in.MinimumStock),
+		AverageDailySales: int(in.AverageDailySales),
+		LeadTimeDays:      int(in.LeadTimeDays),
+		UnitCost:          in.UnitCost,
+		CriticalityLevel:  int(in.CriticalityLevel),
+		SKU:               in.SKU,
+		Actor:             requestActor(ctx),
+	}
+	if in.Barcodes != nil {
+		dto.Barcodes = *in.Barcodes
+	}
+	if in.ExternalIDs != nil {
+		dto.ExternalIDs = toExternalIDs(*in.ExternalIDs)
+	}
+
+	id, domainErr := r.createUC.Execute(dto)
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	return r.getProduct(ctx, id, false)
+}
+
+func (r *Resolver) UpdateProduct(ctx context.Context, args struct {
+	ID    gql.ID
+	Input updateProductInput
+}) (*productResolver, error) {
+	if err := requireRole(ctx, auth.Clerk); err != nil {
+		return nil, err
+	}
+
+	in := args.Input
+	dto := usecases.UpdateProductStockDTO{
+		Tenant:            requestTenant(ctx),
+		ID:                string(args.ID),
+		CurrentStock:      optionalInt(in.CurrentStock),
+		MinimumStock:      optionalInt(in.MinimumStock),
+		AverageDailySales: optionalInt(in.AverageDailySales),
+		LeadTimeDays:      optionalInt(in.LeadTimeDays),
+		UnitCost:          in.UnitCost,
+		CriticalityLevel:  optionalInt(in.CriticalityLevel),
+		SKU:               in.SKU,
+		Barcodes:          in.Barcodes,
+		Actor:             requestActor(ctx),
+	}
+	if in.ExternalIDs != nil {
+		externalIDs := toExternalIDs(*in.ExternalIDs)
+		dto.ExternalIDs = &externalIDs
+	}
+
+	if domainErr := r.updateUC.Execute(dto); domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	return r.getProduct(ctx, string(args.ID), false)
+}
+
+func (r *Resolver) DeleteProduct(ctx context.Context, args struct {
+	ID gql.ID
+}) (gql.ID, error) {
+	if err := requireRole(ctx, auth.Admin); err != nil {
+		return "", err
+	}
+
+	domainErr := r.deleteUC.Execute(usecases.DeleteProductStockDTO{
+		Tenant: requestTenant(ctx),
+		ID:     string(args.ID),
+		Actor:  requestActor(ctx),
+	})
+	if domainErr != nil {
+		return "", toResolverError(domainErr)
+	}
+
+	return args.ID, nil
+}
+
+func (r *Resolver) RestoreProduct(ctx context.Context, args struct {
+	ID gql.ID
+}) (*productResolver, error) {
+	if err := requireRole(ctx, auth.Admin); err != nil {
+		return nil, err
+	}
+
+	p, domainErr := r.restoreUC.Execute(usecases.RestoreProductStockDTO{
+		Tenant: requestTenant(ctx),
+		ID:     string(args.ID),
+		Actor:  requestActor(ctx),
+	})
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	return r.newProductResolvers([]*entities.ProductStock{p})[0], nil
+}
+
+// toPagination reads the connection arguments. The total is only counted
+// when the query selects it.
+func toPagination(ctx context.Context, first *int32, after *string, page *int32) domain.Pagination {
+	pagination := domain.Pagination{
+		IncludeTotal: gql.HasSelectedField(ctx, "totalCount"),
+	}
+
+	if first != nil {
+		pagination.Limit = int(*first)
+	}
+
+	if after != nil {
+		pagination.Cursor = *after
+	}
+
+	if page != nil {
+		pagination.Page = int(*page)
+	}
+
+	return pagination
+}
+
+func toProductStockFilterDTO(in *productFilterInput) usecases.ProductStockFilterDTO {
+	if in == nil {
+		return usecases.ProductStockFilterDTO{}
+	}
+
+	filter := usecases.ProductStockFilterDTO{
+		MinCriticality: optionalInt(in.CriticalityMin),
+		MaxCriticality: optionalInt(in.CriticalityMax),
+		MinUnitCost:    in.UnitCostMin,
+		MaxUnitCost:    in.UnitCostMax,
+		MinStock:       optionalInt(in.StockMin),
+		MaxStock:       optionalInt(in.StockMax),
+		BelowMinimum:   in.BelowMinimum,
+		NeedsRestock:   in.NeedsRestock,
+		IncludeDeleted: in.IncludeDeleted,
+	}
+
+	if in.NameContains != nil {
+		filter.NameContains = *in.NameContains
+	}
+
+	if in.Categories != nil {
+		filter.Categories = *in.Categories
+	}
+
+	if in.Sort != nil {
+		filter.Sort = *in.Sort
+	}
+
+	return filter
+}
+
+func toExternalIDs(in []externalIDInput) map[string]string {
+	externalIDs := make(map[string]string, len(in))
+	for _, externalID := range in {
+		externalIDs[externalID.System] = externalID.ID
+	}
+
+	return externalIDs
+}
+
+func optionalInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+
+	i := int(*v)
+	return &i
+}
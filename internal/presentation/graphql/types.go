@@ -0,0 +1,338 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type productResolver struct {
+	root  *Resolver
+	p     *entities.ProductStock
+	batch *productBatch
+}
+
+// newProductResolvers puts the products in the same batch, so a nested
+// field read from the database is read for all of them at once.
+func (r *Resolver) newProductResolvers(products []*entities.ProductStock) []*productResolver {
+	batch := newProductBatch(products)
+
+	result := make([]*productResolver, len(products))
+	for i, p := range products {
+		result[i] = &productResolver{root: r, p: p, batch: batch}
+	}
+
+	return result
+}
+
+func (p *productResolver) ID() gql.ID {
+	return gql.ID(*p.p.ID)
+}
+
+func (p *productResolver) Name() string {
+	return p.p.Name
+}
+
+func (p *productResolver) Category() *categoryResolver {
+	return &categoryResolver{root: p.root, name: p.p.Category}
+}
+
+func (p *productResolver) CurrentStock() int32 {
+	return int32(p.p.CurrentStock)
+}
+
+func (p *productResolver) MinimumStock() int32 {
+	return int32(p.p.MinimumStock)
+}
+
+func (p *productResolver) AverageDailySales() int32 {
+	return int32(p.p.AverageDailySales)
+}
+
+func (p *productResolver) LeadTimeDays() int32 {
+	return int32(p.p.LeadTimeDays)
+}
+
+func (p *productResolver) UnitCost() float64 {
+	return p.p.UnitCost
+}
+
+func (p *productResolver) CriticalityLevel() int32 {
+	return int32(p.p.CriticalityLevel)
+}
+
+func (p *productResolver) SKU() *string {
+	return p.p.Identifiers.SKU
+}
+
+func (p *productResolver) Barcodes() []string {
+	if p.p.Identifiers.Barcodes == nil {
+		return []string{}
+	}
+
+	return p.p.Identifiers.Barcodes
+}
+
+// ExternalIDs are sorted by system, so responses do not depend on map order.
+func (p *productResolver) ExternalIDs() []*externalIDResolver {
+	result := []*externalIDResolver{}
+	for system, id := range p.p.Identifiers.ExternalIDs {
+		result = append(result, &externalIDResolver{system: system, id: id})
+	}
+
+	slices.SortFunc(result, func(a, b *externalIDResolver) int {
+		return strings.Compare(a.system, b.system)
+	})
+
+	return result
+}
+
+func (p *productResolver) DeletedAt() *gql.Time {
+	if p.p.DeletedAt == nil {
+		return nil
+	}
+
+	return &gql.Time{Time: *p.p.DeletedAt}
+}
+
+func (p *productResolver) Restock() *restockProjectionResolver {
+	return &restockProjectionResolver{restock.Project(p.p)}
+}
+
+func (p *productResolver) History(ctx context.Context, args struct {
+	First int32
+}) ([]*auditEntryResolver, error) {
+	if err := requireRole(ctx, auth.Admin); err != nil {
+		return nil, err
+	}
+
+	history, domainErr := p.batch.history(int(args.First), func(ids []string, limit int) (map[string][]audit.Entry, *domain.Error) {
+		return p.root.historyUC.Execute(usecases.GetProductStockHistoryDTO{
+			Tenant:     requestTenant(ctx),
+			ProductIDs: ids,
+			Limit:      limit,
+		})
+	})
+	if domainErr != nil {
+		return nil, toResolverError(domainErr)
+	}
+
+	entries := history[*p.p.ID]
+
+	result := make([]*auditEntryResolver, len(entries))
+	for i, entry := range entries {
+		result[i] = &auditEntryResolver{entry}
+	}
+
+	return result, nil
+}
+
+type externalIDResolver struct {
+	system string
+	id     string
+}
+
+func (e *externalIDResolver) System() string {
+	return e.system
+}
+
+func (e *externalIDResolver) ID() string {
+	return e.id
+}
+
+type categoryResolver struct {
+	root *Resolver
+	name entities.ProductCategory
+}
+
+func (c *categoryResolver) Name() string {
+	return string(c.name)
+}
+
+func (c *categoryResolver) ProductCount(ctx context.Context) (int32, error) {
+	counts, domainErr := requestLoaders(ctx).categoryCounts(func() (map[entities.ProductCategory]int, *domain.Error) {
+		return c.root.countByCategoryUC.Execute(requestTenant(ctx))
+	})
+	if domainErr != nil {
+		return 0, toResolverError(domainErr)
+	}
+
+	return int32(counts[c.name]), nil
+}
+
+type restockProjectionResolver struct {
+	projection restock.Projection
+}
+
+func (r *restockProjectionResolver) ExpectedConsumption() int32 {
+	return int32(r.projection.ExpectedConsumption)
+}
+
+func (r *restockProjectionResolver) ProjectedStock() int32 {
+	return int32(r.projection.ProjectedStock)
+}
+
+func (r *restockProjectionResolver) IsRepositionNeeded() bool {
+	return r.projection.IsRepositionNeeded
+}
+
+func (r *restockProjectionResolver) UrgencyScore() int32 {
+	return int32(r.projection.UrgencyScore)
+}
+
+func (r *restockProjectionResolver) SuggestedQuantity() int32 {
+	return int32(r.projection.SuggestedQuantity)
+}
+
+type restockPriorityResolver struct {
+	restockProjectionResolver
+	product *productResolver
+}
+
+func (r *restockPriorityResolver) Product() *productResolver {
+	return r.product
+}
+
+type auditEntryResolver struct {
+	entry audit.Entry
+}
+
+func (a *auditEntryResolver) ID() gql.ID {
+	return gql.ID(strconv.FormatInt(a.entry.ID, 10))
+}
+
+func (a *auditEntryResolver) Actor() string {
+	return a.entry.Actor
+}
+
+func (a *auditEntryResolver) Operation() string {
+	return string(a.entry.Operation)
+}
+
+func (a *auditEntryResolver) OccurredAt() gql.Time {
+	return gql.Time{Time: a.entry.OccurredAt}
+}
+
+func (a *auditEntryResolver) Changes() []*fieldChangeResolver {
+	result := make([]*fieldChangeResolver, len(a.entry.Changes))
+	for i, change := range a.entry.Changes {
+		result[i] = &fieldChangeResolver{change}
+	}
+
+	return result
+}
+
+type fieldChangeResolver struct {
+	change audit.FieldChange
+}
+
+func (f *fieldChangeResolver) Field() string {
+	return f.change.Field
+}
+
+func (f *fieldChangeResolver) Before() *string {
+	return encodeValue(f.change.Before)
+}
+
+func (f *fieldChangeResolver) After() *string {
+	return encodeValue(f.change.After)
+}
+
+func encodeValue(v any) *string {
+	if v == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	s := string(encoded)
+	return &s
+}
+
+type pageInfoResolver struct {
+	endCursor string
+}
+
+func (p *pageInfoResolver) HasNextPage() bool {
+	return p.endCursor != ""
+}
+
+func (p *pageInfoResolver) EndCursor() *string {
+	if p.endCursor == "" {
+		return nil
+	}
+
+	return &p.endCursor
+}
+
+type productConnectionResolver struct {
+	nodes    []*productResolver
+	pageInfo *pageInfoResolver
+	total    *int
+}
+
+func (r *Resolver) newProductConnection(page *domain.Page[*entities.ProductStock]) *productConnectionResolver {
+	return &productConnectionResolver{
+		nodes:    r.newProductResolvers(page.Items),
+		pageInfo: &pageInfoResolver{endCursor: page.NextCursor},
+		total:    page.Total,
+	}
+}
+
+func (c *productConnectionResolver) Nodes() []*productResolver {
+	return c.nodes
+}
+
+func (c *productConnectionResolver) PageInfo() *pageInfoResolver {
+	return c.pageInfo
+}
+
+func (c *productConnectionResolver) TotalCount() *int32 {
+	return optionalInt32(c.total)
+}
+
+type restockPriorityConnectionResolver struct {
+	nodes      []*restockPriorityResolver
+	pageInfo   *pageInfoResolver
+	total      *int
+	computedAt time.Time
+}
+
+func (c *restockPriorityConnectionResolver) Nodes() []*restockPriorityResolver {
+	return c.nodes
+}
+
+func (c *restockPriorityConnectionResolver) PageInfo() *pageInfoResolver {
+	return c.pageInfo
+}
+
+func (c *restockPriorityConnectionResolver) TotalCount() *int32 {
+	return optionalInt32(c.total)
+}
+
+func (c *restockPriorityConnectionResolver) ComputedAt() gql.Time {
+	return gql.Time{Time: c.computedAt}
+}
+
+func optionalInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+
+	i := int32(*v)
+	return &i
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"log"
+	"net"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/grpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type GrpcApp struct {
+	server *grpc.Server
+}
+
+func (g GrpcApp) Run() {
+	listener, err := net.Listen("tcp", ":9090")
+	if err != nil {
+		log.Fatalf("failed to listen for grpc: %v", err)
+	}
+
+	if err := g.server.Serve(listener); err != nil {
+		log.Fatalf("failed to start grpc server: %v", err)
+	}
+}
+
+// NewGrpcApp serves the product stock service, with server reflection so
+// tools like grpcurl can list it.
+func NewGrpcApp(server *ProductStockServer, authUC *usecases.AuthenticateUseCase, tenantUC *usecases.ResolveTenantUseCase) GrpcApp {
+	authorizer := callAuthorizer{
+		authUC:   authUC,
+		tenantUC: tenantUC,
+	}
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authorizer.unaryInterceptor),
+		grpc.StreamInterceptor(authorizer.streamInterceptor),
+	)
+	pb.RegisterProductStockServiceServer(s, server)
+	reflection.Register(s)
+
+	return GrpcApp{
+		server: s,
+	}
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/grpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationMetadata = "authorization"
+	apiKeyMetadata        = "x-api-key"
+	actorMetadata         = "x-actor"
+	tenantMetadata        = "x-tenant-id"
+)
+
+// methodRoles is the role each method requires, matching the HTTP routes.
+// Methods missing from it require admin.
+var methodRoles = map[string]auth.Role{
+	pb.ProductStockService_CreateProductStock_FullMethodName:          auth.Clerk,
+	pb.ProductStockService_GetProductStock_FullMethodName:             auth.Viewer,
+	pb.ProductStockService_ListProductStocks_FullMethodName:           auth.Viewer,
+	pb.ProductStockService_StreamProductStocks_FullMethodName:         auth.Viewer,
+	pb.ProductStockService_UpdateProductStock_FullMethodName:          auth.Clerk,
+	pb.ProductStockService_DeleteProductStock_FullMethodName:          auth.Admin,
+	pb.ProductStockService_ListProductStocksByCategory_FullMethodName: auth.Viewer,
+	pb.ProductStockService_GetRestockPriorities_FullMethodName:        auth.Viewer,
+	pb.ProductStockService_StreamRestockPriorities_FullMethodName:     auth.Viewer,
+}
+
+type principalKey struct{}
+
+type tenantKey struct{}
+
+// callAuthorizer authenticates the caller of every call to the product stock
+// service from its metadata, checks the role the method requires and
+// resolves the tenant of the call. The other services, server reflection,
+// are open like the Swagger UI.
+type callAuthorizer struct {
+	authUC   *usecases.AuthenticateUseCase
+	tenantUC *usecases.ResolveTenantUseCase
+}
+
+func (a callAuthorizer) authorize(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	principal, domainErr := a.authUC.Execute(usecases.AuthenticateDTO{
+		Authorization: firstMetadata(md, authorizationMetadata),
+		APIKey:        firstMetadata(md, apiKeyMetadata),
+		Actor:         firstMetadata(md, actorMetadata),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	role, ok := methodRoles[method]
+	if !ok {
+		role = auth.Admin
+	}
+
+	if domainErr := auth.Authorize(principal, role); domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	t, domainErr := a.tenantUC.Execute(usecases.ResolveTenantDTO{
+		Principal: principal,
+		Requested: firstMetadata(md, tenantMetadata),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return context.WithValue(ctx, tenantKey{}, *t), nil
+}
+
+func isProductStockMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+pb.ProductStockService_ServiceDesc.ServiceName+"/")
+}
+
+func (a callAuthorizer) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !isProductStockMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a callAuthorizer) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !isProductStockMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorizedStream carries the context holding the caller and the tenant to
+// the stream handlers.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func callPrincipal(ctx context.Context) *auth.Principal {
+	p, _ := ctx.Value(principalKey{}).(*auth.Principal)
+	return p
+}
+
+// callActor names who makes the call, as recorded in the audit log.
+func callActor(ctx context.Context) string {
+	if principal := callPrincipal(ctx); principal != nil {
+		return principal.Subject
+	}
+
+	return ""
+}
+
+func callTenant(ctx context.Context) tenant.Tenant {
+	t, _ := ctx.Value(tenantKey{}).(tenant.Tenant)
+	return t
+}
+
+// authorizeIncludeDeleted lets only admins see deleted products.
+func authorizeIncludeDeleted(ctx context.Context, includeDeleted bool) error {
+	if !includeDeleted {
+		return nil
+	}
+
+	if domainErr := auth.Authorize(callPrincipal(ctx), auth.Admin); domainErr != nil {
+		return toStatusError(domainErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package grpc
+
+import (
+	"context"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/presentation/grpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ProductStockServer struct {
+	pb.UnimplementedProductStockServiceServer
+
+	createUC        *usecases.CreateProductStockUseCase
+	getAllUC        *usecases.GetAllProductStockUseCase
+	getOneUC        *usecases.GetOneProductStockUseCase
+	updateUC        *usecases.UpdateProductStockUseCase
+	deleteUC        *usecases.DeleteProductStockUseCase
+	getByCategoryUC *usecases.GetByCategoryProductStockUseCase
+	getPriorityUC   *usecases.GetProductPriorityUseCase
+	exportUC        *usecases.ExportProductStockUseCase
+	exportRestockUC *usecases.ExportRestockPrioritiesUseCase
+}
+
+func NewProductStockServer(
+	createUC *usecases.CreateProductStockUseCase,
+	getAllUC *usecases.GetAllProductStockUseCase,
+	getOneUC *usecases.GetOneProductStockUseCase,
+	updateUC *usecases.UpdateProductStockUseCase,
+	deleteUC *usecases.DeleteProductStockUseCase,
+	getByCategoryUC *usecases.GetByCategoryProductStockUseCase,
+	getPriorityUC *usecases.GetProductPriorityUseCase,
+	exportUC *usecases.ExportProductStockUseCase,
+	exportRestockUC *usecases.ExportRestockPrioritiesUseCase,
+) *ProductStockServer {
+	return &ProductStockServer{
+		createUC:        createUC,
+		getAllUC:        getAllUC,
+		getOneUC:        getOneUC,
+		updateUC:        updateUC,
+		deleteUC:        deleteUC,
+		getByCategoryUC: getByCategoryUC,
+		getPriorityUC:   getPriorityUC,
+		exportUC:        exportUC,
+		exportRestockUC: exportRestockUC,
+	}
+}
+
+func mapErrorToStatusCode(errCode domain.ErrorCode) codes.Code {
+	switch errCode {
+	case domain.ErrNotFound:
+		return codes.NotFound
+	case domain.ErrConflict:
+		return codes.AlreadyExists
+	case domain.ErrBadRequest:
+		return codes.InvalidArgument
+	case domain.ErrInternal:
+		return codes.Internal
+	case domain.ErrUnprocessable:
+		return codes.FailedPrecondition
+	case domain.ErrUnauthorized:
+		return codes.Unauthenticated
+	case domain.ErrForbidden:
+		return codes.PermissionDenied
+	default:
+		return codes.Internal
+	}
+}
+
+func toStatusError(domainErr *domain.Error) error {
+	return status.Error(mapErrorToStatusCode(domainErr.ErrCode), domainErr.Message)
+}
+
+func (s *ProductStockServer) CreateProductStock(ctx context.Context, req *pb.CreateProductStockRequest) (*pb.CreateProductStockResponse, error) {
+	id, domainErr := s.createUC.Execute(usecases.CreateProductStockDTO{
+		Tenant:            callTenant(ctx),
+		Name:              req.GetName(),
+		Category:          req.GetCategory(),
+		CurrentStock:      int(req.GetCurrentStock()),
+		MinimumStock:      int(req.GetMinimumStock()),
+		AverageDailySales: int(req.GetAverageDailySales()),
+		LeadTimeDays:      int(req.GetLeadTimeDays()),
+		UnitCost:          req.GetUnitCost(),
+		CriticalityLevel:  int(req.GetCriticalityLevel()),
+		SKU:               req.Sku,
+		Barcodes:          req.GetBarcodes(),
+		ExternalIDs:       req.GetExternalIds(),
+		Actor:             callActor(ctx),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	return &pb.CreateProductStockResponse{Id: id}, nil
+}
+
+func (s *ProductStockServer) GetProductStock(ctx context.Context, req *pb.GetProductStockRequest) (*pb.ProductStock, error) {
+	if err := authorizeIncludeDeleted(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	product, domainErr := s.getOneUC.Execute(usecases.GetOneProductStockDTO{
+		Tenant:         callTenant(ctx),
+		ID:             req.GetId(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	return toProductStockMessage(product), nil
+}
+
+func (s *ProductStockServer) ListProductStocks(ctx context.Context, req *pb.ListProductStocksRequest) (*pb.ProductStockPage, error) {
+	if err := authorizeIncludeDeleted(ctx, req.GetFilter().GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	page, domainErr := s.getAllUC.Execute(usecases.GetAllProductStockDTO{
+		Tenant:     callTenant(ctx),
+		Filter:     toProductStockFilterDTO(req.GetFilter()),
+		Pagination: toPagination(req.GetPagination()),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	return toProductStockPageMessage(page), nil
+}
+
+func (s *ProductStockServer) StreamProductStocks(req *pb.StreamProductStocksRequest, stream pb.ProductStockService_StreamProductStocksServer) error {
+	ctx := stream.Context()
+	if err := authorizeIncludeDeleted(ctx, req.GetFilter().GetIncludeDeleted()); err != nil {
+		return err
+	}
+
+	var sendErr error
+	domainErr := s.exportUC.Execute(callTenant(ctx), toProductStockFilterDTO(req.GetFilter()), func(items []*entities.ProductStock) *domain.Error {
+		for _, p := range items {
+			if sendErr = stream.Send(toProductStockMessage(p)); sendErr != nil {
+				return domain.NewError("failed to send product stock", domain.ErrInternal)
+			}
+		}
+
+		return nil
+	})
+
+	return streamError(sendErr, domainErr)
+}
+
+func (s *ProductStockServer) UpdateProductStock(ctx context.Context, req *pb.UpdateProductStockRequest) (*emptypb.Empty, error) {
+	dto := usecases.UpdateProductStockDTO{
+		Tenant:            callTenant(ctx),
+		ID:                req.GetId(),
+		CurrentStock:      optionalInt(req.CurrentStock),
+		MinimumStock:      optionalInt(req.MinimumStock),
+		AverageDailySales: optionalInt(req.AverageDailySales),
+		LeadTimeDays:      optionalInt(req.LeadTimeDays),
+		UnitCost:          req.UnitCost,
+		CriticalityLevel:  optionalInt(req.CriticalityLevel),
+		SKU:               req.Sku,
+		Actor:             callActor(ctx),
+	}
+
+	if req.Barcodes != nil {
+		barcodes := req.Barcodes.GetValues()
+		dto.Barcodes = &barcodes
+	}
+
+	if req.ExternalIds != nil {
+		externalIDs := req.ExternalIds.GetValues()
+		dto.ExternalIDs = &externalIDs
+	}
+
+	if domainErr := s.updateUC.Execute(dto); domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ProductStockServer) DeleteProductStock(ctx context.Context, req *pb.DeleteProductStockRequest) (*emptypb.Empty, error) {
+	domainErr := s.deleteUC.Execute(usecases.DeleteProductStockDTO{
+		Tenant: callTenant(ctx),
+		ID:     req.GetId(),
+		Actor:  callActor(ctx),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ProductStockServer) ListProductStocksByCategory(ctx context.Context, req *pb.ListProductStocksByCategoryRequest) (*pb.ProductStockPage, error) {
+	page, domainErr := s.getByCategoryUC.Execute(usecases.GetByCategoryDTO{
+		Tenant:     callTenant(ctx),
+		Category:   req.GetCategory(),
+		Pagination: toPagination(req.GetPagination()),
+	})
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	return toProductStockPageMessage(page), nil
+}
+
+func (s *ProductStockServer) GetRestockPriorities(ctx context.Context, req *pb.GetRestockPrioritiesRequest) (*pb.RestockPriorityPage, error) {
+	priorities, domainErr := s.getPriorityUC.Execute(callTenant(ctx), toPagination(req.GetPagination()))
+	if domainErr != nil {
+		return nil, toStatusError(domainErr)
+	}
+
+	response := &pb.RestockPriorityPage{
+		Items:      make([]*pb.RestockPriority, len(priorities.Items)),
+		NextCursor: priorities.NextCursor,
+		Total:      optionalInt64(priorities.Total),
+		ComputedAt: timestamppb.New(priorities.ComputedAt),
+	}
+
+	for i, priority := range priorities.Items {
+		response.Items[i] = toRestockPriorityMessage(priority)
+	}
+
+	return response, nil
+}
+
+func (s *ProductStockServer) StreamRestockPriorities(_ *emptypb.Empty, stream pb.ProductStockService_StreamRestockPrioritiesServer) error {
+	var sendErr error
+	domainErr := s.exportRestockUC.Execute(callTenant(stream.Context()), func(items []restock.Priority) *domain.Error {
+		for _, priority := range items {
+			if sendErr = stream.Send(toRestockPriorityMessage(priority)); sendErr != nil {
+				return domain.NewError("failed to send restock priority", domain.ErrInternal)
+			}
+		}
+
+		return nil
+	})
+
+	return streamError(sendErr, domainErr)
+}
+
+// streamError prefers the error of a failed send, which tells why the
+// stream broke, to the error the use case made of it.
+func streamError(sendErr error, domainErr *domain.Error) error {
+	if sendErr != nil {
+		return sendErr
+	}
+
+	if domainErr != nil {
+		return toStatusError(domainErr)
+	}
+
+	return nil
+}
+
+func toPagination(p *pb.Pagination) domain.Pagination {
+	page := int(p.GetPage())
+	if page <= 0 {
+		page = 1
+	}
+
+	return domain.Pagination{
+		Page:         page,
+		Limit:        int(p.GetLimit()),
+		Cursor:       p.GetCursor(),
+		IncludeTotal: p.GetIncludeTotal(),
+	}
+}
+
+func toProductStockFilterDTO(f *pb.ProductStockFilter) usecases.ProductStockFilterDTO {
+	if f == nil {
+		return usecases.ProductStockFilterDTO{}
+	}
+
+	return usecases.ProductStockFilterDTO{
+		NameContains:   f.GetNameContains(),
+		Categories:     f.GetCategories(),
+		MinCriticality: optionalInt(f.CriticalityMin),
+		MaxCriticality: optionalInt(f.CriticalityMax),
+		MinUnitCost:    f.UnitCostMin,
+		MaxUnitCost:    f.UnitCostMax,
+		MinStock:       optionalInt(f.StockMin),
+		MaxStock:       optionalInt(f.StockMax),
+		BelowMinimum:   f.BelowMinimum,
+		NeedsRestock:   f.NeedsRestock,
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Sort:           f.GetSort(),
+	}
+}
+
+func toProductStockMessage(p *entities.ProductStock) *pb.ProductStock {
+	message := &pb.ProductStock{
+		Name:              p.Name,
+		Category:          string(p.Category),
+		CurrentStock:      int64(p.CurrentStock),
+		MinimumStock:      int64(p.MinimumStock),
+		AverageDailySales: int64(p.AverageDailySales),
+		LeadTimeDays:      int64(p.LeadTimeDays),
+		UnitCost:          p.UnitCost,
+		CriticalityLevel:  int32(p.CriticalityLevel),
+		Sku:               p.Identifiers.SKU,
+		Barcodes:          p.Identifiers.Barcodes,
+		ExternalIds:       p.Identifiers.ExternalIDs,
+	}
+
+	if p.ID != nil {
+		message.Id = *p.ID
+	}
+
+	if p.DeletedAt != nil {
+		message.DeletedAt = timestamppb.New(*p.DeletedAt)
+	}
+
+	return message
+}
+
+func toProductStockPageMessage(page *domain.Page[*entities.ProductStock]) *pb.ProductStockPage {
+	message := &pb.ProductStockPage{
+		Items:      make([]*pb.ProductStock, len(page.Items)),
+		NextCursor: page.NextCursor,
+		Total:      optionalInt64(page.Total),
+	}
+
+	for i, p := range page.Items {
+		message.Items[i] = toProductStockMessage(p)
+	}
+
+	return message
+}
+
+func toRestockPriorityMessage(priority restock.Priority) *pb.RestockPriority {
+	return &pb.RestockPriority{
+		ExpectedConsumption: int64(priority.ExpectedConsumption),
+		ProjectedStock:      int64(priority.ProjectedStock),
+		IsRepositionNeeded:  priority.IsRepositionNeeded,
+		UrgencyScore:        int64(priority.UrgencyScore),
+		SuggestedQuantity:   int64(priority.SuggestedQuantity),
+		ProductStock:        toProductStockMessage(priority.ProductStock),
+	}
+}
+
+func optionalInt[T int32 | int64](v *T) *int {
+	if v == nil {
+		return nil
+	}
+
+	i := int(*v)
+	return &i
+}
+
+func optionalInt64(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+
+	i := int64(*v)
+	return &i
+}
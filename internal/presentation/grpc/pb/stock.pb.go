@@ -0,0 +1,1511 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: stock.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProductStock struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category          string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	CurrentStock      int64                  `protobuf:"varint,4,opt,name=current_stock,json=currentStock,proto3" json:"current_stock,omitempty"`
+	MinimumStock      int64                  `protobuf:"varint,5,opt,name=minimum_stock,json=minimumStock,proto3" json:"minimum_stock,omitempty"`
+	AverageDailySales int64                  `protobuf:"varint,6,opt,name=average_daily_sales,json=averageDailySales,proto3" json:"average_daily_sales,omitempty"`
+	LeadTimeDays      int64                  `protobuf:"varint,7,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	UnitCost          float64                `protobuf:"fixed64,8,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	CriticalityLevel  int32                  `protobuf:"varint,9,opt,name=criticality_level,json=criticalityLevel,proto3" json:"criticality_level,omitempty"`
+	Sku               *string                `protobuf:"bytes,10,opt,name=sku,proto3,oneof" json:"sku,omitempty"`
+	Barcodes          []string               `protobuf:"bytes,11,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	ExternalIds       map[string]string      `protobuf:"bytes,12,rep,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// deleted_at is only set on deleted products, listed with include_deleted.
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductStock) Reset() {
+	*x = ProductStock{}
+	mi := &file_stock_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductStock) ProtoMessage() {}
+
+func (x *ProductStock) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductStock.ProtoReflect.Descriptor instead.
+func (*ProductStock) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProductStock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductStock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductStock) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ProductStock) GetCurrentStock() int64 {
+	if x != nil {
+		return x.CurrentStock
+	}
+	return 0
+}
+
+func (x *ProductStock) GetMinimumStock() int64 {
+	if x != nil {
+		return x.MinimumStock
+	}
+	return 0
+}
+
+func (x *ProductStock) GetAverageDailySales() int64 {
+	if x != nil {
+		return x.AverageDailySales
+	}
+	return 0
+}
+
+func (x *ProductStock) GetLeadTimeDays() int64 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *ProductStock) GetUnitCost() float64 {
+	if x != nil {
+		return x.UnitCost
+	}
+	return 0
+}
+
+func (x *ProductStock) GetCriticalityLevel() int32 {
+	if x != nil {
+		return x.CriticalityLevel
+	}
+	return 0
+}
+
+func (x *ProductStock) GetSku() string {
+	if x != nil && x.Sku != nil {
+		return *x.Sku
+	}
+	return ""
+}
+
+func (x *ProductStock) GetBarcodes() []string {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+func (x *ProductStock) GetExternalIds() map[string]string {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+func (x *ProductStock) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// Pagination works like the page, limit, cursor and total query parameters
+// of the HTTP API.
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	IncludeTotal  bool                   `protobuf:"varint,4,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_stock_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Pagination) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Pagination) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Pagination) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *Pagination) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+type ProductStockPage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*ProductStock        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// next_cursor is empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Total         *int64 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductStockPage) Reset() {
+	*x = ProductStockPage{}
+	mi := &file_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductStockPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductStockPage) ProtoMessage() {}
+
+func (x *ProductStockPage) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductStockPage.ProtoReflect.Descriptor instead.
+func (*ProductStockPage) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProductStockPage) GetItems() []*ProductStock {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ProductStockPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ProductStockPage) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type ProductStockFilter struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NameContains   string                 `protobuf:"bytes,1,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`
+	Categories     []string               `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	CriticalityMin *int32                 `protobuf:"varint,3,opt,name=criticality_min,json=criticalityMin,proto3,oneof" json:"criticality_min,omitempty"`
+	CriticalityMax *int32                 `protobuf:"varint,4,opt,name=criticality_max,json=criticalityMax,proto3,oneof" json:"criticality_max,omitempty"`
+	UnitCostMin    *float64               `protobuf:"fixed64,5,opt,name=unit_cost_min,json=unitCostMin,proto3,oneof" json:"unit_cost_min,omitempty"`
+	UnitCostMax    *float64               `protobuf:"fixed64,6,opt,name=unit_cost_max,json=unitCostMax,proto3,oneof" json:"unit_cost_max,omitempty"`
+	StockMin       *int64                 `protobuf:"varint,7,opt,name=stock_min,json=stockMin,proto3,oneof" json:"stock_min,omitempty"`
+	StockMax       *int64                 `protobuf:"varint,8,opt,name=stock_max,json=stockMax,proto3,oneof" json:"stock_max,omitempty"`
+	BelowMinimum   *bool                  `protobuf:"varint,9,opt,name=below_minimum,json=belowMinimum,proto3,oneof" json:"below_minimum,omitempty"`
+	NeedsRestock   *bool                  `protobuf:"varint,10,opt,name=needs_restock,json=needsRestock,proto3,oneof" json:"needs_restock,omitempty"`
+	// include_deleted requires the admin role.
+	IncludeDeleted bool `protobuf:"varint,11,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// sort lists fields separated by commas, descending when prefixed by "-".
+	Sort          string `protobuf:"bytes,12,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductStockFilter) Reset() {
+	*x = ProductStockFilter{}
+	mi := &file_stock_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductStockFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductStockFilter) ProtoMessage() {}
+
+func (x *ProductStockFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductStockFilter.ProtoReflect.Descriptor instead.
+func (*ProductStockFilter) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProductStockFilter) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ProductStockFilter) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ProductStockFilter) GetCriticalityMin() int32 {
+	if x != nil && x.CriticalityMin != nil {
+		return *x.CriticalityMin
+	}
+	return 0
+}
+
+func (x *ProductStockFilter) GetCriticalityMax() int32 {
+	if x != nil && x.CriticalityMax != nil {
+		return *x.CriticalityMax
+	}
+	return 0
+}
+
+func (x *ProductStockFilter) GetUnitCostMin() float64 {
+	if x != nil && x.UnitCostMin != nil {
+		return *x.UnitCostMin
+	}
+	return 0
+}
+
+func (x *ProductStockFilter) GetUnitCostMax() float64 {
+	if x != nil && x.UnitCostMax != nil {
+		return *x.UnitCostMax
+	}
+	return 0
+}
+
+func (x *ProductStockFilter) GetStockMin() int64 {
+	if x != nil && x.StockMin != nil {
+		return *x.StockMin
+	}
+	return 0
+}
+
+func (x *ProductStockFilter) GetStockMax() int64 {
+	if x != nil && x.StockMax != nil {
+		return *x.StockMax
+	}
+	return 0
+}
+
+func (x *ProductStockFilter) GetBelowMinimum() bool {
+	if x != nil && x.BelowMinimum != nil {
+		return *x.BelowMinimum
+	}
+	return false
+}
+
+func (x *ProductStockFilter) GetNeedsRestock() bool {
+	if x != nil && x.NeedsRestock != nil {
+		return *x.NeedsRestock
+	}
+	return false
+}
+
+func (x *ProductStockFilter) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ProductStockFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type CreateProductStockRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Category          string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	CurrentStock      int64                  `protobuf:"varint,3,opt,name=current_stock,json=currentStock,proto3" json:"current_stock,omitempty"`
+	MinimumStock      int64                  `protobuf:"varint,4,opt,name=minimum_stock,json=minimumStock,proto3" json:"minimum_stock,omitempty"`
+	AverageDailySales int64                  `protobuf:"varint,5,opt,name=average_daily_sales,json=averageDailySales,proto3" json:"average_daily_sales,omitempty"`
+	LeadTimeDays      int64                  `protobuf:"varint,6,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	UnitCost          float64                `protobuf:"fixed64,7,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	CriticalityLevel  int32                  `protobuf:"varint,8,opt,name=criticality_level,json=criticalityLevel,proto3" json:"criticality_level,omitempty"`
+	Sku               *string                `protobuf:"bytes,9,opt,name=sku,proto3,oneof" json:"sku,omitempty"`
+	Barcodes          []string               `protobuf:"bytes,10,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	ExternalIds       map[string]string      `protobuf:"bytes,11,rep,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateProductStockRequest) Reset() {
+	*x = CreateProductStockRequest{}
+	mi := &file_stock_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductStockRequest) ProtoMessage() {}
+
+func (x *CreateProductStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductStockRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductStockRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateProductStockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProductStockRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateProductStockRequest) GetCurrentStock() int64 {
+	if x != nil {
+		return x.CurrentStock
+	}
+	return 0
+}
+
+func (x *CreateProductStockRequest) GetMinimumStock() int64 {
+	if x != nil {
+		return x.MinimumStock
+	}
+	return 0
+}
+
+func (x *CreateProductStockRequest) GetAverageDailySales() int64 {
+	if x != nil {
+		return x.AverageDailySales
+	}
+	return 0
+}
+
+func (x *CreateProductStockRequest) GetLeadTimeDays() int64 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *CreateProductStockRequest) GetUnitCost() float64 {
+	if x != nil {
+		return x.UnitCost
+	}
+	return 0
+}
+
+func (x *CreateProductStockRequest) GetCriticalityLevel() int32 {
+	if x != nil {
+		return x.CriticalityLevel
+	}
+	return 0
+}
+
+func (x *CreateProductStockRequest) GetSku() string {
+	if x != nil && x.Sku != nil {
+		return *x.Sku
+	}
+	return ""
+}
+
+func (x *CreateProductStockRequest) GetBarcodes() []string {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+func (x *CreateProductStockRequest) GetExternalIds() map[string]string {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+type CreateProductStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductStockResponse) Reset() {
+	*x = CreateProductStockResponse{}
+	mi := &file_stock_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductStockResponse) ProtoMessage() {}
+
+func (x *CreateProductStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductStockResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductStockResponse) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateProductStockResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProductStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// include_deleted requires the admin role.
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetProductStockRequest) Reset() {
+	*x = GetProductStockRequest{}
+	mi := &file_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductStockRequest) ProtoMessage() {}
+
+func (x *GetProductStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductStockRequest.ProtoReflect.Descriptor instead.
+func (*GetProductStockRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProductStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductStockRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListProductStocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *ProductStockFilter    `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductStocksRequest) Reset() {
+	*x = ListProductStocksRequest{}
+	mi := &file_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductStocksRequest) ProtoMessage() {}
+
+func (x *ListProductStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductStocksRequest.ProtoReflect.Descriptor instead.
+func (*ListProductStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListProductStocksRequest) GetFilter() *ProductStockFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListProductStocksRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type StreamProductStocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *ProductStockFilter    `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProductStocksRequest) Reset() {
+	*x = StreamProductStocksRequest{}
+	mi := &file_stock_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProductStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProductStocksRequest) ProtoMessage() {}
+
+func (x *StreamProductStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProductStocksRequest.ProtoReflect.Descriptor instead.
+func (*StreamProductStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamProductStocksRequest) GetFilter() *ProductStockFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_stock_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type StringMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        map[string]string      `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringMap) Reset() {
+	*x = StringMap{}
+	mi := &file_stock_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringMap) ProtoMessage() {}
+
+func (x *StringMap) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringMap.ProtoReflect.Descriptor instead.
+func (*StringMap) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StringMap) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// UpdateProductStockRequest only changes the fields that are set.
+type UpdateProductStockRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CurrentStock      *int64                 `protobuf:"varint,2,opt,name=current_stock,json=currentStock,proto3,oneof" json:"current_stock,omitempty"`
+	MinimumStock      *int64                 `protobuf:"varint,3,opt,name=minimum_stock,json=minimumStock,proto3,oneof" json:"minimum_stock,omitempty"`
+	AverageDailySales *int64                 `protobuf:"varint,4,opt,name=average_daily_sales,json=averageDailySales,proto3,oneof" json:"average_daily_sales,omitempty"`
+	LeadTimeDays      *int64                 `protobuf:"varint,5,opt,name=lead_time_days,json=leadTimeDays,proto3,oneof" json:"lead_time_days,omitempty"`
+	UnitCost          *float64               `protobuf:"fixed64,6,opt,name=unit_cost,json=unitCost,proto3,oneof" json:"unit_cost,omitempty"`
+	CriticalityLevel  *int32                 `protobuf:"varint,7,opt,name=criticality_level,json=criticalityLevel,proto3,oneof" json:"criticality_level,omitempty"`
+	Sku               *string                `protobuf:"bytes,8,opt,name=sku,proto3,oneof" json:"sku,omitempty"`
+	// barcodes and external_ids replace the current ones when set.
+	Barcodes      *StringList `protobuf:"bytes,9,opt,name=barcodes,proto3" json:"barcodes,omitempty"`
+	ExternalIds   *StringMap  `protobuf:"bytes,10,opt,name=external_ids,json=externalIds,proto3" json:"external_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductStockRequest) Reset() {
+	*x = UpdateProductStockRequest{}
+	mi := &file_stock_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductStockRequest) ProtoMessage() {}
+
+func (x *UpdateProductStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductStockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductStockRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateProductStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductStockRequest) GetCurrentStock() int64 {
+	if x != nil && x.CurrentStock != nil {
+		return *x.CurrentStock
+	}
+	return 0
+}
+
+func (x *UpdateProductStockRequest) GetMinimumStock() int64 {
+	if x != nil && x.MinimumStock != nil {
+		return *x.MinimumStock
+	}
+	return 0
+}
+
+func (x *UpdateProductStockRequest) GetAverageDailySales() int64 {
+	if x != nil && x.AverageDailySales != nil {
+		return *x.AverageDailySales
+	}
+	return 0
+}
+
+func (x *UpdateProductStockRequest) GetLeadTimeDays() int64 {
+	if x != nil && x.LeadTimeDays != nil {
+		return *x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *UpdateProductStockRequest) GetUnitCost() float64 {
+	if x != nil && x.UnitCost != nil {
+		return *x.UnitCost
+	}
+	return 0
+}
+
+func (x *UpdateProductStockRequest) GetCriticalityLevel() int32 {
+	if x != nil && x.CriticalityLevel != nil {
+		return *x.CriticalityLevel
+	}
+	return 0
+}
+
+func (x *UpdateProductStockRequest) GetSku() string {
+	if x != nil && x.Sku != nil {
+		return *x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateProductStockRequest) GetBarcodes() *StringList {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+func (x *UpdateProductStockRequest) GetExternalIds() *StringMap {
+	if x != nil {
+		return x.ExternalIds
+	}
+	return nil
+}
+
+type DeleteProductStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductStockRequest) Reset() {
+	*x = DeleteProductStockRequest{}
+	mi := &file_stock_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductStockRequest) ProtoMessage() {}
+
+func (x *DeleteProductStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductStockRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductStockRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteProductStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListProductStocksByCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductStocksByCategoryRequest) Reset() {
+	*x = ListProductStocksByCategoryRequest{}
+	mi := &file_stock_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductStocksByCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductStocksByCategoryRequest) ProtoMessage() {}
+
+func (x *ListProductStocksByCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductStocksByCategoryRequest.ProtoReflect.Descriptor instead.
+func (*ListProductStocksByCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListProductStocksByCategoryRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListProductStocksByCategoryRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetRestockPrioritiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestockPrioritiesRequest) Reset() {
+	*x = GetRestockPrioritiesRequest{}
+	mi := &file_stock_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestockPrioritiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestockPrioritiesRequest) ProtoMessage() {}
+
+func (x *GetRestockPrioritiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestockPrioritiesRequest.ProtoReflect.Descriptor instead.
+func (*GetRestockPrioritiesRequest) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetRestockPrioritiesRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type RestockPriority struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ExpectedConsumption int64                  `protobuf:"varint,1,opt,name=expected_consumption,json=expectedConsumption,proto3" json:"expected_consumption,omitempty"`
+	ProjectedStock      int64                  `protobuf:"varint,2,opt,name=projected_stock,json=projectedStock,proto3" json:"projected_stock,omitempty"`
+	IsRepositionNeeded  bool                   `protobuf:"varint,3,opt,name=is_reposition_needed,json=isRepositionNeeded,proto3" json:"is_reposition_needed,omitempty"`
+	UrgencyScore        int64                  `protobuf:"varint,4,opt,name=urgency_score,json=urgencyScore,proto3" json:"urgency_score,omitempty"`
+	SuggestedQuantity   int64                  `protobuf:"varint,5,opt,name=suggested_quantity,json=suggestedQuantity,proto3" json:"suggested_quantity,omitempty"`
+	ProductStock        *ProductStock          `protobuf:"bytes,6,opt,name=product_stock,json=productStock,proto3" json:"product_stock,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RestockPriority) Reset() {
+	*x = RestockPriority{}
+	mi := &file_stock_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockPriority) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockPriority) ProtoMessage() {}
+
+func (x *RestockPriority) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockPriority.ProtoReflect.Descriptor instead.
+func (*RestockPriority) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestockPriority) GetExpectedConsumption() int64 {
+	if x != nil {
+		return x.ExpectedConsumption
+	}
+	return 0
+}
+
+func (x *RestockPriority) GetProjectedStock() int64 {
+	if x != nil {
+		return x.ProjectedStock
+	}
+	return 0
+}
+
+func (x *RestockPriority) GetIsRepositionNeeded() bool {
+	if x != nil {
+		return x.IsRepositionNeeded
+	}
+	return false
+}
+
+func (x *RestockPriority) GetUrgencyScore() int64 {
+	if x != nil {
+		return x.UrgencyScore
+	}
+	return 0
+}
+
+func (x *RestockPriority) GetSuggestedQuantity() int64 {
+	if x != nil {
+		return x.SuggestedQuantity
+	}
+	return 0
+}
+
+func (x *RestockPriority) GetProductStock() *ProductStock {
+	if x != nil {
+		return x.ProductStock
+	}
+	return nil
+}
+
+type RestockPriorityPage struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Items      []*RestockPriority     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Total      *int64                 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	// computed_at tells when the priority snapshot last changed.
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockPriorityPage) Reset() {
+	*x = RestockPriorityPage{}
+	mi := &file_stock_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockPriorityPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockPriorityPage) ProtoMessage() {}
+
+func (x *RestockPriorityPage) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockPriorityPage.ProtoReflect.Descriptor instead.
+func (*RestockPriorityPage) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestockPriorityPage) GetItems() []*RestockPriority {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RestockPriorityPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *RestockPriorityPage) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *RestockPriorityPage) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+var File_stock_proto protoreflect.FileDescriptor
+
+const file_stock_proto_rawDesc = "" +
+	"\n" +
+	"\vstock.proto\x12\bstock.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xba\x04\n" +
+	"\fProductStock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12#\n" +
+	"\rcurrent_stock\x18\x04 \x01(\x03R\fcurrentStock\x12#\n" +
+	"\rminimum_stock\x18\x05 \x01(\x03R\fminimumStock\x12.\n" +
+	"\x13average_daily_sales\x18\x06 \x01(\x03R\x11averageDailySales\x12$\n" +
+	"\x0elead_time_days\x18\a \x01(\x03R\fleadTimeDays\x12\x1b\n" +
+	"\tunit_cost\x18\b \x01(\x01R\bunitCost\x12+\n" +
+	"\x11criticality_level\x18\t \x01(\x05R\x10criticalityLevel\x12\x15\n" +
+	"\x03sku\x18\n" +
+	" \x01(\tH\x00R\x03sku\x88\x01\x01\x12\x1a\n" +
+	"\bbarcodes\x18\v \x03(\tR\bbarcodes\x12J\n" +
+	"\fexternal_ids\x18\f \x03(\v2'.stock.v1.ProductStock.ExternalIdsEntryR\vexternalIds\x129\n" +
+	"\n" +
+	"deleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x1a>\n" +
+	"\x10ExternalIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x06\n" +
+	"\x04_sku\"s\n" +
+	"\n" +
+	"Pagination\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12#\n" +
+	"\rinclude_total\x18\x04 \x01(\bR\fincludeTotal\"\x86\x01\n" +
+	"\x10ProductStockPage\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.stock.v1.ProductStockR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x03H\x00R\x05total\x88\x01\x01B\b\n" +
+	"\x06_total\"\xe8\x04\n" +
+	"\x12ProductStockFilter\x12#\n" +
+	"\rname_contains\x18\x01 \x01(\tR\fnameContains\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories\x12,\n" +
+	"\x0fcriticality_min\x18\x03 \x01(\x05H\x00R\x0ecriticalityMin\x88\x01\x01\x12,\n" +
+	"\x0fcriticality_max\x18\x04 \x01(\x05H\x01R\x0ecriticalityMax\x88\x01\x01\x12'\n" +
+	"\runit_cost_min\x18\x05 \x01(\x01H\x02R\vunitCostMin\x88\x01\x01\x12'\n" +
+	"\runit_cost_max\x18\x06 \x01(\x01H\x03R\vunitCostMax\x88\x01\x01\x12 \n" +
+	"\tstock_min\x18\a \x01(\x03H\x04R\bstockMin\x88\x01\x01\x12 \n" +
+	"\tstock_max\x18\b \x01(\x03H\x05R\bstockMax\x88\x01\x01\x12(\n" +
+	"\rbelow_minimum\x18\t \x01(\bH\x06R\fbelowMinimum\x88\x01\x01\x12(\n" +
+	"\rneeds_restock\x18\n" +
+	" \x01(\bH\aR\fneedsRestock\x88\x01\x01\x12'\n" +
+	"\x0finclude_deleted\x18\v \x01(\bR\x0eincludeDeleted\x12\x12\n" +
+	"\x04sort\x18\f \x01(\tR\x04sortB\x12\n" +
+	"\x10_criticality_minB\x12\n" +
+	"\x10_criticality_maxB\x10\n" +
+	"\x0e_unit_cost_minB\x10\n" +
+	"\x0e_unit_cost_maxB\f\n" +
+	"\n" +
+	"_stock_minB\f\n" +
+	"\n" +
+	"_stock_maxB\x10\n" +
+	"\x0e_below_minimumB\x10\n" +
+	"\x0e_needs_restock\"\x89\x04\n" +
+	"\x19CreateProductStockRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12#\n" +
+	"\rcurrent_stock\x18\x03 \x01(\x03R\fcurrentStock\x12#\n" +
+	"\rminimum_stock\x18\x04 \x01(\x03R\fminimumStock\x12.\n" +
+	"\x13average_daily_sales\x18\x05 \x01(\x03R\x11averageDailySales\x12$\n" +
+	"\x0elead_time_days\x18\x06 \x01(\x03R\fleadTimeDays\x12\x1b\n" +
+	"\tunit_cost\x18\a \x01(\x01R\bunitCost\x12+\n" +
+	"\x11criticality_level\x18\b \x01(\x05R\x10criticalityLevel\x12\x15\n" +
+	"\x03sku\x18\t \x01(\tH\x00R\x03sku\x88\x01\x01\x12\x1a\n" +
+	"\bbarcodes\x18\n" +
+	" \x03(\tR\bbarcodes\x12W\n" +
+	"\fexternal_ids\x18\v \x03(\v24.stock.v1.CreateProductStockRequest.ExternalIdsEntryR\vexternalIds\x1a>\n" +
+	"\x10ExternalIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x06\n" +
+	"\x04_sku\",\n" +
+	"\x1aCreateProductStockResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x16GetProductStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"\x86\x01\n" +
+	"\x18ListProductStocksRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.stock.v1.ProductStockFilterR\x06filter\x124\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x14.stock.v1.PaginationR\n" +
+	"pagination\"R\n" +
+	"\x1aStreamProductStocksRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.stock.v1.ProductStockFilterR\x06filter\"$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x7f\n" +
+	"\tStringMap\x127\n" +
+	"\x06values\x18\x01 \x03(\v2\x1f.stock.v1.StringMap.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x04\n" +
+	"\x19UpdateProductStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\rcurrent_stock\x18\x02 \x01(\x03H\x00R\fcurrentStock\x88\x01\x01\x12(\n" +
+	"\rminimum_stock\x18\x03 \x01(\x03H\x01R\fminimumStock\x88\x01\x01\x123\n" +
+	"\x13average_daily_sales\x18\x04 \x01(\x03H\x02R\x11averageDailySales\x88\x01\x01\x12)\n" +
+	"\x0elead_time_days\x18\x05 \x01(\x03H\x03R\fleadTimeDays\x88\x01\x01\x12 \n" +
+	"\tunit_cost\x18\x06 \x01(\x01H\x04R\bunitCost\x88\x01\x01\x120\n" +
+	"\x11criticality_level\x18\a \x01(\x05H\x05R\x10criticalityLevel\x88\x01\x01\x12\x15\n" +
+	"\x03sku\x18\b \x01(\tH\x06R\x03sku\x88\x01\x01\x120\n" +
+	"\bbarcodes\x18\t \x01(\v2\x14.stock.v1.StringListR\bbarcodes\x126\n" +
+	"\fexternal_ids\x18\n" +
+	" \x01(\v2\x13.stock.v1.StringMapR\vexternalIdsB\x10\n" +
+	"\x0e_current_stockB\x10\n" +
+	"\x0e_minimum_stockB\x16\n" +
+	"\x14_average_daily_salesB\x11\n" +
+	"\x0f_lead_time_daysB\f\n" +
+	"\n" +
+	"_unit_costB\x14\n" +
+	"\x12_criticality_levelB\x06\n" +
+	"\x04_sku\"+\n" +
+	"\x19DeleteProductStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"v\n" +
+	"\"ListProductStocksByCategoryRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x124\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x14.stock.v1.PaginationR\n" +
+	"pagination\"S\n" +
+	"\x1bGetRestockPrioritiesRequest\x124\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2\x14.stock.v1.PaginationR\n" +
+	"pagination\"\xb0\x02\n" +
+	"\x0fRestockPriority\x121\n" +
+	"\x14expected_consumption\x18\x01 \x01(\x03R\x13expectedConsumption\x12'\n" +
+	"\x0fprojected_stock\x18\x02 \x01(\x03R\x0eprojectedStock\x120\n" +
+	"\x14is_reposition_needed\x18\x03 \x01(\bR\x12isRepositionNeeded\x12#\n" +
+	"\rurgency_score\x18\x04 \x01(\x03R\furgencyScore\x12-\n" +
+	"\x12suggested_quantity\x18\x05 \x01(\x03R\x11suggestedQuantity\x12;\n" +
+	"\rproduct_stock\x18\x06 \x01(\v2\x16.stock.v1.ProductStockR\fproductStock\"\xc9\x01\n" +
+	"\x13RestockPriorityPage\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.stock.v1.RestockPriorityR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x03H\x00R\x05total\x88\x01\x01\x12;\n" +
+	"\vcomputed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAtB\b\n" +
+	"\x06_total2\xac\x06\n" +
+	"\x13ProductStockService\x12_\n" +
+	"\x12CreateProductStock\x12#.stock.v1.CreateProductStockRequest\x1a$.stock.v1.CreateProductStockResponse\x12K\n" +
+	"\x0fGetProductStock\x12 .stock.v1.GetProductStockRequest\x1a\x16.stock.v1.ProductStock\x12S\n" +
+	"\x11ListProductStocks\x12\".stock.v1.ListProductStocksRequest\x1a\x1a.stock.v1.ProductStockPage\x12U\n" +
+	"\x13StreamProductStocks\x12$.stock.v1.StreamProductStocksRequest\x1a\x16.stock.v1.ProductStock0\x01\x12Q\n" +
+	"\x12UpdateProductStock\x12#.stock.v1.UpdateProductStockRequest\x1a\x16.google.protobuf.Empty\x12Q\n" +
+	"\x12DeleteProductStock\x12#.stock.v1.DeleteProductStockRequest\x1a\x16.google.protobuf.Empty\x12g\n" +
+	"\x1bListProductStocksByCategory\x12,.stock.v1.ListProductStocksByCategoryRequest\x1a\x1a.stock.v1.ProductStockPage\x12\\\n" +
+	"\x14GetRestockPriorities\x12%.stock.v1.GetRestockPrioritiesRequest\x1a\x1d.stock.v1.RestockPriorityPage\x12N\n" +
+	"\x17StreamRestockPriorities\x12\x16.google.protobuf.Empty\x1a\x19.stock.v1.RestockPriority0\x01BNZLgithub.com/danielalmeidafarias/go_stock_engine/internal/presentation/grpc/pbb\x06proto3"
+
+var (
+	file_stock_proto_rawDescOnce sync.Once
+	file_stock_proto_rawDescData []byte
+)
+
+func file_stock_proto_rawDescGZIP() []byte {
+	file_stock_proto_rawDescOnce.Do(func() {
+		file_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stock_proto_rawDesc), len(file_stock_proto_rawDesc)))
+	})
+	return file_stock_proto_rawDescData
+}
+
+var file_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_stock_proto_goTypes = []any{
+	(*ProductStock)(nil),                       // 0: stock.v1.ProductStock
+	(*Pagination)(nil),                         // 1: stock.v1.Pagination
+	(*ProductStockPage)(nil),                   // 2: stock.v1.ProductStockPage
+	(*ProductStockFilter)(nil),                 // 3: stock.v1.ProductStockFilter
+	(*CreateProductStockRequest)(nil),          // 4: stock.v1.CreateProductStockRequest
+	(*CreateProductStockResponse)(nil),         // 5: stock.v1.CreateProductStockResponse
+	(*GetProductStockRequest)(nil),             // 6: stock.v1.GetProductStockRequest
+	(*ListProductStocksRequest)(nil),           // 7: stock.v1.ListProductStocksRequest
+	(*StreamProductStocksRequest)(nil),         // 8: stock.v1.StreamProductStocksRequest
+	(*StringList)(nil),                         // 9: stock.v1.StringList
+	(*StringMap)(nil),                          // 10: stock.v1.StringMap
+	(*UpdateProductStockRequest)(nil),          // 11: stock.v1.UpdateProductStockRequest
+	(*DeleteProductStockRequest)(nil),          // 12: stock.v1.DeleteProductStockRequest
+	(*ListProductStocksByCategoryRequest)(nil), // 13: stock.v1.ListProductStocksByCategoryRequest
+	(*GetRestockPrioritiesRequest)(nil),        // 14: stock.v1.GetRestockPrioritiesRequest
+	(*RestockPriority)(nil),                    // 15: stock.v1.RestockPriority
+	(*RestockPriorityPage)(nil),                // 16: stock.v1.RestockPriorityPage
+	nil,                                        // 17: stock.v1.ProductStock.ExternalIdsEntry
+	nil,                                        // 18: stock.v1.CreateProductStockRequest.ExternalIdsEntry
+	nil,                                        // 19: stock.v1.StringMap.ValuesEntry
+	(*timestamppb.Timestamp)(nil),              // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                      // 21: google.protobuf.Empty
+}
+var file_stock_proto_depIdxs = []int32{
+	17, // 0: stock.v1.ProductStock.external_ids:type_name -> stock.v1.ProductStock.ExternalIdsEntry
+	20, // 1: stock.v1.ProductStock.deleted_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: stock.v1.ProductStockPage.items:type_name -> stock.v1.ProductStock
+	18, // 3: stock.v1.CreateProductStockRequest.external_ids:type_name -> stock.v1.CreateProductStockRequest.ExternalIdsEntry
+	3,  // 4: stock.v1.ListProductStocksRequest.filter:type_name -> stock.v1.ProductStockFilter
+	1,  // 5: stock.v1.ListProductStocksRequest.pagination:type_name -> stock.v1.Pagination
+	3,  // 6: stock.v1.StreamProductStocksRequest.filter:type_name -> stock.v1.ProductStockFilter
+	19, // 7: stock.v1.StringMap.values:type_name -> stock.v1.StringMap.ValuesEntry
+	9,  // 8: stock.v1.UpdateProductStockRequest.barcodes:type_name -> stock.v1.StringList
+	10, // 9: stock.v1.UpdateProductStockRequest.external_ids:type_name -> stock.v1.StringMap
+	1,  // 10: stock.v1.ListProductStocksByCategoryRequest.pagination:type_name -> stock.v1.Pagination
+	1,  // 11: stock.v1.GetRestockPrioritiesRequest.pagination:type_name -> stock.v1.Pagination
+	0,  // 12: stock.v1.RestockPriority.product_stock:type_name -> stock.v1.ProductStock
+	15, // 13: stock.v1.RestockPriorityPage.items:type_name -> stock.v1.RestockPriority
+	20, // 14: stock.v1.RestockPriorityPage.computed_at:type_name -> google.protobuf.Timestamp
+	4,  // 15: stock.v1.ProductStockService.CreateProductStock:input_type -> stock.v1.CreateProductStockRequest
+	6,  // 16: stock.v1.ProductStockService.GetProductStock:input_type -> stock.v1.GetProductStockRequest
+	7,  // 17: stock.v1.ProductStockService.ListProductStocks:input_type -> stock.v1.ListProductStocksRequest
+	8,  // 18: stock.v1.ProductStockService.StreamProductStocks:input_type -> stock.v1.StreamProductStocksRequest
+	11, // 19: stock.v1.ProductStockService.UpdateProductStock:input_type -> stock.v1.UpdateProductStockRequest
+	12, // 20: stock.v1.ProductStockService.DeleteProductStock:input_type -> stock.v1.DeleteProductStockRequest
+	13, // 21: stock.v1.ProductStockService.ListProductStocksByCategory:input_type -> stock.v1.ListProductStocksByCategoryRequest
+	14, // 22: stock.v1.ProductStockService.GetRestockPriorities:input_type -> stock.v1.GetRestockPrioritiesRequest
+	21, // 23: stock.v1.ProductStockService.StreamRestockPriorities:input_type -> google.protobuf.Empty
+	5,  // 24: stock.v1.ProductStockService.CreateProductStock:output_type -> stock.v1.CreateProductStockResponse
+	0,  // 25: stock.v1.ProductStockService.GetProductStock:output_type -> stock.v1.ProductStock
+	2,  // 26: stock.v1.ProductStockService.ListProductStocks:output_type -> stock.v1.ProductStockPage
+	0,  // 27: stock.v1.ProductStockService.StreamProductStocks:output_type -> stock.v1.ProductStock
+	21, // 28: stock.v1.ProductStockService.UpdateProductStock:output_type -> google.protobuf.Empty
+	21, // 29: stock.v1.ProductStockService.DeleteProductStock:output_type -> google.protobuf.Empty
+	2,  // 30: stock.v1.ProductStockService.ListProductStocksByCategory:output_type -> stock.v1.ProductStockPage
+	16, // 31: stock.v1.ProductStockService.GetRestockPriorities:output_type -> stock.v1.RestockPriorityPage
+	15, // 32: stock.v1.ProductStockService.StreamRestockPriorities:output_type -> stock.v1.RestockPriority
+	24, // [24:33] is the sub-list for method output_type
+	15, // [15:24] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_stock_proto_init() }
+func file_stock_proto_init() {
+	if File_stock_proto != nil {
+		return
+	}
+	file_stock_proto_msgTypes[0].OneofWrappers = []any{}
+	file_stock_proto_msgTypes[2].OneofWrappers = []any{}
+	file_stock_proto_msgTypes[3].OneofWrappers = []any{}
+	file_stock_proto_msgTypes[4].OneofWrappers = []any{}
+	file_stock_proto_msgTypes[11].OneofWrappers = []any{}
+	file_stock_proto_msgTypes[16].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stock_proto_rawDesc), len(file_stock_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stock_proto_goTypes,
+		DependencyIndexes: file_stock_proto_depIdxs,
+		MessageInfos:      file_stock_proto_msgTypes,
+	}.Build()
+	File_stock_proto = out.File
+	file_stock_proto_goTypes = nil
+	file_stock_proto_depIdxs = nil
+}
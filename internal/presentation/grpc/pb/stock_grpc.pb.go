@@ -0,0 +1,451 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: stock.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ProductStockService_CreateProductStock_FullMethodName          = "/stock.v1.ProductStockService/CreateProductStock"
+	ProductStockService_GetProductStock_FullMethodName             = "/stock.v1.ProductStockService/GetProductStock"
+	ProductStockService_ListProductStocks_FullMethodName           = "/stock.v1.ProductStockService/ListProductStocks"
+	ProductStockService_StreamProductStocks_FullMethodName         = "/stock.v1.ProductStockService/StreamProductStocks"
+	ProductStockService_UpdateProductStock_FullMethodName          = "/stock.v1.ProductStockService/UpdateProductStock"
+	ProductStockService_DeleteProductStock_FullMethodName          = "/stock.v1.ProductStockService/DeleteProductStock"
+	ProductStockService_ListProductStocksByCategory_FullMethodName = "/stock.v1.ProductStockService/ListProductStocksByCategory"
+	ProductStockService_GetRestockPriorities_FullMethodName        = "/stock.v1.ProductStockService/GetRestockPriorities"
+	ProductStockService_StreamRestockPriorities_FullMethodName     = "/stock.v1.ProductStockService/StreamRestockPriorities"
+)
+
+// ProductStockServiceClient is the client API for ProductStockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ProductStockService mirrors the product stock and restock priority routes
+// of the HTTP API. Calls carry the same credentials as HTTP requests in
+// their metadata: "authorization" or "x-api-key", and optionally
+// "x-tenant-id".
+type ProductStockServiceClient interface {
+	CreateProductStock(ctx context.Context, in *CreateProductStockRequest, opts ...grpc.CallOption) (*CreateProductStockResponse, error)
+	GetProductStock(ctx context.Context, in *GetProductStockRequest, opts ...grpc.CallOption) (*ProductStock, error)
+	ListProductStocks(ctx context.Context, in *ListProductStocksRequest, opts ...grpc.CallOption) (*ProductStockPage, error)
+	// StreamProductStocks sends every product matching the filter, reading
+	// them page by page, so large lists need no pagination by the client.
+	StreamProductStocks(ctx context.Context, in *StreamProductStocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProductStock], error)
+	UpdateProductStock(ctx context.Context, in *UpdateProductStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteProductStock(ctx context.Context, in *DeleteProductStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListProductStocksByCategory(ctx context.Context, in *ListProductStocksByCategoryRequest, opts ...grpc.CallOption) (*ProductStockPage, error)
+	GetRestockPriorities(ctx context.Context, in *GetRestockPrioritiesRequest, opts ...grpc.CallOption) (*RestockPriorityPage, error)
+	// StreamRestockPriorities sends every product that needs restocking, most
+	// urgent first.
+	StreamRestockPriorities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RestockPriority], error)
+}
+
+type productStockServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProductStockServiceClient(cc grpc.ClientConnInterface) ProductStockServiceClient {
+	return &productStockServiceClient{cc}
+}
+
+func (c *productStockServiceClient) CreateProductStock(ctx context.Context, in *CreateProductStockRequest, opts ...grpc.CallOption) (*CreateProductStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateProductStockResponse)
+	err := c.cc.Invoke(ctx, ProductStockService_CreateProductStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) GetProductStock(ctx context.Context, in *GetProductStockRequest, opts ...grpc.CallOption) (*ProductStock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductStock)
+	err := c.cc.Invoke(ctx, ProductStockService_GetProductStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) ListProductStocks(ctx context.Context, in *ListProductStocksRequest, opts ...grpc.CallOption) (*ProductStockPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductStockPage)
+	err := c.cc.Invoke(ctx, ProductStockService_ListProductStocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) StreamProductStocks(ctx context.Context, in *StreamProductStocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProductStock], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductStockService_ServiceDesc.Streams[0], ProductStockService_StreamProductStocks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamProductStocksRequest, ProductStock]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductStockService_StreamProductStocksClient = grpc.ServerStreamingClient[ProductStock]
+
+func (c *productStockServiceClient) UpdateProductStock(ctx context.Context, in *UpdateProductStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProductStockService_UpdateProductStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) DeleteProductStock(ctx context.Context, in *DeleteProductStockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProductStockService_DeleteProductStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) ListProductStocksByCategory(ctx context.Context, in *ListProductStocksByCategoryRequest, opts ...grpc.CallOption) (*ProductStockPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductStockPage)
+	err := c.cc.Invoke(ctx, ProductStockService_ListProductStocksByCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) GetRestockPriorities(ctx context.Context, in *GetRestockPrioritiesRequest, opts ...grpc.CallOption) (*RestockPriorityPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestockPriorityPage)
+	err := c.cc.Invoke(ctx, ProductStockService_GetRestockPriorities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productStockServiceClient) StreamRestockPriorities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RestockPriority], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductStockService_ServiceDesc.Streams[1], ProductStockService_StreamRestockPriorities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, RestockPriority]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductStockService_StreamRestockPrioritiesClient = grpc.ServerStreamingClient[RestockPriority]
+
+// ProductStockServiceServer is the server API for ProductStockService service.
+// All implementations must embed UnimplementedProductStockServiceServer
+// for forward compatibility.
+//
+// ProductStockService mirrors the product stock and restock priority routes
+// of the HTTP API. Calls carry the same credentials as HTTP requests in
+// their metadata: "authorization" or "x-api-key", and optionally
+// "x-tenant-id".
+type ProductStockServiceServer interface {
+	CreateProductStock(context.Context, *CreateProductStockRequest) (*CreateProductStockResponse, error)
+	GetProductStock(context.Context, *GetProductStockRequest) (*ProductStock, error)
+	ListProductStocks(context.Context, *ListProductStocksRequest) (*ProductStockPage, error)
+	// StreamProductStocks sends every product matching the filter, reading
+	// them page by page, so large lists need no pagination by the client.
+	StreamProductStocks(*StreamProductStocksRequest, grpc.ServerStreamingServer[ProductStock]) error
+	UpdateProductStock(context.Context, *UpdateProductStockRequest) (*emptypb.Empty, error)
+	DeleteProductStock(context.Context, *DeleteProductStockRequest) (*emptypb.Empty, error)
+	ListProductStocksByCategory(context.Context, *ListProductStocksByCategoryRequest) (*ProductStockPage, error)
+	GetRestockPriorities(context.Context, *GetRestockPrioritiesRequest) (*RestockPriorityPage, error)
+	// StreamRestockPriorities sends every product that needs restocking, most
+	// urgent first.
+	StreamRestockPriorities(*emptypb.Empty, grpc.ServerStreamingServer[RestockPriority]) error
+	mustEmbedUnimplementedProductStockServiceServer()
+}
+
+// UnimplementedProductStockServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProductStockServiceServer struct{}
+
+func (UnimplementedProductStockServiceServer) CreateProductStock(context.Context, *CreateProductStockRequest) (*CreateProductStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProductStock not implemented")
+}
+func (UnimplementedProductStockServiceServer) GetProductStock(context.Context, *GetProductStockRequest) (*ProductStock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductStock not implemented")
+}
+func (UnimplementedProductStockServiceServer) ListProductStocks(context.Context, *ListProductStocksRequest) (*ProductStockPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductStocks not implemented")
+}
+func (UnimplementedProductStockServiceServer) StreamProductStocks(*StreamProductStocksRequest, grpc.ServerStreamingServer[ProductStock]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProductStocks not implemented")
+}
+func (UnimplementedProductStockServiceServer) UpdateProductStock(context.Context, *UpdateProductStockRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProductStock not implemented")
+}
+func (UnimplementedProductStockServiceServer) DeleteProductStock(context.Context, *DeleteProductStockRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProductStock not implemented")
+}
+func (UnimplementedProductStockServiceServer) ListProductStocksByCategory(context.Context, *ListProductStocksByCategoryRequest) (*ProductStockPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductStocksByCategory not implemented")
+}
+func (UnimplementedProductStockServiceServer) GetRestockPriorities(context.Context, *GetRestockPrioritiesRequest) (*RestockPriorityPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRestockPriorities not implemented")
+}
+func (UnimplementedProductStockServiceServer) StreamRestockPriorities(*emptypb.Empty, grpc.ServerStreamingServer[RestockPriority]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRestockPriorities not implemented")
+}
+func (UnimplementedProductStockServiceServer) mustEmbedUnimplementedProductStockServiceServer() {}
+func (UnimplementedProductStockServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeProductStockServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductStockServiceServer will
+// result in compilation errors.
+type UnsafeProductStockServiceServer interface {
+	mustEmbedUnimplementedProductStockServiceServer()
+}
+
+func RegisterProductStockServiceServer(s grpc.ServiceRegistrar, srv ProductStockServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProductStockServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProductStockService_ServiceDesc, srv)
+}
+
+func _ProductStockService_CreateProductStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).CreateProductStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_CreateProductStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).CreateProductStock(ctx, req.(*CreateProductStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_GetProductStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).GetProductStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_GetProductStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).GetProductStock(ctx, req.(*GetProductStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_ListProductStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).ListProductStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_ListProductStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).ListProductStocks(ctx, req.(*ListProductStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_StreamProductStocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamProductStocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductStockServiceServer).StreamProductStocks(m, &grpc.GenericServerStream[StreamProductStocksRequest, ProductStock]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductStockService_StreamProductStocksServer = grpc.ServerStreamingServer[ProductStock]
+
+func _ProductStockService_UpdateProductStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).UpdateProductStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_UpdateProductStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).UpdateProductStock(ctx, req.(*UpdateProductStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_DeleteProductStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).DeleteProductStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_DeleteProductStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).DeleteProductStock(ctx, req.(*DeleteProductStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_ListProductStocksByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductStocksByCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).ListProductStocksByCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_ListProductStocksByCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).ListProductStocksByCategory(ctx, req.(*ListProductStocksByCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_GetRestockPriorities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRestockPrioritiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductStockServiceServer).GetRestockPriorities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductStockService_GetRestockPriorities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductStockServiceServer).GetRestockPriorities(ctx, req.(*GetRestockPrioritiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductStockService_StreamRestockPriorities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductStockServiceServer).StreamRestockPriorities(m, &grpc.GenericServerStream[emptypb.Empty, RestockPriority]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductStockService_StreamRestockPrioritiesServer = grpc.ServerStreamingServer[RestockPriority]
+
+// ProductStockService_ServiceDesc is the grpc.ServiceDesc for ProductStockService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProductStockService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stock.v1.ProductStockService",
+	HandlerType: (*ProductStockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProductStock",
+			Handler:    _ProductStockService_CreateProductStock_Handler,
+		},
+		{
+			MethodName: "GetProductStock",
+			Handler:    _ProductStockService_GetProductStock_Handler,
+		},
+		{
+			MethodName: "ListProductStocks",
+			Handler:    _ProductStockService_ListProductStocks_Handler,
+		},
+		{
+			MethodName: "UpdateProductStock",
+			Handler:    _ProductStockService_UpdateProductStock_Handler,
+		},
+		{
+			MethodName: "DeleteProductStock",
+			Handler:    _ProductStockService_DeleteProductStock_Handler,
+		},
+		{
+			MethodName: "ListProductStocksByCategory",
+			Handler:    _ProductStockService_ListProductStocksByCategory_Handler,
+		},
+		{
+			MethodName: "GetRestockPriorities",
+			Handler:    _ProductStockService_GetRestockPriorities_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamProductStocks",
+			Handler:       _ProductStockService_StreamProductStocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRestockPriorities",
+			Handler:       _ProductStockService_StreamRestockPriorities_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "stock.proto",
+}
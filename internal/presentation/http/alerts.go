@@ -0,0 +1,283 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/alert"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/gin-gonic/gin"
+)
+
+type AlertHandler struct {
+	createRuleUC  *usecases.CreateAlertRuleUseCase
+	getRulesUC    *usecases.GetAlertRulesUseCase
+	deleteRuleUC  *usecases.DeleteAlertRuleUseCase
+	getAlertsUC   *usecases.GetAlertsUseCase
+	testChannelUC *usecases.TestAlertChannelUseCase
+}
+
+func NewAlertHandler(
+	createRuleUC *usecases.CreateAlertRuleUseCase,
+	getRulesUC *usecases.GetAlertRulesUseCase,
+	deleteRuleUC *usecases.DeleteAlertRuleUseCase,
+	getAlertsUC *usecases.GetAlertsUseCase,
+	testChannelUC *usecases.TestAlertChannelUseCase,
+) *AlertHandler {
+	return &AlertHandler{
+		createRuleUC:  createRuleUC,
+		getRulesUC:    getRulesUC,
+		deleteRuleUC:  deleteRuleUC,
+		getAlertsUC:   getAlertsUC,
+		testChannelUC: testChannelUC,
+	}
+}
+
+type createAlertRuleRequest struct {
+	Name           string   `json:"name" binding:"required" example:"Critical products running out"`
+	Condition      string   `json:"condition" binding:"required" enums:"projected_below_minimum,below_minimum,out_of_stock" example:"projected_below_minimum"`
+	MinCriticality int      `json:"min_criticality" example:"4"`
+	Categories     []string `json:"categories" example:"OIL"`
+	Channels       []string `json:"channels" binding:"required" example:"email,slack"`
+	Cooldown       string   `json:"cooldown" example:"2h"`
+}
+
+type alertRuleResponse struct {
+	ID             string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name           string    `json:"name" example:"Critical products running out"`
+	Condition      string    `json:"condition" example:"projected_below_minimum"`
+	MinCriticality int       `json:"min_criticality" example:"4"`
+	Categories     []string  `json:"categories" example:"OIL"`
+	Channels       []string  `json:"channels" example:"email,slack"`
+	Cooldown       string    `json:"cooldown" example:"2h0m0s"`
+	CreatedAt      time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
+}
+
+type alertRulesResponse struct {
+	Items []alertRuleResponse `json:"items"`
+}
+
+// alertResponse represents an alert raised by a rule, with the product as
+// it was then.
+type alertResponse struct {
+	ID             int64         `json:"id" example:"42"`
+	RuleID         string        `json:"rule_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RuleName       string        `json:"rule_name" example:"Critical products running out"`
+	Condition      string        `json:"condition" example:"projected_below_minimum"`
+	EventID        int64         `json:"event_id" example:"1042"`
+	ProductID      string        `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Product        event.Product `json:"product"`
+	ProjectedStock int           `json:"projected_stock" example:"-5"`
+	Status         string        `json:"status" example:"sent"`
+	LastError      *string       `json:"last_error" example:"slack: unexpected status 500"`
+	TriggeredAt    time.Time     `json:"triggered_at" example:"2024-01-01T12:00:00Z"`
+	SentAt         *time.Time    `json:"sent_at" example:"2024-01-01T12:00:01Z"`
+}
+
+type alertPageResponse struct {
+	Items      []alertResponse `json:"items"`
+	NextCursor *string         `json:"next_cursor" example:"eyJzIjoiYWxlcnRzIiwiayI6WzQyXX0"`
+	Total      *int            `json:"total,omitempty" example:"120"`
+}
+
+func toAlertRuleResponse(r alert.Rule) alertRuleResponse {
+	res := alertRuleResponse{
+		ID:             *r.ID,
+		Name:           r.Name,
+		Condition:      string(r.Condition),
+		MinCriticality: int(r.MinCriticality),
+		Categories:     make([]string, len(r.Categories)),
+		Channels:       r.Channels,
+		Cooldown:       r.Cooldown.String(),
+		CreatedAt:      r.CreatedAt,
+	}
+
+	for i, c := range r.Categories {
+		res.Categories[i] = string(c)
+	}
+
+	return res
+}
+
+func toAlertResponse(a alert.Alert) alertResponse {
+	return alertResponse{
+		ID:             a.ID,
+		RuleID:         a.RuleID,
+		RuleName:       a.RuleName,
+		Condition:      string(a.Condition),
+		EventID:        a.EventID,
+		ProductID:      a.ProductID,
+		Product:        a.Product,
+		ProjectedStock: a.ProjectedStock,
+		Status:         string(a.Status),
+		LastError:      a.LastError,
+		TriggeredAt:    a.TriggeredAt,
+		SentAt:         a.SentAt,
+	}
+}
+
+func toAlertPageResponse(page *domain.Page[alert.Alert]) alertPageResponse {
+	items := make([]alertResponse, len(page.Items))
+	for i, a := range page.Items {
+		items[i] = toAlertResponse(a)
+	}
+
+	return alertPageResponse{
+		Items:      items,
+		NextCursor: nextCursorResponse(page.NextCursor),
+		Total:      page.Total,
+	}
+}
+
+// CreateRule godoc
+// @Summary      Create an alert rule
+// @Description  Creates a rule notifying the given channels whenever, after a change to its stock, a product it covers meets its condition: projected_below_minimum (the stock left after the lead time is below the minimum), below_minimum or out_of_stock. The rule covers the products at least min_criticality critical (all when 0) of the given categories (all when empty). Once it alerts about a product, it stays quiet about it for the cooldown (1h by default, between 1m and 168h)
+// @Tags         alerts
+// @Accept       json
+// @Produce      json
+// @Param        request  body      createAlertRuleRequest  true  "Alert rule"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      201      {object}  alertRuleResponse
+// @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /alerts/rules [post]
+func (h *AlertHandler) CreateRule(c *gin.Context) {
+	var req createAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, domainErr := h.createRuleUC.Execute(usecases.CreateAlertRuleDTO{
+		Tenant:         requestTenant(c),
+		Name:           req.Name,
+		Condition:      req.Condition,
+		MinCriticality: req.MinCriticality,
+		Categories:     req.Categories,
+		Channels:       req.Channels,
+		Cooldown:       req.Cooldown,
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAlertRuleResponse(*rule))
+}
+
+// GetRules godoc
+// @Summary      List the alert rules
+// @Description  Returns the alert rules of the tenant, oldest first
+// @Tags         alerts
+// @Produce      json
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  alertRulesResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /alerts/rules [get]
+func (h *AlertHandler) GetRules(c *gin.Context) {
+	rules, domainErr := h.getRulesUC.Execute(requestTenant(c))
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	items := make([]alertRuleResponse, len(rules))
+	for i, r := range rules {
+		items[i] = toAlertRuleResponse(r)
+	}
+
+	c.JSON(http.StatusOK, alertRulesResponse{Items: items})
+}
+
+// DeleteRule godoc
+// @Summary      Delete an alert rule
+// @Description  Deletes an alert rule along with the alerts it raised
+// @Tags         alerts
+// @Produce      json
+// @Param        id   path      string  true  "Alert rule ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      204  "No Content"
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /alerts/rules/{id} [delete]
+func (h *AlertHandler) DeleteRule(c *gin.Context) {
+	if domainErr := h.deleteRuleUC.Execute(requestTenant(c), c.Param("id")); domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetAlerts godoc
+// @Summary      List the alerts
+// @Description  Returns the alerts raised by the rules of the tenant, newest first. Pending alerts could not reach every channel of their rule yet and are sent again
+// @Tags         alerts
+// @Produce      json
+// @Param        rule_id  query     string  false  "Only alerts raised by this rule"
+// @Param        page     query     int     false  "Page number, ignored when a cursor is given"  default(1)
+// @Param        limit    query     int     false  "Items per page" default(20)
+// @Param        cursor   query     string  false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total    query     bool    false  "Include the total number of matching items"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  alertPageResponse
+// @Header       200  {string}  Link  "Links to the first and next pages"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /alerts [get]
+func (h *AlertHandler) GetAlerts(c *gin.Context) {
+	page, domainErr := h.getAlertsUC.Execute(usecases.GetAlertsDTO{
+		Tenant:     requestTenant(c),
+		RuleID:     c.Query("rule_id"),
+		Pagination: parsePagination(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	setPageLinks(c, page.NextCursor)
+	c.JSON(http.StatusOK, toAlertPageResponse(page))
+}
+
+// TestChannel godoc
+// @Summary      Test an alert channel
+// @Description  Sends a sample alert through a configured channel (log, email or slack) to check its configuration. Nothing is recorded
+// @Tags         alerts
+// @Produce      json
+// @Param        name  path      string  true  "Channel name"  Enums(log, email, slack)
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      204  "No Content"
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /alerts/channels/{name}/test [post]
+func (h *AlertHandler) TestChannel(c *gin.Context) {
+	if domainErr := h.testChannelUC.Execute(c.Param("name")); domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"log"
 
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -18,20 +20,85 @@ func (g GinApp) Run() {
 	}
 }
 
-func NewGinApp(handler *ProductStockHandler) GinApp {
+// Handlers groups the handlers of the routes, one per feature.
+type Handlers struct {
+	ProductStock *ProductStockHandler
+	Search       *SearchHandler
+	Import       *ImportHandler
+	Export       *ExportHandler
+	Batch        *BatchHandler
+	Restock      *RestockHandler
+	Audit        *AuditHandler
+	Stream       *StreamHandler
+	Webhooks     *WebhookHandler
+	Alerts       *AlertHandler
+	Jobs         *JobHandler
+}
+
+func NewGinApp(h Handlers, authUC *usecases.AuthenticateUseCase, tenantUC *usecases.ResolveTenantUseCase, idempotencyUC *usecases.IdempotentRequestUseCase) GinApp {
 	r := gin.Default()
 
-	stock := r.Group("/stock")
+	api := r.Group("", authenticationMiddleware(authUC), tenantMiddleware(tenantUC))
+	idempotent := idempotencyMiddleware(idempotencyUC)
+
+	stock := api.Group("/stock")
+	{
+		stock.POST("", requireRole(auth.Clerk), idempotent, h.ProductStock.Create)
+		stock.GET("", requireRole(auth.Viewer), h.ProductStock.GetAll)
+		stock.GET("/search", requireRole(auth.Viewer), h.Search.Search)
+		stock.POST("/import", requireRole(auth.Clerk), idempotent, h.Import.Import)
+		stock.GET("/export", requireRole(auth.Viewer), h.Export.Export)
+		stock.POST("/batch", requireRole(auth.Clerk), idempotent, h.Batch.Batch)
+		stock.GET("/:id", requireRole(auth.Viewer), h.ProductStock.GetOne)
+		stock.PUT("/:id", requireRole(auth.Clerk), idempotent, h.ProductStock.Update)
+		stock.DELETE("/:id", requireRole(auth.Admin), idempotent, h.ProductStock.Delete)
+		stock.POST("/:id/restore", requireRole(auth.Admin), idempotent, h.ProductStock.Restore)
+		stock.GET("/category/:category", requireRole(auth.Viewer), h.ProductStock.GetByCategory)
+		stock.GET("/by-sku/:sku", requireRole(auth.Viewer), h.Search.GetBySKU)
+		stock.GET("/by-barcode/:code", requireRole(auth.Viewer), h.Search.GetByBarcode)
+	}
+
+	restock := api.Group("/restock")
+	{
+		restock.GET("/priorities", requireRole(auth.Viewer), h.Restock.GetRestockPriorities)
+		restock.POST("/priorities/refresh", requireRole(auth.Buyer), idempotent, h.Restock.RefreshRestockPriorities)
+		restock.GET("/priorities/export", requireRole(auth.Viewer), h.Export.ExportRestockPriorities)
+		restock.POST("/plan", requireRole(auth.Buyer), idempotent, h.Restock.CreateRestockPlan)
+		restock.POST("/simulate", requireRole(auth.Viewer), idempotent, h.Restock.SimulateInventory)
+		restock.POST("/risk", requireRole(auth.Viewer), idempotent, h.Restock.EstimateStockoutRisk)
+	}
+
+	api.GET("/audit", requireRole(auth.Admin), h.Audit.GetAuditLog)
+
+	streams := api.Group("/stream", requireRole(auth.Viewer))
 	{
-		stock.POST("", handler.Create)
-		stock.GET("", handler.GetAll)
-		stock.GET("/:id", handler.GetOne)
-		stock.PUT("/:id", handler.Update)
-		stock.DELETE("/:id", handler.Delete)
-		stock.GET("/category/:category", handler.GetByCategory)
+		streams.GET("/stock", h.Stream.Stream)
+		streams.GET("/stock/ws", h.Stream.StreamWebSocket)
 	}
 
-	r.GET("/restock/priorities", handler.GetRestockPriorities)
+	hooks := api.Group("/webhooks", requireRole(auth.Admin), idempotent)
+	{
+		hooks.POST("", h.Webhooks.Create)
+		hooks.GET("", h.Webhooks.GetAll)
+		hooks.DELETE("/:id", h.Webhooks.Delete)
+		hooks.GET("/:id/deliveries", h.Webhooks.GetDeliveries)
+		hooks.POST("/:id/deliveries/:delivery_id/redeliver", h.Webhooks.Redeliver)
+	}
+
+	alerting := api.Group("/alerts")
+	{
+		alerting.GET("", requireRole(auth.Viewer), h.Alerts.GetAlerts)
+		alerting.POST("/rules", requireRole(auth.Admin), idempotent, h.Alerts.CreateRule)
+		alerting.GET("/rules", requireRole(auth.Admin), h.Alerts.GetRules)
+		alerting.DELETE("/rules/:id", requireRole(auth.Admin), idempotent, h.Alerts.DeleteRule)
+		alerting.POST("/channels/:name/test", requireRole(auth.Admin), idempotent, h.Alerts.TestChannel)
+	}
+
+	scheduled := api.Group("/jobs", requireRoleAcrossTenants(auth.Admin))
+	{
+		scheduled.GET("", h.Jobs.GetAll)
+		scheduled.GET("/:name/runs", h.Jobs.GetRuns)
+	}
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
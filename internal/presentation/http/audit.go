@@ -0,0 +1,124 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/audit"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditLogUC *usecases.GetAuditLogUseCase
+}
+
+func NewAuditHandler(auditLogUC *usecases.GetAuditLogUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditLogUC: auditLogUC,
+	}
+}
+
+// auditFieldChangeResponse represents the values of a field before and after a change.
+type auditFieldChangeResponse struct {
+	Field  string `json:"field" example:"current_stock"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// auditEntryResponse represents a change recorded in the audit log.
+type auditEntryResponse struct {
+	ID         int64                      `json:"id" example:"42"`
+	Actor      string                     `json:"actor" example:"jane@example.com"`
+	Operation  string                     `json:"operation" example:"update"`
+	EntityType string                     `json:"entity_type" example:"product_stock"`
+	EntityID   string                     `json:"entity_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Changes    []auditFieldChangeResponse `json:"changes"`
+	OccurredAt time.Time                  `json:"occurred_at" example:"2024-01-01T12:00:00Z"`
+}
+
+// auditLogPageResponse represents a page of the audit log.
+type auditLogPageResponse struct {
+	Items      []auditEntryResponse `json:"items"`
+	NextCursor *string              `json:"next_cursor" example:"eyJzIjoiYXVkaXQiLCJrIjpbNDJdfQ"`
+	Total      *int                 `json:"total,omitempty" example:"120"`
+}
+
+func toAuditLogPageResponse(page *domain.Page[audit.Entry]) auditLogPageResponse {
+	items := make([]auditEntryResponse, len(page.Items))
+	for i, entry := range page.Items {
+		items[i] = auditEntryResponse{
+			ID:         entry.ID,
+			Actor:      entry.Actor,
+			Operation:  string(entry.Operation),
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Changes:    make([]auditFieldChangeResponse, len(entry.Changes)),
+			OccurredAt: entry.OccurredAt,
+		}
+
+		for j, change := range entry.Changes {
+			items[i].Changes[j] = auditFieldChangeResponse(change)
+		}
+	}
+
+	return auditLogPageResponse{
+		Items:      items,
+		NextCursor: nextCursorResponse(page.NextCursor),
+		Total:      page.Total,
+	}
+}
+
+// GetAuditLog godoc
+// @Summary      List the audit log
+// @Description  Returns the recorded changes, newest first. Each entry has the actor (the authenticated caller of the request), the operation and the fields that changed with their values before and after
+// @Tags         audit
+// @Produce      json
+// @Param        entity_id  query     string  false  "Only changes to this entity"
+// @Param        actor      query     string  false  "Only changes made by this actor"
+// @Param        from       query     string  false  "Only changes at or after this time (RFC 3339)"  example(2024-01-01T00:00:00Z)
+// @Param        to         query     string  false  "Only changes before this time (RFC 3339)"  example(2024-02-01T00:00:00Z)
+// @Param        page       query     int     false  "Page number, ignored when a cursor is given"  default(1)
+// @Param        limit      query     int     false  "Items per page" default(20)
+// @Param        cursor     query     string  false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total      query     bool    false  "Include the total number of matching items"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  auditLogPageResponse
+// @Header       200  {string}  Link  "Links to the first and next pages"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /audit [get]
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	from, err := optionalQuery(c, "from", parseTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	to, err := optionalQuery(c, "to", parseTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, domainErr := h.auditLogUC.Execute(usecases.GetAuditLogDTO{
+		Tenant:     requestTenant(c),
+		EntityID:   c.Query("entity_id"),
+		Actor:      c.Query("actor"),
+		From:       from,
+		To:         to,
+		Pagination: parsePagination(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	setPageLinks(c, page.NextCursor)
+	c.JSON(http.StatusOK, toAuditLogPageResponse(page))
+}
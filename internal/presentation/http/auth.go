@@ -0,0 +1,85 @@
+package http
+
+import (
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	apiKeyHeader        = "X-API-Key"
+	actorHeader         = "X-Actor"
+	principalKey        = "principal"
+)
+
+// authenticationMiddleware identifies the caller from the Authorization
+// bearer token or the X-API-Key header and rejects anonymous requests.
+func authenticationMiddleware(uc *usecases.AuthenticateUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, domainErr := uc.Execute(usecases.AuthenticateDTO{
+			Authorization: c.GetHeader(authorizationHeader),
+			APIKey:        c.GetHeader(apiKeyHeader),
+			Actor:         c.GetHeader(actorHeader),
+		})
+		if domainErr != nil {
+			abortWithAuthError(c, domainErr)
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// requireRole lets through callers with at least the given role.
+func requireRole(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorizeRole(c, role)
+	}
+}
+
+// authorizeRole aborts the request with 403 and returns false unless the
+// caller has at least the given role.
+func authorizeRole(c *gin.Context, role auth.Role) bool {
+	if domainErr := auth.Authorize(requestPrincipal(c), role); domainErr != nil {
+		abortWithAuthError(c, domainErr)
+		return false
+	}
+
+	return true
+}
+
+// requireRoleAcrossTenants lets through callers with at least the given
+// role that are not bound to a tenant.
+func requireRoleAcrossTenants(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if domainErr := auth.AuthorizeAcrossTenants(requestPrincipal(c), role); domainErr != nil {
+			abortWithAuthError(c, domainErr)
+		}
+	}
+}
+
+func abortWithAuthError(c *gin.Context, domainErr *domain.Error) {
+	if domainErr.ErrCode == domain.ErrUnauthorized {
+		c.Header("WWW-Authenticate", auth.BearerScheme)
+	}
+
+	c.AbortWithStatusJSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+}
+
+func requestPrincipal(c *gin.Context) *auth.Principal {
+	principal, _ := c.Get(principalKey)
+	p, _ := principal.(*auth.Principal)
+	return p
+}
+
+// requestActor names who makes the request, as recorded in the audit log.
+func requestActor(c *gin.Context) string {
+	if principal := requestPrincipal(c); principal != nil {
+		return principal.Subject
+	}
+
+	return ""
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type BatchHandler struct {
+	batchUC *usecases.BatchProductStockUseCase
+}
+
+func NewBatchHandler(batchUC *usecases.BatchProductStockUseCase) *BatchHandler {
+	return &BatchHandler{
+		batchUC: batchUC,
+	}
+}
+
+type batchOperationRequest struct {
+	Op   string          `json:"op" binding:"required" example:"update"`
+	ID   string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type batchRequest struct {
+	Atomic     bool                    `json:"atomic"`
+	Operations []batchOperationRequest `json:"operations" binding:"required,dive"`
+}
+
+// toDTO decodes the data of each operation into the request of the
+// matching single-product endpoint, with the same validation.
+func (req batchRequest) toDTO() (usecases.BatchProductStockDTO, error) {
+	dto := usecases.BatchProductStockDTO{
+		Atomic:     req.Atomic,
+		Operations: make([]usecases.BatchOperationDTO, len(req.Operations)),
+	}
+
+	for i, op := range req.Operations {
+		operation := usecases.BatchOperationDTO{
+			Type: usecases.BatchOperationType(op.Op),
+			ID:   op.ID,
+		}
+
+		var err error
+		switch operation.Type {
+		case usecases.BatchCreate:
+			var create createProductStockRequest
+			err = decodeBatchData(op.Data, &create)
+			operation.Create = create.toDTO()
+		case usecases.BatchUpdate:
+			var update updateProductStockRequest
+			err = decodeBatchData(op.Data, &update)
+			operation.Update = update.toDTO(op.ID)
+		}
+
+		if err != nil {
+			return dto, fmt.Errorf("operation %d: %w", i, err)
+		}
+
+		dto.Operations[i] = operation
+	}
+
+	return dto, nil
+}
+
+func isBatchDelete(op usecases.BatchOperationDTO) bool {
+	return op.Type == usecases.BatchDelete
+}
+
+func decodeBatchData(data json.RawMessage, target any) error {
+	if len(data) == 0 {
+		return errors.New("data is required")
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid data: %w", err)
+	}
+
+	return binding.Validator.ValidateStruct(target)
+}
+
+type batchOperationResponse struct {
+	Op     string `json:"op" example:"update"`
+	ID     string `json:"id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Result string `json:"result" example:"applied" enums:"applied,failed,rolled_back,skipped"`
+	Error  string `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Atomic    bool                     `json:"atomic"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Results   []batchOperationResponse `json:"results"`
+}
+
+func toBatchResponse(report *usecases.BatchReport) batchResponse {
+	response := batchResponse{
+		Atomic:    report.Atomic,
+		Succeeded: report.Succeeded,
+		Failed:    report.Failed,
+		Results:   make([]batchOperationResponse, len(report.Results)),
+	}
+
+	for i, result := range report.Results {
+		item := batchOperationResponse{
+			Op:     string(result.Type),
+			ID:     result.ID,
+			Result: "applied",
+		}
+
+		switch {
+		case result.Err != nil:
+			item.Result = "failed"
+			item.Error = result.Err.Message
+		case result.RolledBack:
+			item.Result = "rolled_back"
+		case result.Skipped:
+			item.Result = "skipped"
+		}
+
+		response.Results[i] = item
+	}
+
+	return response
+}
+
+// Batch godoc
+// @Summary      Create, update and delete product stocks in batch
+// @Description  Applies a list of operations in order. "data" takes the body of POST /stock for creates and of PUT /stock/{id} for updates. With atomic=true the batch runs in a single transaction that stops at the first failure, whose status is returned; otherwise every operation is applied on its own and the response is 200 with a result per operation. Batches with deletes require the admin role
+// @Tags         stock
+// @Accept       json
+// @Produce      json
+// @Param        request  body      batchRequest   true  "Operations"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200      {object}  batchResponse
+// @Failure      400      {object}  batchResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      404      {object}  batchResponse
+// @Failure      409      {object}  batchResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/batch [post]
+func (h *BatchHandler) Batch(c *gin.Context) {
+	var req batchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := req.toDTO()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dto.Tenant = requestTenant(c)
+	dto.Actor = requestActor(c)
+
+	if slices.ContainsFunc(dto.Operations, isBatchDelete) && !authorizeRole(c, auth.Admin) {
+		return
+	}
+
+	report, domainErr := h.batchUC.Execute(dto)
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	status := http.StatusOK
+	if report.Atomic && report.Failed > 0 {
+		for _, result := range report.Results {
+			if result.Err != nil {
+				status = mapErrorToHTTPStatus(result.Err.ErrCode)
+			}
+		}
+	}
+
+	c.JSON(status, toBatchResponse(report))
+}
@@ -0,0 +1,282 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/gin-gonic/gin"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// exportStream writes an export as CSV or NDJSON, as negotiated from the
+// Accept header. Nothing is written until the first page arrives, so an
+// error raised before it (e.g. an invalid filter) still gets a regular
+// error response; a later error can only cut the stream short.
+type exportStream[T any] struct {
+	c         *gin.Context
+	filename  string
+	format    string
+	csvHeader []string
+	csvRow    func(T) []string
+	jsonItem  func(T) any
+	started   bool
+	csv       *csv.Writer
+	json      *json.Encoder
+}
+
+// newExportStream answers 406 and returns false when the client accepts
+// neither format.
+func newExportStream[T any](c *gin.Context, filename string, csvHeader []string, csvRow func(T) []string, jsonItem func(T) any) (*exportStream[T], bool) {
+	format := c.NegotiateFormat(csvContentType, ndjsonContentType)
+	if format == "" {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": "supported formats are " + csvContentType + " and " + ndjsonContentType})
+		return nil, false
+	}
+
+	return &exportStream[T]{
+		c:         c,
+		filename:  filename,
+		format:    format,
+		csvHeader: csvHeader,
+		csvRow:    csvRow,
+		jsonItem:  jsonItem,
+	}, true
+}
+
+func (s *exportStream[T]) start() {
+	s.started = true
+
+	extension := ".csv"
+	if s.format == ndjsonContentType {
+		extension = ".ndjson"
+	}
+
+	s.c.Header("Content-Type", s.format+"; charset=utf-8")
+	s.c.Header("Content-Disposition", `attachment; filename="`+s.filename+extension+`"`)
+	s.c.Status(http.StatusOK)
+
+	if s.format == ndjsonContentType {
+		s.json = json.NewEncoder(s.c.Writer)
+		return
+	}
+
+	s.csv = csv.NewWriter(s.c.Writer)
+	s.csv.Write(s.csvHeader)
+}
+
+// write is given to the export use cases as their yield function.
+func (s *exportStream[T]) write(items []T) *domain.Error {
+	if !s.started {
+		s.start()
+	}
+
+	for _, item := range items {
+		var err error
+		if s.json != nil {
+			err = s.json.Encode(s.jsonItem(item))
+		} else {
+			err = s.csv.Write(s.csvRow(item))
+		}
+
+		if err != nil {
+			return domain.NewError("failed to write export: "+err.Error(), domain.ErrInternal)
+		}
+	}
+
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return domain.NewError("failed to write export: "+err.Error(), domain.ErrInternal)
+		}
+	}
+
+	s.c.Writer.Flush()
+	return nil
+}
+
+func (s *exportStream[T]) finish(err *domain.Error) {
+	if err != nil {
+		if !s.started {
+			s.c.JSON(mapErrorToHTTPStatus(err.ErrCode), gin.H{"error": err.Message})
+			return
+		}
+
+		log.Printf("export %s interrupted: %s", s.filename, err.Message)
+		s.c.Abort()
+		return
+	}
+
+	s.write(nil)
+}
+
+var productStockCSVHeader = []string{
+	"id", "sku", "name", "category", "current_stock", "minimum_stock",
+	"average_daily_sales", "lead_time_days", "unit_cost", "criticality_level", "barcodes",
+}
+
+func productStockCSVRow(p *entities.ProductStock) []string {
+	response := toProductStockResponse(p)
+
+	sku := ""
+	if response.SKU != nil {
+		sku = *response.SKU
+	}
+
+	return []string{
+		response.ID,
+		sku,
+		csvText(response.Name),
+		response.Category,
+		strconv.Itoa(response.CurrentStock),
+		strconv.Itoa(response.MinimumStock),
+		strconv.Itoa(response.AverageDailySales),
+		strconv.Itoa(response.LeadTimeDays),
+		strconv.FormatFloat(response.UnitCost, 'f', -1, 64),
+		strconv.Itoa(response.CriticalityLevel),
+		strings.Join(response.Barcodes, "|"),
+	}
+}
+
+// deletedProductStockCSVHeader is used when deleted products are exported
+// too, so the regular export keeps its columns.
+var deletedProductStockCSVHeader = append(slices.Clone(productStockCSVHeader), "deleted_at")
+
+func deletedProductStockCSVRow(p *entities.ProductStock) []string {
+	deletedAt := ""
+	if p.DeletedAt != nil {
+		deletedAt = p.DeletedAt.UTC().Format(time.RFC3339)
+	}
+
+	return append(productStockCSVRow(p), deletedAt)
+}
+
+var restockPriorityCSVHeader = slices.Concat(productStockCSVHeader, []string{
+	"expected_consumption", "projected_stock", "urgency_score", "suggested_quantity",
+})
+
+func restockPriorityCSVRow(priority restock.Priority) []string {
+	return append(productStockCSVRow(priority.ProductStock),
+		strconv.Itoa(priority.ExpectedConsumption),
+		strconv.Itoa(priority.ProjectedStock),
+		strconv.Itoa(priority.UrgencyScore),
+		strconv.Itoa(priority.SuggestedQuantity),
+	)
+}
+
+func productStockExportItem(p *entities.ProductStock) any {
+	return toProductStockResponse(p)
+}
+
+func restockPriorityExportItem(priority restock.Priority) any {
+	return toRestockPriorityResponse(priority)
+}
+
+// csvText keeps spreadsheets from evaluating free text as a formula.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+type ExportHandler struct {
+	exportUC        *usecases.ExportProductStockUseCase
+	exportRestockUC *usecases.ExportRestockPrioritiesUseCase
+}
+
+func NewExportHandler(
+	exportUC *usecases.ExportProductStockUseCase,
+	exportRestockUC *usecases.ExportRestockPrioritiesUseCase,
+) *ExportHandler {
+	return &ExportHandler{
+		exportUC:        exportUC,
+		exportRestockUC: exportRestockUC,
+	}
+}
+
+// Export godoc
+// @Summary      Export product stocks
+// @Description  Streams every product stock matching the same filters as GET /stock, as CSV or NDJSON depending on the Accept header
+// @Tags         stock
+// @Produce      text/csv,application/x-ndjson
+// @Param        name             query     string    false  "Name contains (case insensitive)"
+// @Param        category         query     []string  false  "Categories (repeated or comma separated)"  collectionFormat(csv)
+// @Param        criticality_min  query     int       false  "Minimum criticality level"
+// @Param        criticality_max  query     int       false  "Maximum criticality level"
+// @Param        unit_cost_min    query     number    false  "Minimum unit cost"
+// @Param        unit_cost_max    query     number    false  "Maximum unit cost"
+// @Param        stock_min        query     int       false  "Minimum current stock"
+// @Param        stock_max        query     int       false  "Maximum current stock"
+// @Param        below_minimum    query     bool      false  "Only products whose current stock is (or is not) below the minimum stock"
+// @Param        needs_restock    query     bool      false  "Only products whose projected stock is (or is not) below the minimum stock"
+// @Param        sort             query     string    false  "Comma separated sort fields, prefixed with - for descending order"  example(-unit_cost,name)
+// @Param        include_deleted  query     bool      false  "Include soft deleted product stocks, adding a deleted_at column to the CSV (admin only)"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {string}  string  "CSV with a header row, or one JSON product stock per line"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      406  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/export [get]
+func (h *ExportHandler) Export(c *gin.Context) {
+	filter, err := parseProductStockFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.IncludeDeleted && !authorizeRole(c, auth.Admin) {
+		return
+	}
+
+	csvHeader, csvRow := productStockCSVHeader, productStockCSVRow
+	if filter.IncludeDeleted {
+		csvHeader, csvRow = deletedProductStockCSVHeader, deletedProductStockCSVRow
+	}
+
+	stream, ok := newExportStream(c, "stock", csvHeader, csvRow, productStockExportItem)
+	if !ok {
+		return
+	}
+
+	stream.finish(h.exportUC.Execute(requestTenant(c), filter, stream.write))
+}
+
+// ExportRestockPriorities godoc
+// @Summary      Export restock priorities
+// @Description  Streams every restock priority, with its computed fields, as CSV or NDJSON depending on the Accept header
+// @Tags         restock
+// @Produce      text/csv,application/x-ndjson
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {string}  string  "CSV with a header row, or one JSON restock priority per line"
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      406  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /restock/priorities/export [get]
+func (h *ExportHandler) ExportRestockPriorities(c *gin.Context) {
+	stream, ok := newExportStream(c, "restock_priorities", restockPriorityCSVHeader, restockPriorityCSVRow, restockPriorityExportItem)
+	if !ok {
+		return
+	}
+
+	stream.finish(h.exportRestockUC.Execute(requestTenant(c), stream.write))
+}
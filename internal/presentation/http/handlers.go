@@ -1,11 +1,17 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
 	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/entities"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,8 +21,8 @@ type ProductStockHandler struct {
 	getOneUC        *usecases.GetOneProductStockUseCase
 	updateUC        *usecases.UpdateProductStockUseCase
 	deleteUC        *usecases.DeleteProductStockUseCase
+	restoreUC       *usecases.RestoreProductStockUseCase
 	getByCategoryUC *usecases.GetByCategoryProductStockUseCase
-	getPriorityUC   *usecases.GetProductPriorityUseCase
 }
 
 func NewProductStockHandler(
@@ -25,8 +31,8 @@ func NewProductStockHandler(
 	getOneUC *usecases.GetOneProductStockUseCase,
 	updateUC *usecases.UpdateProductStockUseCase,
 	deleteUC *usecases.DeleteProductStockUseCase,
+	restoreUC *usecases.RestoreProductStockUseCase,
 	getByCategoryUC *usecases.GetByCategoryProductStockUseCase,
-	getPriorityUC *usecases.GetProductPriorityUseCase,
 ) *ProductStockHandler {
 	return &ProductStockHandler{
 		createUC:        createUC,
@@ -34,8 +40,8 @@ func NewProductStockHandler(
 		getOneUC:        getOneUC,
 		updateUC:        updateUC,
 		deleteUC:        deleteUC,
+		restoreUC:       restoreUC,
 		getByCategoryUC: getByCategoryUC,
-		getPriorityUC:   getPriorityUC,
 	}
 }
 
@@ -49,6 +55,12 @@ func mapErrorToHTTPStatus(errCode domain.ErrorCode) int {
 		return http.StatusBadRequest
 	case domain.ErrInternal:
 		return http.StatusInternalServerError
+	case domain.ErrUnprocessable:
+		return http.StatusUnprocessableEntity
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrForbidden:
+		return http.StatusForbidden
 	default:
 		return http.StatusInternalServerError
 	}
@@ -57,13 +69,145 @@ func mapErrorToHTTPStatus(errCode domain.ErrorCode) int {
 func parsePagination(c *gin.Context) domain.Pagination {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	includeTotal, _ := strconv.ParseBool(c.DefaultQuery("total", "false"))
 
 	return domain.Pagination{
-		Page:  page,
-		Limit: limit,
+		Page:         page,
+		Limit:        limit,
+		Cursor:       c.Query("cursor"),
+		IncludeTotal: includeTotal,
 	}
 }
 
+// setPageLinks advertises the first and next pages in a Link header (RFC
+// 8288), keeping every other query parameter of the request.
+func setPageLinks(c *gin.Context, nextCursor string) {
+	link := func(cursor, rel string) string {
+		query := c.Request.URL.Query()
+		query.Del("page")
+		query.Del("cursor")
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		target := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf("<%s>; rel=\"%s\"", target.String(), rel)
+	}
+
+	links := []string{link("", "first")}
+	if nextCursor != "" {
+		links = append(links, link(nextCursor, "next"))
+	}
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+func nextCursorResponse(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+
+	return &cursor
+}
+
+func optionalQuery[T any](c *gin.Context, key string, parse func(string) (T, error)) (*T, error) {
+	raw, ok := c.GetQuery(key)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	value, err := parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %q", key, raw)
+	}
+
+	return &value, nil
+}
+
+func parseTime(raw string) (time.Time, error) {
+	return time.Parse(time.RFC3339, raw)
+}
+
+func parseFloat(raw string) (float64, error) {
+	return strconv.ParseFloat(raw, 64)
+}
+
+// splitQuery reads a query parameter that is repeated or comma separated.
+func splitQuery(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for value := range strings.SplitSeq(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+func parseProductStockFilter(c *gin.Context) (usecases.ProductStockFilterDTO, error) {
+	filter := usecases.ProductStockFilterDTO{
+		NameContains: c.Query("name"),
+		Sort:         c.Query("sort"),
+		Categories:   splitQuery(c, "category"),
+	}
+
+	var err error
+	for _, p := range []struct {
+		key    string
+		target **int
+	}{
+		{"criticality_min", &filter.MinCriticality},
+		{"criticality_max", &filter.MaxCriticality},
+		{"stock_min", &filter.MinStock},
+		{"stock_max", &filter.MaxStock},
+	} {
+		if *p.target, err = optionalQuery(c, p.key, strconv.Atoi); err != nil {
+			return filter, err
+		}
+	}
+
+	for _, p := range []struct {
+		key    string
+		target **float64
+	}{
+		{"unit_cost_min", &filter.MinUnitCost},
+		{"unit_cost_max", &filter.MaxUnitCost},
+	} {
+		if *p.target, err = optionalQuery(c, p.key, parseFloat); err != nil {
+			return filter, err
+		}
+	}
+
+	for _, p := range []struct {
+		key    string
+		target **bool
+	}{
+		{"below_minimum", &filter.BelowMinimum},
+		{"needs_restock", &filter.NeedsRestock},
+	} {
+		if *p.target, err = optionalQuery(c, p.key, strconv.ParseBool); err != nil {
+			return filter, err
+		}
+	}
+
+	if filter.IncludeDeleted, err = parseIncludeDeleted(c); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func parseIncludeDeleted(c *gin.Context) (bool, error) {
+	includeDeleted, err := optionalQuery(c, "include_deleted", strconv.ParseBool)
+	if err != nil {
+		return false, err
+	}
+
+	return includeDeleted != nil && *includeDeleted, nil
+}
+
 type errorResponse struct {
 	Error string `json:"error" example:"error message"`
 }
@@ -83,15 +227,55 @@ type productStockResponse struct {
 	LeadTimeDays      int     `json:"lead_time_days" example:"7"`
 	UnitCost          float64 `json:"unit_cost" example:"25.50"`
 	CriticalityLevel  int     `json:"criticality_level" example:"3"`
+
+	SKU         *string           `json:"sku" example:"ENG-OF-001"`
+	Barcodes    []string          `json:"barcodes" example:"4006381333931"`
+	ExternalIDs map[string]string `json:"external_ids"`
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2024-01-01T12:00:00Z"`
+}
+
+// productStockPageResponse represents a page of product stocks.
+type productStockPageResponse struct {
+	Items      []productStockResponse `json:"items"`
+	NextCursor *string                `json:"next_cursor" example:"eyJzIjoicHJvZHVjdHM6IiwiayI6WyI1NTBlODQwMCJdfQ"`
+	Total      *int                   `json:"total,omitempty" example:"120"`
+}
+
+func toProductStockPageResponse(page *domain.Page[*entities.ProductStock]) productStockPageResponse {
+	items := make([]productStockResponse, len(page.Items))
+	for i, p := range page.Items {
+		items[i] = toProductStockResponse(p)
+	}
+
+	return productStockPageResponse{
+		Items:      items,
+		NextCursor: nextCursorResponse(page.NextCursor),
+		Total:      page.Total,
+	}
 }
 
-// restockPriorityResponse represents a product restock priority.
-type restockPriorityResponse struct {
-	ExpectedConsumption int                  `json:"expected_consumption" example:"70"`
-	ProjectedStock      int                  `json:"projected_stock" example:"-20"`
-	IsRepositionNeeded  bool                 `json:"is_reposition_needed" example:"true"`
-	UrgencyScore        int                  `json:"urgency_score" example:"210"`
-	ProductStock        productStockResponse `json:"product_stock"`
+func toProductStockResponse(p *entities.ProductStock) productStockResponse {
+	response := productStockResponse{
+		Name:              p.Name,
+		Category:          string(p.Category),
+		CurrentStock:      p.CurrentStock,
+		MinimumStock:      p.MinimumStock,
+		AverageDailySales: p.AverageDailySales,
+		LeadTimeDays:      p.LeadTimeDays,
+		UnitCost:          p.UnitCost,
+		CriticalityLevel:  int(p.CriticalityLevel),
+		SKU:               p.Identifiers.SKU,
+		Barcodes:          p.Identifiers.Barcodes,
+		ExternalIDs:       p.Identifiers.ExternalIDs,
+		DeletedAt:         p.DeletedAt,
+	}
+
+	if p.ID != nil {
+		response.ID = *p.ID
+	}
+
+	return response
 }
 
 type createProductStockRequest struct {
@@ -103,6 +287,26 @@ type createProductStockRequest struct {
 	LeadTimeDays      int     `json:"lead_time_days"`
 	UnitCost          float64 `json:"unit_cost" binding:"required"`
 	CriticalityLevel  int     `json:"criticality_level" binding:"required"`
+
+	SKU         *string           `json:"sku" example:"ENG-OF-001"`
+	Barcodes    []string          `json:"barcodes" example:"4006381333931"`
+	ExternalIDs map[string]string `json:"external_ids"`
+}
+
+func (req createProductStockRequest) toDTO() usecases.CreateProductStockDTO {
+	return usecases.CreateProductStockDTO{
+		Name:              req.Name,
+		Category:          req.Category,
+		CurrentStock:      req.CurrentStock,
+		MinimumStock:      req.MinimumStock,
+		AverageDailySales: req.AverageDailySales,
+		LeadTimeDays:      req.LeadTimeDays,
+		UnitCost:          req.UnitCost,
+		CriticalityLevel:  req.CriticalityLevel,
+		SKU:               req.SKU,
+		Barcodes:          req.Barcodes,
+		ExternalIDs:       req.ExternalIDs,
+	}
 }
 
 // Create godoc
@@ -112,9 +316,15 @@ type createProductStockRequest struct {
 // @Accept       json
 // @Produce      json
 // @Param        request  body      createProductStockRequest  true  "Product stock data"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
 // @Success      201      {object}  createResponse
 // @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      409      {object}  errorResponse
 // @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /stock [post]
 func (h *ProductStockHandler) Create(c *gin.Context) {
 	var req createProductStockRequest
@@ -123,16 +333,11 @@ func (h *ProductStockHandler) Create(c *gin.Context) {
 		return
 	}
 
-	id, domainErr := h.createUC.Execute(usecases.CreateProductStockDTO{
-		Name:              req.Name,
-		Category:          req.Category,
-		CurrentStock:      req.CurrentStock,
-		MinimumStock:      req.MinimumStock,
-		AverageDailySales: req.AverageDailySales,
-		LeadTimeDays:      req.LeadTimeDays,
-		UnitCost:          req.UnitCost,
-		CriticalityLevel:  req.CriticalityLevel,
-	})
+	dto := req.toDTO()
+	dto.Tenant = requestTenant(c)
+	dto.Actor = requestActor(c)
+
+	id, domainErr := h.createUC.Execute(dto)
 	if domainErr != nil {
 		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
 		return
@@ -143,24 +348,58 @@ func (h *ProductStockHandler) Create(c *gin.Context) {
 
 // GetAll godoc
 // @Summary      List all product stocks
-// @Description  Returns a paginated list of all product stocks
+// @Description  Returns a paginated list of product stocks matching the filters, ordered by the given sort fields
 // @Tags         stock
 // @Produce      json
-// @Param        page   query     int  false  "Page number"   default(1)
-// @Param        limit  query     int  false  "Items per page" default(20)
-// @Success      200    {array}   productStockResponse
+// @Param        page             query     int       false  "Page number, ignored when a cursor is given"  default(1)
+// @Param        limit            query     int       false  "Items per page" default(20)
+// @Param        cursor           query     string    false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total            query     bool      false  "Include the total number of matching items"
+// @Param        name             query     string    false  "Name contains (case insensitive)"
+// @Param        category         query     []string  false  "Categories (repeated or comma separated)"  collectionFormat(csv)
+// @Param        criticality_min  query     int       false  "Minimum criticality level"
+// @Param        criticality_max  query     int       false  "Maximum criticality level"
+// @Param        unit_cost_min    query     number    false  "Minimum unit cost"
+// @Param        unit_cost_max    query     number    false  "Maximum unit cost"
+// @Param        stock_min        query     int       false  "Minimum current stock"
+// @Param        stock_max        query     int       false  "Maximum current stock"
+// @Param        below_minimum    query     bool      false  "Only products whose current stock is (or is not) below the minimum stock"
+// @Param        needs_restock    query     bool      false  "Only products whose projected stock is (or is not) below the minimum stock"
+// @Param        sort             query     string    false  "Comma separated sort fields, prefixed with - for descending order"  example(-unit_cost,name)
+// @Param        include_deleted  query     bool      false  "Include soft deleted product stocks (admin only)"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200    {object}  productStockPageResponse
+// @Header       200    {string}  Link  "Links to the first and next pages"
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      403    {object}  errorResponse
 // @Failure      500    {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /stock [get]
 func (h *ProductStockHandler) GetAll(c *gin.Context) {
-	pagination := parsePagination(c)
+	filter, err := parseProductStockFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.IncludeDeleted && !authorizeRole(c, auth.Admin) {
+		return
+	}
 
-	products, domainErr := h.getAllUC.Execute(pagination)
+	page, domainErr := h.getAllUC.Execute(usecases.GetAllProductStockDTO{
+		Tenant:     requestTenant(c),
+		Filter:     filter,
+		Pagination: parsePagination(c),
+	})
 	if domainErr != nil {
 		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	setPageLinks(c, page.NextCursor)
+	c.JSON(http.StatusOK, toProductStockPageResponse(page))
 }
 
 // GetOne godoc
@@ -168,22 +407,40 @@ func (h *ProductStockHandler) GetAll(c *gin.Context) {
 // @Description  Returns a single product stock by its ID
 // @Tags         stock
 // @Produce      json
-// @Param        id   path      string  true  "Product stock ID"
+// @Param        id               path      string  true   "Product stock ID"
+// @Param        include_deleted  query     bool    false  "Also find a soft deleted product stock (admin only)"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
 // @Success      200  {object}  productStockResponse
 // @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
 // @Failure      404  {object}  errorResponse
 // @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /stock/{id} [get]
 func (h *ProductStockHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
+	includeDeleted, err := parseIncludeDeleted(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	product, domainErr := h.getOneUC.Execute(id)
+	if includeDeleted && !authorizeRole(c, auth.Admin) {
+		return
+	}
+
+	product, domainErr := h.getOneUC.Execute(usecases.GetOneProductStockDTO{
+		Tenant:         requestTenant(c),
+		ID:             c.Param("id"),
+		IncludeDeleted: includeDeleted,
+	})
 	if domainErr != nil {
 		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
 		return
 	}
 
-	c.JSON(http.StatusOK, product)
+	c.JSON(http.StatusOK, toProductStockResponse(product))
 }
 
 type updateProductStockRequest struct {
@@ -193,6 +450,25 @@ type updateProductStockRequest struct {
 	LeadTimeDays      *int     `json:"lead_time_days"`
 	UnitCost          *float64 `json:"unit_cost"`
 	CriticalityLevel  *int     `json:"criticality_level"`
+
+	SKU         *string            `json:"sku"`
+	Barcodes    *[]string          `json:"barcodes"`
+	ExternalIDs *map[string]string `json:"external_ids"`
+}
+
+func (req updateProductStockRequest) toDTO(id string) usecases.UpdateProductStockDTO {
+	return usecases.UpdateProductStockDTO{
+		ID:                id,
+		CurrentStock:      req.CurrentStock,
+		MinimumStock:      req.MinimumStock,
+		AverageDailySales: req.AverageDailySales,
+		LeadTimeDays:      req.LeadTimeDays,
+		UnitCost:          req.UnitCost,
+		CriticalityLevel:  req.CriticalityLevel,
+		SKU:               req.SKU,
+		Barcodes:          req.Barcodes,
+		ExternalIDs:       req.ExternalIDs,
+	}
 }
 
 // Update godoc
@@ -203,10 +479,16 @@ type updateProductStockRequest struct {
 // @Produce      json
 // @Param        id       path      string                     true  "Product stock ID"
 // @Param        request  body      updateProductStockRequest  true  "Fields to update"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
 // @Success      204      "No Content"
 // @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
 // @Failure      404      {object}  errorResponse
+// @Failure      409      {object}  errorResponse
 // @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /stock/{id} [put]
 func (h *ProductStockHandler) Update(c *gin.Context) {
 	id := c.Param("id")
@@ -217,15 +499,11 @@ func (h *ProductStockHandler) Update(c *gin.Context) {
 		return
 	}
 
-	domainErr := h.updateUC.Execute(usecases.UpdateProductStockDTO{
-		ID:                id,
-		CurrentStock:      req.CurrentStock,
-		MinimumStock:      req.MinimumStock,
-		AverageDailySales: req.AverageDailySales,
-		LeadTimeDays:      req.LeadTimeDays,
-		UnitCost:          req.UnitCost,
-		CriticalityLevel:  req.CriticalityLevel,
-	})
+	dto := req.toDTO(id)
+	dto.Tenant = requestTenant(c)
+	dto.Actor = requestActor(c)
+
+	domainErr := h.updateUC.Execute(dto)
 	if domainErr != nil {
 		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
 		return
@@ -234,21 +512,59 @@ func (h *ProductStockHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// Restore godoc
+// @Summary      Restore a product stock
+// @Description  Restores a soft deleted product stock that was not purged yet
+// @Tags         stock
+// @Produce      json
+// @Param        id   path      string  true  "Product stock ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  productStockResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      409  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/{id}/restore [post]
+func (h *ProductStockHandler) Restore(c *gin.Context) {
+	product, domainErr := h.restoreUC.Execute(usecases.RestoreProductStockDTO{
+		Tenant: requestTenant(c),
+		ID:     c.Param("id"),
+		Actor:  requestActor(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toProductStockResponse(product))
+}
+
 // Delete godoc
 // @Summary      Delete a product stock
-// @Description  Deletes a product stock by its ID
+// @Description  Soft deletes a product stock by its ID. It can be restored until it is purged after the retention window
 // @Tags         stock
 // @Produce      json
 // @Param        id   path      string  true  "Product stock ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
 // @Success      204  "No Content"
 // @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
 // @Failure      404  {object}  errorResponse
 // @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /stock/{id} [delete]
 func (h *ProductStockHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	domainErr := h.deleteUC.Execute(id)
+	domainErr := h.deleteUC.Execute(usecases.DeleteProductStockDTO{
+		Tenant: requestTenant(c),
+		ID:     c.Param("id"),
+		Actor:  requestActor(c),
+	})
 	if domainErr != nil {
 		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
 		return
@@ -263,17 +579,26 @@ func (h *ProductStockHandler) Delete(c *gin.Context) {
 // @Tags         stock
 // @Produce      json
 // @Param        category  path      string  true   "Product category"
-// @Param        page      query     int     false  "Page number"    default(1)
+// @Param        page      query     int     false  "Page number, ignored when a cursor is given"  default(1)
 // @Param        limit     query     int     false  "Items per page" default(20)
-// @Success      200       {array}   productStockResponse
+// @Param        cursor    query     string  false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total     query     bool    false  "Include the total number of matching items"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200       {object}  productStockPageResponse
+// @Header       200       {string}  Link  "Links to the first and next pages"
 // @Failure      400       {object}  errorResponse
+// @Failure      401       {object}  errorResponse
+// @Failure      403       {object}  errorResponse
 // @Failure      500       {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /stock/category/{category} [get]
 func (h *ProductStockHandler) GetByCategory(c *gin.Context) {
 	category := c.Param("category")
 	pagination := parsePagination(c)
 
-	products, domainErr := h.getByCategoryUC.Execute(usecases.GetByCategoryDTO{
+	page, domainErr := h.getByCategoryUC.Execute(usecases.GetByCategoryDTO{
+		Tenant:     requestTenant(c),
 		Category:   category,
 		Pagination: pagination,
 	})
@@ -282,27 +607,6 @@ func (h *ProductStockHandler) GetByCategory(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
-}
-
-// GetRestockPriorities godoc
-// @Summary      Get restock priorities
-// @Description  Returns a paginated list of products that need restocking, sorted by urgency
-// @Tags         restock
-// @Produce      json
-// @Param        page   query     int  false  "Page number"    default(1)
-// @Param        limit  query     int  false  "Items per page" default(20)
-// @Success      200    {array}   restockPriorityResponse
-// @Failure      500    {object}  errorResponse
-// @Router       /restock/priorities [get]
-func (h *ProductStockHandler) GetRestockPriorities(c *gin.Context) {
-	pagination := parsePagination(c)
-
-	priorities, domainErr := h.getPriorityUC.Execute(pagination)
-	if domainErr != nil {
-		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
-		return
-	}
-
-	c.JSON(http.StatusOK, priorities)
+	setPageLinks(c, page.NextCursor)
+	c.JSON(http.StatusOK, toProductStockPageResponse(page))
 }
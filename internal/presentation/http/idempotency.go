@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotentBodySize bounds the body buffered to fingerprint the
+	// request, except for the uploads of POST /stock/import.
+	maxIdempotentBodySize = 1 << 20
+)
+
+// idempotencyMiddleware makes POST, PUT and DELETE requests carrying an
+// Idempotency-Key header safe to retry: the response of the first request
+// is stored and replayed to the retries. It runs after the role checks of
+// the route. Server errors and refused authorizations are not stored, so a
+// request that failed unexpectedly, or before the caller was granted the
+// role, can be retried with the same key.
+func idempotencyMiddleware(uc *usecases.IdempotentRequestUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		limit := int64(maxIdempotentBodySize)
+		if c.FullPath() == "/stock/import" {
+			limit = maxUploadSize
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body is larger than %d bytes", limit)})
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		dto := usecases.BeginIdempotentRequestDTO{
+			Tenant:      requestTenant(c),
+			Actor:       requestActor(c),
+			Key:         key,
+			Fingerprint: requestFingerprint(c.Request, body),
+		}
+
+		stored, domainErr := uc.Begin(dto)
+		if domainErr != nil {
+			c.AbortWithStatusJSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+			return
+		}
+
+		if stored != nil {
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				releaseIdempotencyKey(uc, dto.ID())
+				panic(recovered)
+			}
+		}()
+
+		c.Next()
+
+		if status := recorder.Status(); status >= http.StatusInternalServerError || status == http.StatusUnauthorized || status == http.StatusForbidden {
+			releaseIdempotencyKey(uc, dto.ID())
+			return
+		}
+
+		if err := uc.Complete(dto.ID(), repository.IdempotentResponse{
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}); err != nil {
+			log.Printf("failed to store response of idempotency key %s: %s", key, err.Message)
+		}
+	}
+}
+
+func releaseIdempotencyKey(uc *usecases.IdempotentRequestUseCase, id repository.IdempotencyKeyID) {
+	if err := uc.Release(id); err != nil {
+		log.Printf("failed to release idempotency key %s: %s", id.Key, err.Message)
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete
+}
+
+// requestFingerprint identifies a request by its method, URL and body. Keys
+// are already scoped to the tenant and caller that sent them.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body written through it.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
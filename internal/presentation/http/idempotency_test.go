@@ -0,0 +1,92 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/auth"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/infraestructure/repository/memory"
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotencyMiddlewareLimitsBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(idempotencyMiddleware(usecases.NewIdempotentRequestUseCase(memory.NewIdempotencyKeyRepository(), time.Hour)))
+	echoSize := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"size": len(body)})
+	}
+	r.POST("/stock", echoSize)
+	r.POST("/stock/import", echoSize)
+
+	tests := []struct {
+		path string
+		size int
+		want int
+	}{
+		{"/stock", maxIdempotentBodySize, http.StatusOK},
+		{"/stock", maxIdempotentBodySize + 1, http.StatusRequestEntityTooLarge},
+		{"/stock/import", maxIdempotentBodySize + 1, http.StatusOK},
+		{"/stock/import", maxUploadSize + 1, http.StatusRequestEntityTooLarge},
+	}
+
+	for i, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(make([]byte, tt.size)))
+		req.Header.Set(idempotencyKeyHeader, string(rune('a'+i)))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.want {
+			t.Errorf("POST %s with %d bytes = %d, want %d", tt.path, tt.size, w.Code, tt.want)
+		}
+	}
+}
+
+func TestIdempotencyMiddlewareDoesNotStoreRefusedAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(principalKey, &auth.Principal{Subject: "jane", Role: auth.Role(c.GetHeader("X-Test-Role"))})
+	})
+	r.Use(idempotencyMiddleware(usecases.NewIdempotentRequestUseCase(memory.NewIdempotencyKeyRepository(), time.Hour)))
+	r.POST("/stock/batch", func(c *gin.Context) {
+		if !authorizeRole(c, auth.Admin) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"succeeded": 1})
+	})
+
+	send := func(role auth.Role) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/stock/batch", strings.NewReader(`{"operations":[]}`))
+		req.Header.Set(idempotencyKeyHeader, "batch-1")
+		req.Header.Set("X-Test-Role", string(role))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(auth.Clerk); w.Code != http.StatusForbidden {
+		t.Fatalf("clerk got %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	// Once granted the role, the caller retries with the same key.
+	w := send(auth.Admin)
+	if w.Code != http.StatusOK || w.Header().Get(idempotentReplayedHeader) != "" {
+		t.Fatalf("admin got %d, replayed %q, want a fresh %d", w.Code, w.Header().Get(idempotentReplayedHeader), http.StatusOK)
+	}
+
+	if w := send(auth.Admin); w.Header().Get(idempotentReplayedHeader) != "true" {
+		t.Fatalf("retry got %d without replay, want the stored response", w.Code)
+	}
+}
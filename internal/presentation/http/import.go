@@ -0,0 +1,110 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	importUC *usecases.ImportProductStockUseCase
+}
+
+func NewImportHandler(importUC *usecases.ImportProductStockUseCase) *ImportHandler {
+	return &ImportHandler{
+		importUC: importUC,
+	}
+}
+
+type importRowResponse struct {
+	Row    int      `json:"row" example:"2"`
+	Action string   `json:"action" example:"create"`
+	ID     *string  `json:"id"`
+	SKU    *string  `json:"sku"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type importReportResponse struct {
+	DryRun    bool                `json:"dry_run"`
+	Committed bool                `json:"committed"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Failed    int                 `json:"failed"`
+	Rows      []importRowResponse `json:"rows"`
+}
+
+func toImportReportResponse(report *usecases.ImportReport) importReportResponse {
+	response := importReportResponse{
+		DryRun:    report.DryRun,
+		Committed: report.Committed,
+		Created:   report.Created,
+		Updated:   report.Updated,
+		Failed:    report.Failed,
+		Rows:      make([]importRowResponse, len(report.Rows)),
+	}
+
+	for i, row := range report.Rows {
+		response.Rows[i] = importRowResponse{
+			Row:    row.Row,
+			Action: string(row.Action),
+			ID:     row.ID,
+			SKU:    row.SKU,
+			Errors: row.Errors,
+		}
+	}
+
+	return response
+}
+
+// Import godoc
+// @Summary      Import product stocks
+// @Description  Creates or updates product stocks from a CSV or XLSX file, sent as the "file" field of a multipart form or as the request body. Rows whose sku matches an existing product update it. The import is written in a single transaction and only when every row is valid; with dry_run=true nothing is written
+// @Tags         stock
+// @Accept       text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    false  "CSV or XLSX file"
+// @Param        dry_run  query     bool    false  "Only validate the file"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200      {object}  importReportResponse
+// @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      422      {object}  importReportResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/import [post]
+func (h *ImportHandler) Import(c *gin.Context) {
+	dryRun, err := optionalQuery(c, "dry_run", strconv.ParseBool)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	header, rows, err := readUploadedTable(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, domainErr := h.importUC.Execute(usecases.ImportProductStockDTO{
+		Tenant: requestTenant(c),
+		Header: header,
+		Rows:   rows,
+		DryRun: dryRun != nil && *dryRun,
+		Actor:  requestActor(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	status := http.StatusOK
+	if !report.DryRun && !report.Committed {
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(status, toImportReportResponse(report))
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/job"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	getJobsUC *usecases.GetJobsUseCase
+	getRunsUC *usecases.GetJobRunsUseCase
+}
+
+func NewJobHandler(getJobsUC *usecases.GetJobsUseCase, getRunsUC *usecases.GetJobRunsUseCase) *JobHandler {
+	return &JobHandler{
+		getJobsUC: getJobsUC,
+		getRunsUC: getRunsUC,
+	}
+}
+
+type jobRunResponse struct {
+	ID         int64      `json:"id" example:"42"`
+	Job        string     `json:"job" example:"purge_deleted_products"`
+	Instance   string     `json:"instance" example:"inventory-app-1:1"`
+	Status     string     `json:"status" example:"succeeded"`
+	Result     *string    `json:"result" example:"purged 3 deleted products"`
+	Error      *string    `json:"error" example:"failed to purge deleted products"`
+	StartedAt  time.Time  `json:"started_at" example:"2024-01-01T12:00:00Z"`
+	FinishedAt *time.Time `json:"finished_at" example:"2024-01-01T12:00:01Z"`
+}
+
+type jobResponse struct {
+	Name        string          `json:"name" example:"purge_deleted_products"`
+	Description string          `json:"description" example:"Deletes for good the products deleted longer than the retention ago"`
+	Schedule    string          `json:"schedule" example:"@hourly"`
+	NextRunAt   time.Time       `json:"next_run_at" example:"2024-01-01T13:00:00Z"`
+	LastRun     *jobRunResponse `json:"last_run"`
+}
+
+type jobsResponse struct {
+	Items []jobResponse `json:"items"`
+}
+
+type jobRunPageResponse struct {
+	Items      []jobRunResponse `json:"items"`
+	NextCursor *string          `json:"next_cursor" example:"eyJzIjoiam9iX3J1bnMiLCJrIjpbNDJdfQ"`
+	Total      *int             `json:"total,omitempty" example:"120"`
+}
+
+func toJobRunResponse(r job.Run) jobRunResponse {
+	return jobRunResponse{
+		ID:         r.ID,
+		Job:        r.Job,
+		Instance:   r.Instance,
+		Status:     string(r.Status),
+		Result:     r.Result,
+		Error:      r.Error,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+	}
+}
+
+func toJobResponse(s usecases.JobStatus) jobResponse {
+	res := jobResponse{
+		Name:        s.Job.Name,
+		Description: s.Job.Description,
+		Schedule:    s.Job.Spec,
+		NextRunAt:   s.NextRunAt,
+	}
+
+	if s.LastRun != nil {
+		lastRun := toJobRunResponse(*s.LastRun)
+		res.LastRun = &lastRun
+	}
+
+	return res
+}
+
+func toJobRunPageResponse(page *domain.Page[job.Run]) jobRunPageResponse {
+	items := make([]jobRunResponse, len(page.Items))
+	for i, r := range page.Items {
+		items[i] = toJobRunResponse(r)
+	}
+
+	return jobRunPageResponse{
+		Items:      items,
+		NextCursor: nextCursorResponse(page.NextCursor),
+		Total:      page.Total,
+	}
+}
+
+// GetAll godoc
+// @Summary      List the scheduled jobs
+// @Description  Returns the background jobs of the application with their schedule, the time of their next run and the outcome of their last one, made by any instance. Jobs work across tenants, so only admins not bound to a tenant can see them
+// @Tags         jobs
+// @Produce      json
+// @Success      200  {object}  jobsResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /jobs [get]
+func (h *JobHandler) GetAll(c *gin.Context) {
+	statuses, domainErr := h.getJobsUC.Execute()
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	items := make([]jobResponse, len(statuses))
+	for i, s := range statuses {
+		items[i] = toJobResponse(s)
+	}
+
+	c.JSON(http.StatusOK, jobsResponse{Items: items})
+}
+
+// GetRuns godoc
+// @Summary      List the runs of a job
+// @Description  Returns the runs of a scheduled job, newest first. Running runs are in progress, or were interrupted when no instance holds the job anymore
+// @Tags         jobs
+// @Produce      json
+// @Param        name    path      string  true   "Job name"
+// @Param        page    query     int     false  "Page number, ignored when a cursor is given"  default(1)
+// @Param        limit   query     int     false  "Items per page" default(20)
+// @Param        cursor  query     string  false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total   query     bool    false  "Include the total number of matching items"
+// @Success      200  {object}  jobRunPageResponse
+// @Header       200  {string}  Link  "Links to the first and next pages"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /jobs/{name}/runs [get]
+func (h *JobHandler) GetRuns(c *gin.Context) {
+	page, domainErr := h.getRunsUC.Execute(usecases.GetJobRunsDTO{
+		Tenant:     requestTenant(c),
+		Name:       c.Param("name"),
+		Pagination: parsePagination(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	setPageLinks(c, page.NextCursor)
+	c.JSON(http.StatusOK, toJobRunPageResponse(page))
+}
@@ -0,0 +1,427 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/restock"
+	"github.com/gin-gonic/gin"
+)
+
+type RestockHandler struct {
+	getPriorityUC  *usecases.GetProductPriorityUseCase
+	refreshUC      *usecases.RefreshRestockPrioritySnapshotUseCase
+	restockPlanUC  *usecases.CreateRestockPlanUseCase
+	simulateUC     *usecases.SimulateInventoryUseCase
+	stockoutRiskUC *usecases.EstimateStockoutRiskUseCase
+}
+
+func NewRestockHandler(
+	getPriorityUC *usecases.GetProductPriorityUseCase,
+	refreshUC *usecases.RefreshRestockPrioritySnapshotUseCase,
+	restockPlanUC *usecases.CreateRestockPlanUseCase,
+	simulateUC *usecases.SimulateInventoryUseCase,
+	stockoutRiskUC *usecases.EstimateStockoutRiskUseCase,
+) *RestockHandler {
+	return &RestockHandler{
+		getPriorityUC:  getPriorityUC,
+		refreshUC:      refreshUC,
+		restockPlanUC:  restockPlanUC,
+		simulateUC:     simulateUC,
+		stockoutRiskUC: stockoutRiskUC,
+	}
+}
+
+type restockPriorityResponse struct {
+	ExpectedConsumption int                  `json:"expected_consumption" example:"70"`
+	ProjectedStock      int                  `json:"projected_stock" example:"-20"`
+	IsRepositionNeeded  bool                 `json:"is_reposition_needed" example:"true"`
+	UrgencyScore        int                  `json:"urgency_score" example:"210"`
+	SuggestedQuantity   int                  `json:"suggested_quantity" example:"70"`
+	ProductStock        productStockResponse `json:"product_stock"`
+}
+
+// restockPrioritiesResponse represents a page of the restock priority snapshot.
+type restockPrioritiesResponse struct {
+	ComputedAt time.Time                 `json:"computed_at" example:"2024-01-01T12:00:00Z"`
+	Items      []restockPriorityResponse `json:"items"`
+	NextCursor *string                   `json:"next_cursor" example:"eyJzIjoicmVzdG9ja19wcmlvcml0aWVzIiwiayI6WzIxMF19"`
+	Total      *int                      `json:"total,omitempty" example:"42"`
+}
+
+func toRestockPriorityResponse(priority restock.Priority) restockPriorityResponse {
+	return restockPriorityResponse{
+		ExpectedConsumption: priority.ExpectedConsumption,
+		ProjectedStock:      priority.ProjectedStock,
+		IsRepositionNeeded:  priority.IsRepositionNeeded,
+		UrgencyScore:        priority.UrgencyScore,
+		SuggestedQuantity:   priority.SuggestedQuantity,
+		ProductStock:        toProductStockResponse(priority.ProductStock),
+	}
+}
+
+func toRestockPrioritiesResponse(priorities *usecases.RestockPriorities) restockPrioritiesResponse {
+	items := make([]restockPriorityResponse, len(priorities.Items))
+	for i, priority := range priorities.Items {
+		items[i] = toRestockPriorityResponse(priority)
+	}
+
+	return restockPrioritiesResponse{
+		ComputedAt: priorities.ComputedAt,
+		Items:      items,
+		NextCursor: nextCursorResponse(priorities.NextCursor),
+		Total:      priorities.Total,
+	}
+}
+
+type restockPlanRequest struct {
+	Budget          float64            `json:"budget" binding:"required" example:"5000"`
+	CategoryBudgets map[string]float64 `json:"category_budgets"`
+	Objective       string             `json:"objective" example:"urgency" enums:"urgency,stockout_cost"`
+}
+
+// restockPlanLineResponse represents an order line of a restock plan.
+type restockPlanLineResponse struct {
+	Quantity     int                  `json:"quantity" example:"25"`
+	Cost         float64              `json:"cost" example:"462.50"`
+	UrgencyScore int                  `json:"urgency_score" example:"75"`
+	StockoutCost float64              `json:"stockout_cost" example:"0"`
+	ProductStock productStockResponse `json:"product_stock"`
+}
+
+// restockPlanResponse represents the optimal set of order lines within a budget.
+type restockPlanResponse struct {
+	Objective             string                    `json:"objective" example:"urgency"`
+	Budget                float64                   `json:"budget" example:"5000"`
+	TotalCost             float64                   `json:"total_cost" example:"4987.50"`
+	TotalUrgency          int                       `json:"total_urgency" example:"640"`
+	StockoutCostAvoided   float64                   `json:"stockout_cost_avoided" example:"1250"`
+	RemainingStockoutCost float64                   `json:"remaining_stockout_cost" example:"310"`
+	Lines                 []restockPlanLineResponse `json:"lines"`
+}
+
+func toRestockPlanResponse(plan *usecases.RestockPlan) restockPlanResponse {
+	lines := make([]restockPlanLineResponse, len(plan.Lines))
+	for i, line := range plan.Lines {
+		lines[i] = restockPlanLineResponse{
+			Quantity:     line.Quantity,
+			Cost:         line.Cost,
+			UrgencyScore: line.UrgencyScore,
+			StockoutCost: line.StockoutCost,
+			ProductStock: toProductStockResponse(line.ProductStock),
+		}
+	}
+
+	return restockPlanResponse{
+		Objective:             string(plan.Objective),
+		Budget:                plan.Budget,
+		TotalCost:             plan.TotalCost,
+		TotalUrgency:          plan.TotalUrgency,
+		StockoutCostAvoided:   plan.StockoutCostAvoided,
+		RemainingStockoutCost: plan.RemainingStockoutCost,
+		Lines:                 lines,
+	}
+}
+
+type simulateInventoryRequest struct {
+	ProductID         string `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Category          string `json:"category" example:"engine"`
+	Days              int    `json:"days" binding:"required" example:"30"`
+	DemandCurve       []int  `json:"demand_curve"`
+	MinimumStock      *int   `json:"minimum_stock" example:"60"`
+	LeadTimeDays      *int   `json:"lead_time_days" example:"5"`
+	AverageDailySales *int   `json:"average_daily_sales" example:"12"`
+}
+
+// simulationDayResponse represents the inventory state at the end of a simulated day.
+type simulationDayResponse struct {
+	Day      int `json:"day" example:"1"`
+	Demand   int `json:"demand" example:"10"`
+	Sold     int `json:"sold" example:"10"`
+	Stockout int `json:"stockout" example:"0"`
+	Received int `json:"received" example:"0"`
+	Ordered  int `json:"ordered" example:"40"`
+	OnHand   int `json:"on_hand" example:"140"`
+	OnOrder  int `json:"on_order" example:"40"`
+}
+
+// productSimulationResponse represents the simulated series of a product.
+type productSimulationResponse struct {
+	TotalStockout    int                     `json:"total_stockout" example:"12"`
+	FirstStockoutDay int                     `json:"first_stockout_day" example:"9"`
+	ProductStock     productStockResponse    `json:"product_stock"`
+	Series           []simulationDayResponse `json:"series"`
+}
+
+// inventorySimulationResponse represents a what-if simulation of the inventory.
+type inventorySimulationResponse struct {
+	Days     int                         `json:"days" example:"30"`
+	Totals   []simulationDayResponse     `json:"totals"`
+	Products []productSimulationResponse `json:"products"`
+}
+
+func toSimulationDaysResponse(series []restock.SimulationDay) []simulationDayResponse {
+	days := make([]simulationDayResponse, len(series))
+	for i, day := range series {
+		days[i] = simulationDayResponse(day)
+	}
+
+	return days
+}
+
+func toInventorySimulationResponse(simulation *usecases.InventorySimulation) inventorySimulationResponse {
+	products := make([]productSimulationResponse, len(simulation.Products))
+	for i, product := range simulation.Products {
+		products[i] = productSimulationResponse{
+			TotalStockout:    product.TotalStockout,
+			FirstStockoutDay: product.FirstStockoutDay,
+			ProductStock:     toProductStockResponse(product.ProductStock),
+			Series:           toSimulationDaysResponse(product.Series),
+		}
+	}
+
+	return inventorySimulationResponse{
+		Days:     simulation.Days,
+		Totals:   toSimulationDaysResponse(simulation.Totals),
+		Products: products,
+	}
+}
+
+type distributionRequest struct {
+	Kind   string  `json:"kind" example:"normal" enums:"fixed,poisson,normal,uniform,triangular"`
+	Spread float64 `json:"spread" example:"0.25"`
+}
+
+type stockoutRiskRequest struct {
+	ProductID  string              `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Category   string              `json:"category" example:"engine"`
+	Trials     int                 `json:"trials" example:"1000"`
+	Seed       *uint64             `json:"seed" example:"42"`
+	Confidence float64             `json:"confidence" example:"0.9"`
+	Demand     distributionRequest `json:"demand"`
+	LeadTime   distributionRequest `json:"lead_time"`
+}
+
+// productStockoutRiskResponse represents the estimated stockout risk of a product.
+type productStockoutRiskResponse struct {
+	StockoutProbability     float64              `json:"stockout_probability" example:"0.37"`
+	BelowMinimumProbability float64              `json:"below_minimum_probability" example:"0.81"`
+	MeanProjectedStock      float64              `json:"mean_projected_stock" example:"4.2"`
+	ProjectedStockLower     float64              `json:"projected_stock_lower" example:"-11"`
+	ProjectedStockUpper     float64              `json:"projected_stock_upper" example:"18"`
+	ProductStock            productStockResponse `json:"product_stock"`
+}
+
+// stockoutRiskResponse represents a Monte Carlo stockout risk estimation.
+type stockoutRiskResponse struct {
+	Trials     int                           `json:"trials" example:"1000"`
+	Seed       uint64                        `json:"seed" example:"42"`
+	Confidence float64                       `json:"confidence" example:"0.9"`
+	Products   []productStockoutRiskResponse `json:"products"`
+}
+
+func toStockoutRiskResponse(report *usecases.StockoutRiskReport) stockoutRiskResponse {
+	products := make([]productStockoutRiskResponse, len(report.Products))
+	for i, risk := range report.Products {
+		products[i] = productStockoutRiskResponse{
+			StockoutProbability:     risk.StockoutProbability,
+			BelowMinimumProbability: risk.BelowMinimumProbability,
+			MeanProjectedStock:      risk.MeanProjectedStock,
+			ProjectedStockLower:     risk.ProjectedStockLower,
+			ProjectedStockUpper:     risk.ProjectedStockUpper,
+			ProductStock:            toProductStockResponse(risk.ProductStock),
+		}
+	}
+
+	return stockoutRiskResponse{
+		Trials:     report.Trials,
+		Seed:       report.Seed,
+		Confidence: report.Confidence,
+		Products:   products,
+	}
+}
+
+// GetRestockPriorities godoc
+// @Summary      Get restock priorities
+// @Description  Returns a paginated list of products that need restocking, sorted by urgency, served from a snapshot that is updated whenever a product changes. computed_at tells when the snapshot last changed
+// @Tags         restock
+// @Produce      json
+// @Param        page    query     int     false  "Page number, ignored when a cursor is given"  default(1)
+// @Param        limit   query     int     false  "Items per page" default(20)
+// @Param        cursor  query     string  false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total   query     bool    false  "Include the total number of items"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200     {object}  restockPrioritiesResponse
+// @Header       200     {string}  Link  "Links to the first and next pages"
+// @Failure      400     {object}  errorResponse
+// @Failure      401     {object}  errorResponse
+// @Failure      403     {object}  errorResponse
+// @Failure      500     {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /restock/priorities [get]
+func (h *RestockHandler) GetRestockPriorities(c *gin.Context) {
+	pagination := parsePagination(c)
+
+	priorities, domainErr := h.getPriorityUC.Execute(requestTenant(c), pagination)
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	setPageLinks(c, priorities.NextCursor)
+	c.JSON(http.StatusOK, toRestockPrioritiesResponse(priorities))
+}
+
+// RefreshRestockPriorities godoc
+// @Summary      Refresh restock priorities
+// @Description  Rebuilds the restock priority snapshot from the current stock
+// @Tags         restock
+// @Produce      json
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  refreshResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /restock/priorities/refresh [post]
+func (h *RestockHandler) RefreshRestockPriorities(c *gin.Context) {
+	computedAt, domainErr := h.refreshUC.Execute(requestTenant(c).ID)
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"computed_at": computedAt})
+}
+
+// CreateRestockPlan godoc
+// @Summary      Create a budget-constrained restock plan
+// @Description  Selects the order lines of the restock priority list that maximize the total urgency (or the stockout cost avoided) without exceeding the budget and the optional per-category budgets
+// @Tags         restock
+// @Accept       json
+// @Produce      json
+// @Param        request  body      restockPlanRequest  true  "Budget and objective"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200      {object}  restockPlanResponse
+// @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /restock/plan [post]
+func (h *RestockHandler) CreateRestockPlan(c *gin.Context) {
+	var req restockPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, domainErr := h.restockPlanUC.Execute(usecases.CreateRestockPlanDTO{
+		Tenant:          requestTenant(c),
+		Budget:          req.Budget,
+		CategoryBudgets: req.CategoryBudgets,
+		Objective:       req.Objective,
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRestockPlanResponse(plan))
+}
+
+// SimulateInventory godoc
+// @Summary      Simulate the inventory over time
+// @Description  Simulates the next days of inventory of a product or of a whole category under the current reorder policy, returning the day-by-day on-hand, on-order and stockout series. Minimum stock, lead time and average daily sales can be overridden to tune the policy before changing the product
+// @Tags         restock
+// @Accept       json
+// @Produce      json
+// @Param        request  body      simulateInventoryRequest  true  "Simulation parameters"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200      {object}  inventorySimulationResponse
+// @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      404      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /restock/simulate [post]
+func (h *RestockHandler) SimulateInventory(c *gin.Context) {
+	var req simulateInventoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	simulation, domainErr := h.simulateUC.Execute(usecases.SimulateInventoryDTO{
+		Tenant:            requestTenant(c),
+		ProductID:         req.ProductID,
+		Category:          req.Category,
+		Days:              req.Days,
+		DemandCurve:       req.DemandCurve,
+		MinimumStock:      req.MinimumStock,
+		LeadTimeDays:      req.LeadTimeDays,
+		AverageDailySales: req.AverageDailySales,
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toInventorySimulationResponse(simulation))
+}
+
+// EstimateStockoutRisk godoc
+// @Summary      Estimate stockout risk
+// @Description  Samples demand and lead time from the given distributions over many trials and reports, per product, the probability of stocking out before the replenishment arrives and a confidence interval for the projected stock. Passing the same seed reproduces the same results
+// @Tags         restock
+// @Accept       json
+// @Produce      json
+// @Param        page     query     int                  false  "Page number"    default(1)
+// @Param        limit    query     int                  false  "Items per page" default(20)
+// @Param        request  body      stockoutRiskRequest  true   "Simulation parameters"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200      {object}  stockoutRiskResponse
+// @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      404      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /restock/risk [post]
+func (h *RestockHandler) EstimateStockoutRisk(c *gin.Context) {
+	var req stockoutRiskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, domainErr := h.stockoutRiskUC.Execute(usecases.EstimateStockoutRiskDTO{
+		Tenant:     requestTenant(c),
+		ProductID:  req.ProductID,
+		Category:   req.Category,
+		Trials:     req.Trials,
+		Seed:       req.Seed,
+		Confidence: req.Confidence,
+		Demand:     usecases.DistributionDTO(req.Demand),
+		LeadTime:   usecases.DistributionDTO(req.LeadTime),
+		Pagination: parsePagination(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toStockoutRiskResponse(report))
+}
+
+// refreshResponse represents the result of a snapshot refresh.
+type refreshResponse struct {
+	ComputedAt time.Time `json:"computed_at" example:"2024-01-01T12:00:00Z"`
+}
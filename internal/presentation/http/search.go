@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/repository"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchUC       *usecases.SearchProductStockUseCase
+	getBySKUUC     *usecases.GetBySKUProductStockUseCase
+	getByBarcodeUC *usecases.GetByBarcodeProductStockUseCase
+}
+
+func NewSearchHandler(
+	searchUC *usecases.SearchProductStockUseCase,
+	getBySKUUC *usecases.GetBySKUProductStockUseCase,
+	getByBarcodeUC *usecases.GetByBarcodeProductStockUseCase,
+) *SearchHandler {
+	return &SearchHandler{
+		searchUC:       searchUC,
+		getBySKUUC:     getBySKUUC,
+		getByBarcodeUC: getByBarcodeUC,
+	}
+}
+
+// restockPriorityResponse represents a product restock priority.
+type productStockSearchResultResponse struct {
+	Score        float64              `json:"score"`
+	ProductStock productStockResponse `json:"product_stock"`
+}
+
+type productStockSearchResponse struct {
+	Items []productStockSearchResultResponse `json:"items"`
+}
+
+func toProductStockSearchResponse(results []repository.ProductStockSearchResult) productStockSearchResponse {
+	response := productStockSearchResponse{
+		Items: make([]productStockSearchResultResponse, len(results)),
+	}
+
+	for i, result := range results {
+		response.Items[i] = productStockSearchResultResponse{
+			Score:        result.Score,
+			ProductStock: toProductStockResponse(result.ProductStock),
+		}
+	}
+
+	return response
+}
+
+// Search godoc
+// @Summary      Search product stocks
+// @Description  Searches product stocks by name, tolerating partial words and typos, or by exact SKU, sorted by relevance
+// @Tags         stock
+// @Produce      json
+// @Param        q      query     string  true   "Search text"
+// @Param        page   query     int     false  "Page number"    default(1)
+// @Param        limit  query     int     false  "Items per page" default(20)
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200    {object}  productStockSearchResponse
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      403    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	results, domainErr := h.searchUC.Execute(usecases.SearchProductStockDTO{
+		Tenant:     requestTenant(c),
+		Query:      c.Query("q"),
+		Pagination: parsePagination(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toProductStockSearchResponse(results))
+}
+
+// GetBySKU godoc
+// @Summary      Get a product stock by SKU
+// @Description  Returns a single product stock by its SKU (case insensitive)
+// @Tags         stock
+// @Produce      json
+// @Param        sku  path      string  true  "Product SKU"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  productStockResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/by-sku/{sku} [get]
+func (h *SearchHandler) GetBySKU(c *gin.Context) {
+	product, domainErr := h.getBySKUUC.Execute(requestTenant(c), c.Param("sku"))
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toProductStockResponse(product))
+}
+
+// GetByBarcode godoc
+// @Summary      Get a product stock by barcode
+// @Description  Returns a single product stock by one of its barcodes, given as EAN-13 or UPC-A
+// @Tags         stock
+// @Produce      json
+// @Param        code  path      string  true  "EAN-13 or UPC-A barcode"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200   {object}  productStockResponse
+// @Failure      400   {object}  errorResponse
+// @Failure      401   {object}  errorResponse
+// @Failure      403   {object}  errorResponse
+// @Failure      404   {object}  errorResponse
+// @Failure      500   {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stock/by-barcode/{code} [get]
+func (h *SearchHandler) GetByBarcode(c *gin.Context) {
+	product, domainErr := h.getByBarcodeUC.Execute(requestTenant(c), c.Param("code"))
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, toProductStockResponse(product))
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+
+	streamHeartbeatInterval = 15 * time.Second
+	streamRetryMillis       = 3000
+	websocketWriteTimeout   = 10 * time.Second
+	// websocketPongTimeout closes connections whose client stopped
+	// answering the pings.
+	websocketPongTimeout = 2 * streamHeartbeatInterval
+)
+
+// The stream needs credentials in headers, which browsers cannot send
+// along a cross-site WebSocket handshake, so any origin can connect.
+var websocketUpgrader = websocket.Upgrader{
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+type StreamHandler struct {
+	streamUC *usecases.StockStreamUseCase
+}
+
+func NewStreamHandler(streamUC *usecases.StockStreamUseCase) *StreamHandler {
+	return &StreamHandler{
+		streamUC: streamUC,
+	}
+}
+
+// streamMessageResponse is a message of the stock stream sent over
+// WebSocket. Over SSE the id and the type are the id and the event fields.
+type streamMessageResponse struct {
+	ID   *int64 `json:"id,omitempty" example:"1042"`
+	Type string `json:"type" example:"stock.changed"`
+	Data any    `json:"data"`
+}
+
+// streamPrioritiesResponse holds the restock priorities streamed when they
+// change.
+type streamPrioritiesResponse struct {
+	Items []restockPriorityResponse `json:"items"`
+}
+
+func toStreamMessageResponse(m usecases.StreamMessage) streamMessageResponse {
+	res := streamMessageResponse{Type: m.Type, Data: struct{}{}}
+
+	if m.ID != 0 {
+		res.ID = &m.ID
+	}
+
+	switch {
+	case m.Event != nil:
+		res.Data = m.Event
+	case m.Type == usecases.PrioritiesMessage:
+		items := make([]restockPriorityResponse, len(m.Priorities))
+		for i, priority := range m.Priorities {
+			items[i] = toRestockPriorityResponse(priority)
+		}
+		res.Data = streamPrioritiesResponse{Items: items}
+	}
+
+	return res
+}
+
+func (h *StreamHandler) subscribe(c *gin.Context) (*usecases.StockStreamSubscription, bool) {
+	lastEventID := c.GetHeader(lastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	s, domainErr := h.streamUC.Subscribe(usecases.StockStreamDTO{
+		Tenant:      requestTenant(c),
+		Topics:      splitQuery(c, "topic"),
+		Categories:  splitQuery(c, "category"),
+		Limit:       limit,
+		LastEventID: lastEventID,
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return nil, false
+	}
+
+	return s, true
+}
+
+// Stream godoc
+// @Summary      Stream stock updates (SSE)
+// @Description  Pushes, as Server-Sent Events, the events of the products as they are committed (product.created, stock.changed, product.deleted, product.restored and restock.needed, whose data is the event) and the restock priorities (priorities), first as they are and then whenever they change. Event messages have an id; reconnecting with it in Last-Event-ID replays the events missed meanwhile, or sends reset when more than 1000 were missed. A comment is sent every 15 seconds to keep the connection open
+// @Tags         stream
+// @Produce      text/event-stream
+// @Param        topic          query     []string  false  "Topics to stream (repeated or comma separated), all by default"  Enums(stock, priorities)  collectionFormat(csv)
+// @Param        category       query     []string  false  "Only products of these categories (repeated or comma separated)"  collectionFormat(csv)
+// @Param        limit          query     int       false  "Number of restock priorities to stream"  default(20)
+// @Param        Last-Event-ID  header    string    false  "Id of the last event received, to resume the stream"
+// @Param        last_event_id  query     string    false  "Same as Last-Event-ID, for clients unable to set it"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {string}  string  "Stream of events"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stream/stock [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	s, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer s.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetryMillis)
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case m, ok := <-s.Messages():
+			if !ok {
+				return false
+			}
+			return writeServerSentEvent(w, m) == nil
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func writeServerSentEvent(w io.Writer, m usecases.StreamMessage) error {
+	res := toStreamMessageResponse(m)
+
+	data, err := json.Marshal(res.Data)
+	if err != nil {
+		return err
+	}
+
+	if res.ID != nil {
+		if _, err := fmt.Fprintf(w, "id: %d\n", *res.ID); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", res.Type, data)
+	return err
+}
+
+// StreamWebSocket godoc
+// @Summary      Stream stock updates (WebSocket)
+// @Description  Same as GET /stream/stock over a WebSocket. Every message is a JSON object with the id (events only), the type and the data. Messages from the client are ignored. The connection is closed with code 1013 when the client falls too far behind; it should reconnect with last_event_id to resume
+// @Tags         stream
+// @Param        topic          query     []string  false  "Topics to stream (repeated or comma separated), all by default"  Enums(stock, priorities)  collectionFormat(csv)
+// @Param        category       query     []string  false  "Only products of these categories (repeated or comma separated)"  collectionFormat(csv)
+// @Param        limit          query     int       false  "Number of restock priorities to stream"  default(20)
+// @Param        last_event_id  query     string    false  "Id of the last event received, to resume the stream"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      101  {object}  streamMessageResponse  "Switching to the WebSocket protocol"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /stream/stock/ws [get]
+func (h *StreamHandler) StreamWebSocket(c *gin.Context) {
+	s, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer s.Close()
+
+	// Upgrade answers failed handshakes itself.
+	conn, err := websocketUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Reading is needed to handle the pongs and notice the client leaving.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+
+		conn.SetReadLimit(512)
+		conn.SetReadDeadline(time.Now().Add(websocketPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(websocketPongTimeout))
+		})
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case m, ok := <-s.Messages():
+			if !ok {
+				closeMessage := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too far behind, reconnect to resume")
+				conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(websocketWriteTimeout))
+				return
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+			if err := conn.WriteJSON(toStreamMessageResponse(m)); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteTimeout)); err != nil {
+				return
+			}
+		case <-gone:
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	maxUploadSize   = 20 << 20
+	csvContentType  = "text/csv"
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// readUploadedTable reads a CSV or XLSX table either from the "file" field
+// of a multipart form or from the raw request body. The format comes from
+// the file extension or the content type and defaults to CSV. The first row
+// is returned as the header.
+func readUploadedTable(c *gin.Context) ([]string, [][]string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize)
+
+	var body io.Reader = c.Request.Body
+	format := c.ContentType()
+
+	if strings.HasPrefix(format, "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, nil, errors.New("the \"file\" form field is required")
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer file.Close()
+
+		body = file
+		format = header.Header.Get("Content-Type")
+		if strings.EqualFold(filepath.Ext(header.Filename), ".xlsx") {
+			format = xlsxContentType
+		}
+	}
+
+	var records [][]string
+	var err error
+
+	if format == xlsxContentType {
+		records, err = readXLSX(body)
+	} else {
+		records, err = readCSV(body)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil, errors.New("the file is empty")
+	}
+
+	return records[0], records[1:], nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid CSV: " + err.Error())
+	}
+
+	return records, nil
+}
+
+// readXLSX reads the first sheet of the workbook.
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, errors.New("invalid XLSX: " + err.Error())
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("the workbook has no sheets")
+	}
+
+	rows, err := file.GetRows(sheets[0])
+	if err != nil {
+		return nil, errors.New("invalid XLSX: " + err.Error())
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tenantHeader = "X-Tenant-ID"
+	tenantKey    = "tenant"
+)
+
+// tenantMiddleware resolves the tenant of the request from the caller's
+// credentials or the X-Tenant-ID header. It must run after authentication.
+func tenantMiddleware(uc *usecases.ResolveTenantUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, domainErr := uc.Execute(usecases.ResolveTenantDTO{
+			Principal: requestPrincipal(c),
+			Requested: c.GetHeader(tenantHeader),
+		})
+		if domainErr != nil {
+			c.AbortWithStatusJSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+			return
+		}
+
+		c.Set(tenantKey, *t)
+		c.Next()
+	}
+}
+
+func requestTenant(c *gin.Context) tenant.Tenant {
+	t, _ := c.Get(tenantKey)
+	resolved, _ := t.(tenant.Tenant)
+	return resolved
+}
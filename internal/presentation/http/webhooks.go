@@ -0,0 +1,275 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	usecases "github.com/danielalmeidafarias/go_stock_engine/internal/application"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/event"
+	"github.com/danielalmeidafarias/go_stock_engine/internal/domain/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	createUC     *usecases.CreateWebhookSubscriptionUseCase
+	getAllUC     *usecases.GetWebhookSubscriptionsUseCase
+	deleteUC     *usecases.DeleteWebhookSubscriptionUseCase
+	deliveriesUC *usecases.GetWebhookDeliveriesUseCase
+	redeliverUC  *usecases.RedeliverWebhookUseCase
+}
+
+func NewWebhookHandler(
+	createUC *usecases.CreateWebhookSubscriptionUseCase,
+	getAllUC *usecases.GetWebhookSubscriptionsUseCase,
+	deleteUC *usecases.DeleteWebhookSubscriptionUseCase,
+	deliveriesUC *usecases.GetWebhookDeliveriesUseCase,
+	redeliverUC *usecases.RedeliverWebhookUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		createUC:     createUC,
+		getAllUC:     getAllUC,
+		deleteUC:     deleteUC,
+		deliveriesUC: deliveriesUC,
+		redeliverUC:  redeliverUC,
+	}
+}
+
+type createWebhookRequest struct {
+	URL        string   `json:"url" binding:"required" example:"https://example.com/hooks/stock"`
+	EventTypes []string `json:"event_types" binding:"required" example:"stock.changed,restock.needed"`
+	Secret     string   `json:"secret" binding:"required" example:"a-long-random-shared-secret"`
+}
+
+// webhookResponse represents a webhook subscription. The secret is never returned.
+type webhookResponse struct {
+	ID         string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	URL        string    `json:"url" example:"https://example.com/hooks/stock"`
+	EventTypes []string  `json:"event_types" example:"stock.changed,restock.needed"`
+	CreatedAt  time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
+}
+
+type webhooksResponse struct {
+	Items []webhookResponse `json:"items"`
+}
+
+// webhookDeliveryResponse represents the delivery of an event to a webhook,
+// with the outcome of its last attempt.
+type webhookDeliveryResponse struct {
+	ID             int64       `json:"id" example:"42"`
+	Event          event.Event `json:"event"`
+	Status         string      `json:"status" example:"pending"`
+	Attempts       int         `json:"attempts" example:"2"`
+	NextAttemptAt  *time.Time  `json:"next_attempt_at" example:"2024-01-01T12:01:00Z"`
+	LastAttemptAt  *time.Time  `json:"last_attempt_at" example:"2024-01-01T12:00:30Z"`
+	LastStatusCode *int        `json:"last_status_code" example:"503"`
+	LastError      *string     `json:"last_error" example:"unexpected status 503"`
+	CreatedAt      time.Time   `json:"created_at" example:"2024-01-01T12:00:00Z"`
+	DeliveredAt    *time.Time  `json:"delivered_at" example:"2024-01-01T12:01:00Z"`
+}
+
+type webhookDeliveryPageResponse struct {
+	Items      []webhookDeliveryResponse `json:"items"`
+	NextCursor *string                   `json:"next_cursor" example:"eyJzIjoid2ViaG9va19kZWxpdmVyaWVzIiwiayI6WzQyXX0"`
+	Total      *int                      `json:"total,omitempty" example:"120"`
+}
+
+func toWebhookResponse(s webhook.Subscription) webhookResponse {
+	res := webhookResponse{
+		ID:         *s.ID,
+		URL:        s.URL,
+		EventTypes: make([]string, len(s.EventTypes)),
+		CreatedAt:  s.CreatedAt,
+	}
+
+	for i, t := range s.EventTypes {
+		res.EventTypes[i] = string(t)
+	}
+
+	return res
+}
+
+func toWebhookDeliveryResponse(d webhook.Delivery) webhookDeliveryResponse {
+	return webhookDeliveryResponse{
+		ID:             d.ID,
+		Event:          d.Event,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func toWebhookDeliveryPageResponse(page *domain.Page[webhook.Delivery]) webhookDeliveryPageResponse {
+	items := make([]webhookDeliveryResponse, len(page.Items))
+	for i, d := range page.Items {
+		items[i] = toWebhookDeliveryResponse(d)
+	}
+
+	return webhookDeliveryPageResponse{
+		Items:      items,
+		NextCursor: nextCursorResponse(page.NextCursor),
+		Total:      page.Total,
+	}
+}
+
+// Create godoc
+// @Summary      Subscribe a webhook
+// @Description  Subscribes a URL to the events of the given types of the tenant. Every event is posted as JSON, signed with the secret in the X-Webhook-Signature header, and retried with exponential backoff until it is acknowledged with a 2xx response
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        request  body      createWebhookRequest  true  "Webhook subscription"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      201      {object}  webhookResponse
+// @Failure      400      {object}  errorResponse
+// @Failure      401      {object}  errorResponse
+// @Failure      403      {object}  errorResponse
+// @Failure      500      {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s, domainErr := h.createUC.Execute(usecases.CreateWebhookSubscriptionDTO{
+		Tenant:     requestTenant(c),
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWebhookResponse(*s))
+}
+
+// GetAll godoc
+// @Summary      List the webhooks
+// @Description  Returns the webhook subscriptions of the tenant, oldest first
+// @Tags         webhooks
+// @Produce      json
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  webhooksResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /webhooks [get]
+func (h *WebhookHandler) GetAll(c *gin.Context) {
+	subscriptions, domainErr := h.getAllUC.Execute(requestTenant(c))
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	items := make([]webhookResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		items[i] = toWebhookResponse(s)
+	}
+
+	c.JSON(http.StatusOK, webhooksResponse{Items: items})
+}
+
+// Delete godoc
+// @Summary      Delete a webhook
+// @Description  Deletes a webhook subscription along with its deliveries, pending ones included
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      204  "No Content"
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	if domainErr := h.deleteUC.Execute(requestTenant(c), c.Param("id")); domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetDeliveries godoc
+// @Summary      List the deliveries of a webhook
+// @Description  Returns the deliveries of a webhook, newest first, with the outcome of their last attempt. Dead deliveries failed too many times and are only retried when redelivered
+// @Tags         webhooks
+// @Produce      json
+// @Param        id      path      string  true   "Webhook ID"
+// @Param        status  query     string  false  "Only deliveries with this status"  Enums(pending, succeeded, dead)
+// @Param        page    query     int     false  "Page number, ignored when a cursor is given"  default(1)
+// @Param        limit   query     int     false  "Items per page" default(20)
+// @Param        cursor  query     string  false  "Opaque cursor from next_cursor of the previous page"
+// @Param        total   query     bool    false  "Include the total number of matching items"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      200  {object}  webhookDeliveryPageResponse
+// @Header       200  {string}  Link  "Links to the first and next pages"
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	page, domainErr := h.deliveriesUC.Execute(usecases.GetWebhookDeliveriesDTO{
+		Tenant:         requestTenant(c),
+		SubscriptionID: c.Param("id"),
+		Status:         c.Query("status"),
+		Pagination:     parsePagination(c),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	setPageLinks(c, page.NextCursor)
+	c.JSON(http.StatusOK, toWebhookDeliveryPageResponse(page))
+}
+
+// Redeliver godoc
+// @Summary      Redeliver a webhook delivery
+// @Description  Queues a delivery again, dead or not, with a fresh count of attempts. The same event is posted again, with a new signature
+// @Tags         webhooks
+// @Produce      json
+// @Param        id           path      string  true  "Webhook ID"
+// @Param        delivery_id  path      int     true  "Delivery ID"
+// @Param        X-Tenant-ID  header  string  false  "Tenant to act on, defaults to the caller's tenant or \"default\""
+// @Success      202  {object}  webhookDeliveryResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      403  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	d, domainErr := h.redeliverUC.Execute(usecases.RedeliverWebhookDTO{
+		Tenant:         requestTenant(c),
+		SubscriptionID: c.Param("id"),
+		DeliveryID:     c.Param("delivery_id"),
+	})
+	if domainErr != nil {
+		c.JSON(mapErrorToHTTPStatus(domainErr.ErrCode), gin.H{"error": domainErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toWebhookDeliveryResponse(*d))
+}